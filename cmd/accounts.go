@@ -3,6 +3,7 @@ package cmd
 import (
 	"os"
 	"strings"
+	"time"
 
 	"github.com/ec-systems/core.ledger.server/pkg/client"
 	"github.com/ec-systems/core.ledger.server/pkg/config"
@@ -75,7 +76,25 @@ func addAccountsCmd(root *RootCommand) {
 				}
 			}
 
-			balances, err := l.Balance(cmd.Context(), holder, asset, types.AllAccounts, types.AllStatuses)
+			at, err := cmd.Flags().GetString("at")
+			if err != nil {
+				return err
+			}
+
+			var balances map[types.Asset]*types.Balance
+			if at == "" {
+				balances, err = l.Balance(cmd.Context(), holder, asset, types.AllAccounts, types.AllStatuses)
+			} else {
+				var t time.Time
+
+				t, err = time.Parse(time.RFC3339, at)
+				if err != nil {
+					return fmt.Errorf("invalid point in time %v: %v", at, err)
+				}
+
+				balances, err = l.BalanceAt(cmd.Context(), holder, asset, types.AllAccounts, t)
+			}
+
 			if err != nil {
 				return err
 			}
@@ -86,11 +105,12 @@ func addAccountsCmd(root *RootCommand) {
 			}
 
 			table := tablewriter.NewWriter(os.Stdout)
-			table.SetHeader([]string{"Holder", "Account", "Asset", "TX Count", "Balance"})
+			table.SetHeader([]string{"Holder", "Account", "Asset", "TX Count", "Balance", "Available", "Pending", "Finished", "Canceled"})
 
 			for asset, balance := range balances {
 				for account, acc := range balance.Accounts {
-					table.Append([]string{holder, account.String(), asset.String(), fmt.Sprintf("%v", acc.Count), acc.Sum.String()})
+					table.Append([]string{holder, account.String(), asset.String(), fmt.Sprintf("%v", acc.Count), acc.Sum.String(),
+						acc.Available.String(), acc.Pending().String(), acc.Finished().String(), acc.Canceled().String()})
 				}
 			}
 
@@ -103,6 +123,8 @@ func addAccountsCmd(root *RootCommand) {
 		},
 	}
 
+	cmd.Flags().String("at", "", "Show the balances at a point in time (RFC3339, e.g. 2026-09-30T23:59:59Z)")
+
 	//cfg := config.Configuration()
 
 	//root.bindCmdFlag(cmd.Flags(), "Worker.TaskQueueName", "queue")
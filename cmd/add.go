@@ -87,13 +87,17 @@ func addAddCmd(root *RootCommand) {
 
 			defer client.Close(cmd.Context())
 
-			assets := cfg.Assets
 			l := ledger.New(client,
-				ledger.SupportedAssets(assets),
+				ledger.SupportedAssets(cfg.Assets),
 				ledger.SupportedStatuses(cfg.Statuses),
 			)
 
-			asset, err := assets.Parse(args[1])
+			err = l.LoadAssets(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			asset, err := l.SupportedAssets().Parse(args[1])
 			if err != nil {
 				return err
 			}
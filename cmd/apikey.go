@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/spf13/cobra"
+)
+
+// addAPIKeyCmd creates and adds the api key command to Root
+func addAPIKeyCmd(root *RootCommand) {
+	cmd := &cobra.Command{
+		Use:   "apikey [key]",
+		Short: "Generate an api key and its hash for the service auth configuration",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var key string
+			var err error
+
+			if len(args) > 0 {
+				key = args[0]
+			} else {
+				key, err = ledger.NewAPIKey()
+				if err != nil {
+					return err
+				}
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Key:  %v\nHash: %v\n", key, ledger.HashAPIKey(key))
+
+			return nil
+		},
+	}
+
+	root.AddCommand(cmd)
+}
@@ -1,14 +1,17 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/ec-systems/core.ledger.server/pkg/client"
 	"github.com/ec-systems/core.ledger.server/pkg/config"
 	"github.com/ec-systems/core.ledger.server/pkg/ledger"
 	"github.com/ec-systems/core.ledger.server/pkg/types"
 	"github.com/olekukonko/tablewriter"
+	"github.com/shopspring/decimal"
 	"github.com/spf13/cobra"
 )
 
@@ -40,6 +43,11 @@ func addAssetsCmd(root *RootCommand) {
 				return err
 			}
 
+			err = l.LoadAssets(cmd.Context())
+			if err != nil {
+				return err
+			}
+
 			if showSupported {
 				prefix := ""
 				if len(args) > 0 {
@@ -47,19 +55,19 @@ func addAssetsCmd(root *RootCommand) {
 				}
 
 				table := tablewriter.NewWriter(cmd.OutOrStderr())
-				table.SetHeader([]string{"Symbol", "Asset"})
+				table.SetHeader([]string{"Symbol", "Asset", "Decimals", "Min", "Max", "Enabled"})
 				table.SetAlignment(tablewriter.ALIGN_LEFT)
 
-				assets := cfg.Assets
+				assets := l.SupportedAssets()
 
 				if prefix == "" {
 					for k, v := range assets {
-						table.Append([]string{k.String(), v})
+						table.Append(assetRow(k, v))
 					}
 				} else {
 					for k, v := range assets {
 						if strings.HasPrefix(k.String(), prefix) {
-							table.Append([]string{k.String(), v})
+							table.Append(assetRow(k, v))
 						}
 					}
 				}
@@ -74,7 +82,7 @@ func addAssetsCmd(root *RootCommand) {
 				if showBalance {
 					asset := types.AllAssets
 					if len(args) > 0 {
-						asset, err = cfg.Assets.Parse(args[0])
+						asset, err = l.SupportedAssets().Parse(args[0])
 						if err != nil {
 							return err
 						}
@@ -89,7 +97,7 @@ func addAssetsCmd(root *RootCommand) {
 					table.SetHeader([]string{"Symbol", "Asset", "Balance"})
 
 					for k, v := range balances {
-						table.Append([]string{k.String(), cfg.Assets.Name(k), v.String()})
+						table.Append([]string{k.String(), l.SupportedAssets().Name(k), v.String()})
 					}
 
 					table.Render()
@@ -103,7 +111,7 @@ func addAssetsCmd(root *RootCommand) {
 					}
 
 					for _, asset := range assets {
-						table.Append([]string{asset.String(), cfg.Assets.Name(asset)})
+						table.Append([]string{asset.String(), l.SupportedAssets().Name(asset)})
 					}
 
 					table.Render()
@@ -117,5 +125,169 @@ func addAssetsCmd(root *RootCommand) {
 	cmd.Flags().Bool("supported", false, "Show supported assets")
 	cmd.Flags().Bool("balance", false, "Show balance of used assets")
 
+	addAssetDefineCmd(cmd)
+	addAssetEnableCmd(cmd, "enable", "Enable an asset of the asset registry", true)
+	addAssetEnableCmd(cmd, "disable", "Disable an asset, new transactions of the asset are rejected", false)
+	addAssetHistoryCmd(cmd)
+
 	root.AddCommand(cmd)
 }
+
+func addAssetDefineCmd(parent *cobra.Command) {
+	cmd := &cobra.Command{
+		Use:   "define [symbol] [name]",
+		Short: "Add or replace an asset definition in the asset registry",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			definition := types.NewAssetDefinition(args[1])
+
+			if cmd.Flags().Changed("decimals") {
+				decimals, err := cmd.Flags().GetInt32("decimals")
+				if err != nil {
+					return err
+				}
+
+				definition.Decimals = types.Decimals(decimals)
+			}
+
+			for flag, amount := range map[string]*decimal.Decimal{"min": &definition.Min, "max": &definition.Max} {
+				value, err := cmd.Flags().GetString(flag)
+				if err != nil {
+					return err
+				}
+
+				if value != "" {
+					*amount, err = decimal.NewFromString(value)
+					if err != nil {
+						return fmt.Errorf("invalid %v amount %v: %v", flag, value, err)
+					}
+				}
+			}
+
+			disabled, err := cmd.Flags().GetBool("disabled")
+			if err != nil {
+				return err
+			}
+
+			definition.Enabled = !disabled
+
+			return registry(cmd, func(l *ledger.Ledger) (*ledger.RegisteredAsset, error) {
+				return l.AddAsset(cmd.Context(), types.Asset(args[0]), definition)
+			})
+		},
+	}
+
+	cmd.Flags().Int32("decimals", 0, "Max decimals of the amounts (not limited by default)")
+	cmd.Flags().String("min", "", "Min amount of a transaction")
+	cmd.Flags().String("max", "", "Max amount of a transaction")
+	cmd.Flags().Bool("disabled", false, "Add the asset disabled")
+
+	parent.AddCommand(cmd)
+}
+
+func addAssetEnableCmd(parent *cobra.Command, use string, short string, enabled bool) {
+	cmd := &cobra.Command{
+		Use:   use + " [symbol]",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return registry(cmd, func(l *ledger.Ledger) (*ledger.RegisteredAsset, error) {
+				return l.EnableAsset(cmd.Context(), types.Asset(args[0]), enabled)
+			})
+		},
+	}
+
+	parent.AddCommand(cmd)
+}
+
+func addAssetHistoryCmd(parent *cobra.Command) {
+	cmd := &cobra.Command{
+		Use:   "history [symbol]",
+		Short: "Show all versions of an asset definition",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			table := tablewriter.NewWriter(cmd.OutOrStderr())
+			table.SetHeader([]string{"Symbol", "Asset", "Decimals", "Min", "Max", "Enabled", "Modified", "User"})
+			table.SetAlignment(tablewriter.ALIGN_LEFT)
+
+			err := registry(cmd, func(l *ledger.Ledger) (*ledger.RegisteredAsset, error) {
+				return nil, l.AssetHistory(cmd.Context(), types.Asset(args[0]), func(ctx context.Context, asset *ledger.RegisteredAsset) (bool, error) {
+					table.Append(registeredAssetRow(asset))
+					return true, nil
+				})
+			})
+
+			if err != nil {
+				return err
+			}
+
+			table.Render()
+
+			return nil
+		},
+	}
+
+	parent.AddCommand(cmd)
+}
+
+// registry runs a change of the asset registry and shows the changed asset
+func registry(cmd *cobra.Command, f func(*ledger.Ledger) (*ledger.RegisteredAsset, error)) error {
+	cfg := config.Configuration()
+
+	client, err := client.New(cmd.Context(), cfg.ClientOptions.Username, cfg.ClientOptions.Password, cfg.ClientOptions.Database,
+		client.ClientOptions(cfg.ClientOptions),
+		client.Limit(25),
+	)
+	if err != nil {
+		return fmt.Errorf("database client error: %v", err)
+	}
+
+	defer client.Close(cmd.Context())
+
+	l := ledger.New(client,
+		ledger.SupportedAssets(cfg.Assets),
+		ledger.SupportedStatuses(cfg.Statuses),
+	)
+
+	asset, err := f(l)
+	if err != nil || asset == nil {
+		return err
+	}
+
+	table := tablewriter.NewWriter(cmd.OutOrStderr())
+	table.SetHeader([]string{"Symbol", "Asset", "Decimals", "Min", "Max", "Enabled", "Modified", "User"})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Append(registeredAssetRow(asset))
+	table.Render()
+
+	return nil
+}
+
+func registeredAssetRow(asset *ledger.RegisteredAsset) []string {
+	row := assetRow(asset.Symbol, asset.Definition)
+
+	modified := ""
+	if asset.Modified != nil {
+		modified = asset.Modified.Format(time.RFC3339)
+	}
+
+	return append(row, modified, asset.User)
+}
+
+func assetRow(asset types.Asset, definition types.AssetDefinition) []string {
+	row := []string{asset.String(), definition.Name, "", "", "", fmt.Sprint(definition.Enabled)}
+
+	if definition.Decimals != nil {
+		row[2] = fmt.Sprint(*definition.Decimals)
+	}
+
+	if !definition.Min.IsZero() {
+		row[3] = definition.Min.String()
+	}
+
+	if !definition.Max.IsZero() {
+		row[4] = definition.Max.String()
+	}
+
+	return row
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ec-systems/core.ledger.server/pkg/client"
+	"github.com/ec-systems/core.ledger.server/pkg/config"
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/logger"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+func addRebuildBalancesCmd(root *RootCommand) {
+	cmd := &cobra.Command{
+		Use:   "rebuild-balances [holder]",
+		Short: "Recompute the balance snapshots from the transaction history and report drifts",
+		Args:  cobra.RangeArgs(0, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := config.Configuration()
+
+			holder := ""
+			if len(args) > 0 {
+				holder = args[0]
+			}
+
+			dryRun, err := cmd.Flags().GetBool("dry-run")
+			if err != nil {
+				return err
+			}
+
+			client, err := client.New(cmd.Context(), cfg.ClientOptions.Username, cfg.ClientOptions.Password, cfg.ClientOptions.Database,
+				client.ClientOptions(cfg.ClientOptions),
+				client.Limit(25),
+			)
+			if err != nil {
+				return fmt.Errorf("database client error: %v", err)
+			}
+
+			defer client.Close(cmd.Context())
+
+			l := ledger.New(client,
+				ledger.SupportedAssets(cfg.Assets),
+				ledger.SupportedStatuses(cfg.Statuses),
+			)
+
+			table := tablewriter.NewWriter(cmd.OutOrStderr())
+			table.SetHeader([]string{"Key", "Holder", "Account", "Asset", "Stored", "Computed", "Stored Count", "Computed Count"})
+
+			drifts := 0
+			err = l.RebuildBalances(cmd.Context(), holder, dryRun, func(ctx context.Context, drift *ledger.BalanceDrift) error {
+				stored, count := "missing", "-"
+				if drift.Stored != nil {
+					stored = drift.Stored.Sum.String()
+					count = fmt.Sprintf("%v", drift.Stored.Count)
+				}
+
+				table.Append([]string{
+					drift.Computed.Key(),
+					drift.Computed.Holder,
+					drift.Computed.Account.String(),
+					drift.Computed.Asset.String(),
+					stored,
+					drift.Computed.Sum.String(),
+					count,
+					fmt.Sprintf("%v", drift.Computed.Count),
+				})
+
+				drifts++
+				return nil
+			})
+
+			if err != nil {
+				return fmt.Errorf("failed to rebuild balances: %v", err)
+			}
+
+			if drifts > 0 {
+				table.Render()
+			}
+
+			if dryRun {
+				logger.Infof("%v balance drifts found", drifts)
+			} else {
+				logger.Infof("%v balance drifts fixed", drifts)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("dry-run", false, "Only report drifts, don't rewrite the balances")
+
+	root.AddCommand(cmd)
+}
@@ -78,11 +78,18 @@ func addHistoryCmd(root *RootCommand) {
 			}
 
 			table := tablewriter.NewWriter(os.Stdout)
-			table.SetHeader([]string{"TX", "Date", "Status"})
+			table.SetHeader([]string{"TX", "Date", "Status", "Holder", "Account", "Asset", "Amount", "Order", "Item", "User", "Ref"})
 
 			err = l.History(cmd.Context(), types.ID{UUID: id}, func(ctx context.Context, tx *ledger.Transaction) (bool, error) {
+				date := tx.Created
+				if tx.Modified != nil {
+					date = tx.Modified
+				}
+
 				table.Append([]string{
-					fmt.Sprintf("%v", tx.TX()), tx.Modified.Format(ledger.TimeFormat), tx.Status.String(l.SupportedStatus())})
+					fmt.Sprintf("%v", tx.TX()), date.Format(ledger.TimeFormat), tx.Status.String(l.SupportedStatus()),
+					tx.Holder, tx.Account.String(), tx.Asset.String(), tx.Amount.String(), tx.Order, tx.Item, tx.User, tx.Reference,
+				})
 				return true, nil
 			})
 
@@ -92,6 +99,28 @@ func addHistoryCmd(root *RootCommand) {
 
 			table.Render()
 
+			links, err := l.Links(cmd.Context(), types.ID{UUID: id})
+			if err != nil {
+				return err
+			}
+
+			if len(links.Incoming) == 0 && len(links.Outgoing) == 0 {
+				return nil
+			}
+
+			table = tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"Direction", "Relation", "Source", "Target", "Holder", "Asset", "Amount"})
+
+			for _, link := range links.Incoming {
+				table.Append(linkRow("incoming", link))
+			}
+
+			for _, link := range links.Outgoing {
+				table.Append(linkRow("outgoing", link))
+			}
+
+			table.Render()
+
 			return nil
 		},
 		PostRunE: func(cmd *cobra.Command, args []string) error {
@@ -101,3 +130,16 @@ func addHistoryCmd(root *RootCommand) {
 
 	root.AddCommand(cmd)
 }
+
+func linkRow(direction string, link *ledger.Link) []string {
+	row := []string{direction, string(link.Relation), link.Source.String(), link.Target.String(), "", "", ""}
+
+	switch {
+	case link.Transaction != nil:
+		row[4], row[5], row[6] = link.Transaction.Holder, link.Transaction.Asset.String(), link.Transaction.Amount.String()
+	case link.Hold != nil:
+		row[4], row[5], row[6] = link.Hold.Holder, link.Hold.Asset.String(), link.Hold.Amount.String()
+	}
+
+	return row
+}
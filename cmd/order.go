@@ -1,13 +1,14 @@
 package cmd
 
 import (
-	"context"
+	"encoding/json"
 	"os"
 	"strings"
 
 	"github.com/ec-systems/core.ledger.server/pkg/client"
 	"github.com/ec-systems/core.ledger.server/pkg/config"
 	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/service"
 	"github.com/olekukonko/tablewriter"
 
 	"fmt"
@@ -59,8 +60,13 @@ func addOrdersCmd(root *RootCommand) {
 			}
 
 			item := ""
-			if len(args) > 1 {
-				order = args[1]
+			if len(args) > 2 {
+				item = args[2]
+			}
+
+			asJSON, err := cmd.Flags().GetBool("json")
+			if err != nil {
+				return err
 			}
 
 			client, err := client.New(cmd.Context(), cfg.ClientOptions.Username, cfg.ClientOptions.Password, cfg.ClientOptions.Database,
@@ -79,49 +85,70 @@ func addOrdersCmd(root *RootCommand) {
 			)
 
 			if len(args) == 1 {
-				table := tablewriter.NewWriter(os.Stdout)
-				table.SetHeader([]string{"TX", "Date", "Order"})
-
-				err = l.Orders(cmd.Context(), holder, func(ctx context.Context, tx *ledger.Transaction) (bool, error) {
-					row := []string{
-						fmt.Sprintf("%v", tx.TX()),
-						tx.Created.Format(ledger.TimeFormat),
-						tx.Order,
-					}
-
-					table.Append(row)
-					return true, nil
-				})
+				orders, err := l.HolderOrders(cmd.Context(), holder)
 				if err != nil {
 					return err
 				}
-				table.Render()
 
-			} else {
+				result := []*service.Order{}
+				for _, order := range orders {
+					output := &service.Order{}
+					output.Set(l, order)
+					result = append(result, output)
+				}
+
+				if asJSON {
+					return printJSON(result)
+				}
+
 				table := tablewriter.NewWriter(os.Stdout)
-				table.SetHeader([]string{"TX", "Date", "Order", "Item", "Asset", "Status", "Amount"})
+				table.SetHeader([]string{"Order", "Status", "Items", "Net"})
+
+				for _, order := range result {
+					table.Append([]string{
+						order.Order,
+						order.Status,
+						fmt.Sprintf("%v", len(order.Items)),
+						netString(order.Net),
+					})
+				}
+
+				table.Render()
+				return nil
+			}
 
-				err = l.OrderItems(cmd.Context(), holder, order, item, func(ctx context.Context, tx *ledger.Transaction) (bool, error) {
-					row := []string{
+			summary, err := l.Order(cmd.Context(), holder, order, item)
+			if err != nil {
+				return err
+			}
+
+			result := &service.Order{}
+			result.Set(l, summary)
+
+			if asJSON {
+				return printJSON(result)
+			}
+
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"TX", "Date", "Order", "Item", "Asset", "Status", "Amount"})
+
+			for _, item := range summary.Items {
+				for _, tx := range item.Transactions {
+					table.Append([]string{
 						fmt.Sprintf("%v", tx.TX()),
 						tx.Created.Format(ledger.TimeFormat),
 						tx.Order,
-					}
-
-					row = append(row, tx.Item)
-					row = append(row, tx.Asset.String())
-					row = append(row, tx.Status.String(l.SupportedStatus()))
-					row = append(row, tx.Amount.String())
-
-					table.Append(row)
-					return true, nil
-				})
-				if err != nil {
-					return err
+						tx.Item,
+						tx.Asset.String(),
+						tx.Status.String(l.SupportedStatus()),
+						tx.Amount.String(),
+					})
 				}
-				table.Render()
 			}
 
+			table.SetFooter([]string{"", "", "", "", "", result.Status, netString(result.Net)})
+			table.Render()
+
 			return nil
 		},
 		PostRunE: func(cmd *cobra.Command, args []string) error {
@@ -129,5 +156,22 @@ func addOrdersCmd(root *RootCommand) {
 		},
 	}
 
+	cmd.Flags().Bool("json", false, "Print the orders as json")
+
 	root.AddCommand(cmd)
 }
+
+func printJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func netString(net []*service.AssetBalance) string {
+	sums := []string{}
+	for _, n := range net {
+		sums = append(sums, fmt.Sprintf("%v %v", n.Sum, n.Asset))
+	}
+
+	return strings.Join(sums, ", ")
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/codenotary/immudb/pkg/signer"
+	"github.com/ec-systems/core.ledger.server/pkg/client"
+	"github.com/ec-systems/core.ledger.server/pkg/config"
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/google/uuid"
+	"github.com/olekukonko/tablewriter"
+
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func addProofCmd(root *RootCommand) {
+
+	cmd := &cobra.Command{
+		Use:           "proof <id> [file]",
+		Short:         "Export the proof bundle of a transaction",
+		Args:          cobra.RangeArgs(1, 2),
+		SilenceErrors: true,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			cfg := config.Configuration()
+			validate := validator.New()
+
+			err := validate.Struct(cfg)
+			switch v := err.(type) {
+			case validator.ValidationErrors:
+				messages := []string{}
+				for _, err := range v {
+					msg := fmt.Sprintf("%v is %v", err.StructNamespace(), err.ActualTag())
+					messages = append(messages, msg)
+				}
+
+				return fmt.Errorf("invalid configuration: %v", strings.Join(messages, ", "))
+			case *validator.InvalidValidationError:
+				return fmt.Errorf("invalid configuration: %v", v)
+			default:
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := config.Configuration()
+
+			id, err := uuid.Parse(args[0])
+			if err != nil {
+				return err
+			}
+
+			client, err := client.New(cmd.Context(), cfg.ClientOptions.Username, cfg.ClientOptions.Password, cfg.ClientOptions.Database,
+				client.ClientOptions(cfg.ClientOptions),
+				client.Limit(25),
+			)
+			if err != nil {
+				return fmt.Errorf("immudb client error: %v", err)
+			}
+
+			defer client.Close(cmd.Context())
+
+			l := ledger.New(client,
+				ledger.SupportedAssets(cfg.Assets),
+				ledger.SupportedStatuses(cfg.Statuses),
+			)
+
+			b, err := l.Bundle(cmd.Context(), types.ID{UUID: id})
+			if err != nil {
+				return err
+			}
+
+			if len(args) < 2 {
+				return b.Write(os.Stdout)
+			}
+
+			file, err := os.Create(args[1])
+			if err != nil {
+				return err
+			}
+
+			defer file.Close()
+
+			return b.Write(file)
+		},
+		PostRunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	}
+
+	root.AddCommand(cmd)
+}
+
+func addVerifyProofCmd(root *RootCommand) {
+
+	cmd := &cobra.Command{
+		Use:           "verify-proof <file>",
+		Short:         "Verify a proof bundle offline",
+		Args:          cobra.ExactArgs(1),
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := config.Configuration()
+
+			path, err := cmd.Flags().GetString("key")
+			if err != nil {
+				return err
+			}
+
+			key, err := signer.ParsePublicKeyFile(path)
+			if err != nil {
+				return fmt.Errorf("invalid public key %v: %v", path, err)
+			}
+
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+
+			defer file.Close()
+
+			b, err := client.ReadBundle(file)
+			if err != nil {
+				return err
+			}
+
+			err = b.Verify(key)
+			if err != nil {
+				return err
+			}
+
+			tx := &ledger.Transaction{}
+			err = tx.Parse(b.Entry)
+			if err != nil {
+				return err
+			}
+
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"TX", "ID", "Date", "Holder", "Account", "Asset", "Amount", "Status", "State TX"})
+			table.Append(append(row(tx, statusCol, cfg.Statuses), fmt.Sprintf("%v", b.State.TxId)))
+			table.Render()
+
+			fmt.Println("Proof verified")
+
+			return nil
+		},
+	}
+
+	root.AddCommand(cmd)
+
+	cmd.Flags().StringP("key", "k", "keys/ec.pub", "Public key of the database signer")
+}
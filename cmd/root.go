@@ -56,6 +56,15 @@ func GetRootCmd(version *Version) *RootCommand {
 					cfg.Statuses = types.DefaultStatusMap
 				}
 
+				if cfg.Transitions == nil {
+					cfg.Transitions = types.DefaultTransitions
+				}
+
+				err = cfg.Transitions.Validate(cfg.Statuses)
+				if err != nil {
+					return err
+				}
+
 				docs.SwaggerInfo.Version = rootCmd.GetVersion().GitVersion
 
 				return nil
@@ -70,15 +79,21 @@ func GetRootCmd(version *Version) *RootCommand {
 
 	addVersionCmd(rootCmd)
 	addAssetsCmd(rootCmd)
+	addRebuildBalancesCmd(rootCmd)
 	addAddCmd(rootCmd)
 	addRemoveCmd(rootCmd)
+	addTransferCmd(rootCmd)
 	addTxCmd(rootCmd)
 	addAccountsCmd(rootCmd)
 	addHoldersCmd(rootCmd)
 	addKeysCmd(rootCmd)
+	addProofCmd(rootCmd)
+	addVerifyProofCmd(rootCmd)
 	addHistoryCmd(rootCmd)
+	addFindCmd(rootCmd)
 	addOrdersCmd(rootCmd)
 	addServiceCmd(rootCmd)
+	addAPIKeyCmd(rootCmd)
 	addInitCmd(rootCmd)
 
 	return rootCmd
@@ -136,11 +151,19 @@ func (r *RootCommand) init() error {
 	r.PersistentFlags().String("signing-key", cfg.ClientOptions.ServerSigningPubKey, "Path to the public key to verify signatures when presents")
 	r.bind("ClientOptions.ServerSigningPubKey", "signing-key")
 
+	r.PersistentFlags().Bool("verified", cfg.Verified, "Verify all reads against the trusted database state")
+	r.bind("Verified", "verified")
+
 	statuses := types.DefaultStatusMap
 
 	r.PersistentFlags().Var(&statuses, "statuses", "Supported statuses")
 	r.bind("Statuses", "statuses")
 
+	transitions := types.DefaultTransitions
+
+	r.PersistentFlags().Var(&transitions, "transitions", "Allowed status changes, e.g. Created=Finished|Canceled,Finished=")
+	r.bind("Transitions", "transitions")
+
 	format := types.Protobuf
 
 	r.PersistentFlags().VarP(&format, "format", "f", "Format of the database values")
@@ -192,6 +215,8 @@ func (r *RootCommand) initializeConfig(cmd *cobra.Command) error {
 		config.DurationHookFunc(),
 		logger.LogLevelHookFunc(),
 		types.StatusHookFunc(),
+		types.AssetHookFunc(),
+		types.TransitionsHookFunc(),
 		types.FormatHookFunc(),
 		mapstructure.StringToTimeDurationHookFunc(),
 		mapstructure.StringToSliceHookFunc(","),
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"os"
 	"os/signal"
 	"strings"
@@ -72,31 +73,56 @@ func addServiceCmd(root *RootCommand) {
 			logger.Infof("Start ledger service %v", root.GetVersion().GitVersion)
 			logger.Infof("Configuration\n%v", cfg)
 
-			client, err := client.New(cmd.Context(), cfg.ClientOptions.Username, cfg.ClientOptions.Password, cfg.ClientOptions.Database,
-				client.ClientOptions(cfg.ClientOptions),
-				client.Limit(25),
-			)
+			var cl *client.Client
+			var err error
+
+			if cfg.Service.Embedded {
+				cl, err = client.NewEmbedded(cmd.Context(), cfg.Service.DataDir, cfg.ClientOptions.Database,
+					client.Limit(25),
+					client.Verified(cfg.Verified),
+				)
+			} else {
+				cl, err = client.New(cmd.Context(), cfg.ClientOptions.Username, cfg.ClientOptions.Password, cfg.ClientOptions.Database,
+					client.ClientOptions(cfg.ClientOptions),
+					client.Limit(25),
+					client.Verified(cfg.Verified),
+				)
+			}
 			if err != nil {
 				return fmt.Errorf("database client error: %v", err)
 			}
 
-			defer client.Close(cmd.Context())
+			defer cl.Close(cmd.Context())
 
 			collector := metrics.NewTxCollector(cfg)
 			prometheus.MustRegister(collector)
 
-			l := ledger.New(client,
+			l := ledger.New(cl,
 				ledger.SupportedAssets(cfg.Assets),
 				ledger.SupportedStatuses(cfg.Statuses),
+				ledger.StatusTransitions(cfg.Transitions),
 				ledger.ReadOnly(cfg.Service.ReadOnly),
+				ledger.SpendPending(cfg.Service.SpendPending),
+				ledger.UniqueReferences(cfg.Service.UniqueRefs),
 				ledger.Collector(collector),
 			)
 
+			err = l.LoadAssets(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("asset registry error: %v", err)
+			}
+
 			svc, err := service.NewLedgerService(cmd.Context(), l, &cfg.Service)
 			if err != nil {
 				return fmt.Errorf("service error: %v", err)
 			}
 
+			ctx, cancel := context.WithCancel(cmd.Context())
+			defer cancel()
+
+			go l.SweepHolds(ctx, cfg.Service.HoldSweep)
+			go l.WatchAssets(ctx, cfg.Service.AssetReload)
+
 			go func() {
 				sig := <-sigs
 				logger.Infof("Got %v signal", sig)
@@ -135,6 +161,9 @@ func addServiceCmd(root *RootCommand) {
 	cmd.Flags().IntP("metrics", "M", cfg.Service.Metrics, "Metrics port")
 	root.bindFlags(cmd.Flags(), "Service.Metrics", "metrics")
 
+	cmd.Flags().Int("grpc", cfg.Service.Grpc, "gRPC port (0 disables the gRPC service)")
+	root.bindFlags(cmd.Flags(), "Service.Grpc", "grpc")
+
 	cmd.Flags().Bool("production", cfg.Service.Production, "Service port")
 	root.bindFlags(cmd.Flags(), "Service.Production", "production")
 
@@ -144,5 +173,38 @@ func addServiceCmd(root *RootCommand) {
 	cmd.Flags().Bool("read-only", cfg.Service.ReadOnly, "Read-only mode")
 	root.bindFlags(cmd.Flags(), "Service.ReadOnly", "read-only")
 
+	cmd.Flags().Bool("spend-pending", cfg.Service.SpendPending, "Allow to remove pending (created) credits")
+	root.bindFlags(cmd.Flags(), "Service.SpendPending", "spend-pending")
+
+	cmd.Flags().Bool("unique-refs", cfg.Service.UniqueRefs, "Reject a credit if a credit with the same reference exists")
+	root.bindFlags(cmd.Flags(), "Service.UniqueRefs", "unique-refs")
+
+	cmd.Flags().Duration("hold-sweep", cfg.Service.HoldSweep, "Interval to release expired holds (0 disables the sweeper)")
+	root.bindFlags(cmd.Flags(), "Service.HoldSweep", "hold-sweep")
+
+	cmd.Flags().Duration("asset-reload", cfg.Service.AssetReload, "Interval to reload the asset registry (0 disables the reload)")
+	root.bindFlags(cmd.Flags(), "Service.AssetReload", "asset-reload")
+
+	cmd.Flags().Duration("webhook-interval", cfg.Service.WebhookInterval, "Interval to deliver new transactions to webhooks (0 disables the delivery)")
+	root.bindFlags(cmd.Flags(), "Service.WebhookInterval", "webhook-interval")
+
+	cmd.Flags().Int("webhook-retries", cfg.Service.WebhookRetries, "Retries of a failed webhook delivery")
+	root.bindFlags(cmd.Flags(), "Service.WebhookRetries", "webhook-retries")
+
+	cmd.Flags().Duration("webhook-backoff", cfg.Service.WebhookBackoff, "Delay before the first retry of a webhook delivery, doubled for every further retry")
+	root.bindFlags(cmd.Flags(), "Service.WebhookBackoff", "webhook-backoff")
+
+	cmd.Flags().Duration("webhook-timeout", cfg.Service.WebhookTimeout, "Timeout of a webhook request")
+	root.bindFlags(cmd.Flags(), "Service.WebhookTimeout", "webhook-timeout")
+
+	cmd.Flags().String("policy", cfg.Service.Policy, "YAML file with the roles of the principals (all operations are allowed without a policy)")
+	root.bindFlags(cmd.Flags(), "Service.Policy", "policy")
+
+	cmd.Flags().Bool("embedded", cfg.Service.Embedded, "Use an embedded database instead of an immudb server")
+	root.bindFlags(cmd.Flags(), "Service.Embedded", "embedded")
+
+	cmd.Flags().String("data-dir", cfg.Service.DataDir, "Data directory of the embedded database")
+	root.bindFlags(cmd.Flags(), "Service.DataDir", "data-dir")
+
 	root.AddCommand(cmd)
 }
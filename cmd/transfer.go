@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/ec-systems/core.ledger.server/pkg/client"
+	"github.com/ec-systems/core.ledger.server/pkg/config"
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/logger"
+	"github.com/shopspring/decimal"
+
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func addTransferCmd(root *RootCommand) {
+	cmd := &cobra.Command{
+		Use:           "transfer <from holder id> <to holder id> <asset> <amount> [order] [order item]",
+		Short:         "Transfer assets from one holder to another",
+		Args:          cobra.RangeArgs(4, 6),
+		SilenceErrors: true,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			cfg := config.Configuration()
+			validate := validator.New()
+
+			err := validate.Struct(cfg)
+			switch v := err.(type) {
+			case validator.ValidationErrors:
+				messages := []string{}
+				for _, err := range v {
+					msg := fmt.Sprintf("%v is %v", err.StructNamespace(), err.ActualTag())
+					messages = append(messages, msg)
+				}
+
+				return fmt.Errorf("invalid configuration: %v", strings.Join(messages, ", "))
+			case *validator.InvalidValidationError:
+				return fmt.Errorf("invalid configuration: %v", v)
+			default:
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := config.Configuration()
+
+			from := args[0]
+			to := args[1]
+
+			amount, err := decimal.NewFromString(args[3])
+			if err != nil {
+				return fmt.Errorf("invalid amount format: %v", err)
+			}
+
+			order := ""
+			if len(args) > 4 {
+				order = args[4]
+			}
+
+			item := ""
+			if len(args) > 5 {
+				item = args[5]
+			}
+
+			ref, err := cmd.Flags().GetString("ref")
+			if err != nil {
+				return err
+			}
+
+			client, err := client.New(cmd.Context(), cfg.ClientOptions.Username, cfg.ClientOptions.Password, cfg.ClientOptions.Database,
+				client.ClientOptions(cfg.ClientOptions),
+				client.Limit(25),
+			)
+
+			if err != nil {
+				return fmt.Errorf("immudb client error: %v", err)
+			}
+
+			defer client.Close(cmd.Context())
+
+			l := ledger.New(client,
+				ledger.SupportedAssets(cfg.Assets),
+				ledger.SupportedStatuses(cfg.Statuses),
+			)
+
+			err = l.LoadAssets(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			asset, err := l.SupportedAssets().Parse(args[2])
+			if err != nil {
+				return err
+			}
+
+			debit, credit, err := l.Transfer(cmd.Context(), from, to, asset, amount,
+				ledger.OrderID(order),
+				ledger.OrderItemID(item),
+				ledger.Reference(ref),
+			)
+
+			if err != nil {
+				return err
+			}
+
+			logger.Infof("Transfer created: %v -> %v", debit.ID, credit.ID)
+
+			return nil
+		},
+		PostRunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("ref", "r", "", "Reference (optional)")
+
+	root.AddCommand(cmd)
+}
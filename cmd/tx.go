@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/ec-systems/core.ledger.server/pkg/client"
 	"github.com/ec-systems/core.ledger.server/pkg/config"
@@ -62,9 +63,15 @@ func addTxCmd(root *RootCommand) {
 			cfg := config.Configuration()
 			holder := ""
 
+			verify, err := cmd.Flags().GetBool("verify")
+			if err != nil {
+				return err
+			}
+
 			client, err := client.New(cmd.Context(), cfg.ClientOptions.Username, cfg.ClientOptions.Password, cfg.ClientOptions.Database,
 				client.ClientOptions(cfg.ClientOptions),
 				client.Limit(25),
+				client.Verified(cfg.Verified || verify),
 			)
 			if err != nil {
 				return fmt.Errorf("immudb client error: %v", err)
@@ -129,13 +136,6 @@ func addTxCmd(root *RootCommand) {
 				return err
 			}
 
-			verify, err := cmd.Flags().GetBool("verify")
-			if err != nil {
-				return err
-			}
-
-			verify = verify && cfg.ClientOptions.ServerSigningPubKey != ""
-
 			columns := []string{"TX", "ID", "Date", "Holder", "Account", "Asset", "Amount"}
 			colFlag := uint8(0)
 
@@ -173,14 +173,17 @@ func addTxCmd(root *RootCommand) {
 
 			table.SetHeader(columns)
 
-			err = l.Transactions(cmd.Context(), holder, asset, account, func(ctx context.Context, tx *ledger.Transaction) (bool, error) {
+			page, err := txPage(cmd, statuses)
+			if err != nil {
+				return err
+			}
+
+			next, err := l.TransactionsPage(cmd.Context(), holder, asset, account, page, func(ctx context.Context, tx *ledger.Transaction) (bool, error) {
 				if verify {
-					t, err := l.Get(cmd.Context(), tx.ID)
+					_, _, err := l.Proof(ctx, tx.ID)
 					if err != nil {
 						return false, err
 					}
-
-					_ = t
 				}
 
 				table.Append(row(tx, colFlag, l.SupportedStatus()))
@@ -193,6 +196,10 @@ func addTxCmd(root *RootCommand) {
 
 			table.Render()
 
+			if next != "" {
+				fmt.Printf("Next cursor: %v\n", next)
+			}
+
 			return nil
 		},
 		PostRunE: func(cmd *cobra.Command, args []string) error {
@@ -212,6 +219,78 @@ func addTxCmd(root *RootCommand) {
 	cmd.Flags().BoolP("all", "a", false, "Show all columns")
 
 	cmd.Flags().BoolP("verify", "V", false, "Verify all transactions")
+
+	cmd.Flags().Int("limit", 0, "Max number of transactions (0 lists all)")
+	cmd.Flags().String("cursor", "", "Cursor of the next page")
+	cmd.Flags().Bool("desc", false, "List the newest transactions first")
+	cmd.Flags().String("filter-status", "", "Only transactions with the status")
+	cmd.Flags().String("from", "", "Only transactions created at or after (RFC3339)")
+	cmd.Flags().String("to", "", "Only transactions created at or before (RFC3339)")
+	cmd.Flags().String("order-id", "", "Only transactions of the order")
+}
+
+func txPage(cmd *cobra.Command, statuses types.Statuses) (*ledger.Page, error) {
+	page := ledger.NewPage()
+
+	var err error
+
+	page.Limit, err = cmd.Flags().GetInt("limit")
+	if err != nil {
+		return nil, err
+	}
+
+	page.Cursor, err = cmd.Flags().GetString("cursor")
+	if err != nil {
+		return nil, err
+	}
+
+	page.Desc, err = cmd.Flags().GetBool("desc")
+	if err != nil {
+		return nil, err
+	}
+
+	page.Order, err = cmd.Flags().GetString("order-id")
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := cmd.Flags().GetString("filter-status")
+	if err != nil {
+		return nil, err
+	}
+
+	if status != "" {
+		page.Status, err = statuses.Parse(status)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	from, err := cmd.Flags().GetString("from")
+	if err != nil {
+		return nil, err
+	}
+
+	if from != "" {
+		page.From, err = time.Parse(time.RFC3339, from)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from %v: %v", from, err)
+		}
+	}
+
+	to, err := cmd.Flags().GetString("to")
+	if err != nil {
+		return nil, err
+	}
+
+	if to != "" {
+		page.To, err = time.Parse(time.RFC3339, to)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to %v: %v", to, err)
+		}
+	}
+
+	return page, nil
 }
 
 func row(t *ledger.Transaction, cols uint8, statuses types.Statuses) []string {
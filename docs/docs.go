@@ -66,6 +66,18 @@ const docTemplate = `{
                         "name": "holder",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Balance at a point in time (RFC3339)",
+                        "name": "at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Balance as of an immudb transaction",
+                        "name": "tx",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -78,6 +90,9 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "400": {
+                        "description": ""
+                    },
                     "404": {
                         "description": ""
                     },
@@ -133,7 +148,7 @@ const docTemplate = `{
         },
         "/accounts/{holder}/{asset}/{account}": {
             "get": {
-                "description": "List all the transactions of an account",
+                "description": "List the transactions of an account, the cursor of the next page is returned in the Next-Cursor header",
                 "produces": [
                     "application/json"
                 ],
@@ -162,13 +177,64 @@ const docTemplate = `{
                         "name": "account",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max number of transactions",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order (asc, desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or before (RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.Transaction"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.Transaction"
+                            }
+                        },
+                        "headers": {
+                            "Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor of the next page"
+                            }
                         }
                     },
                     "404": {
@@ -291,11 +357,136 @@ const docTemplate = `{
                         "description": ""
                     },
                     "406": {
-                        "description": "Not Acceptable",
+                        "description": ""
+                    },
+                    "409": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/accounts/{holder}/{asset}/{account}/{id}/proof": {
+            "get": {
+                "description": "Verify a transaction against the trusted database state",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Accounts"
+                ],
+                "summary": "Verify Transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account Holder",
+                        "name": "holder",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Asset Symbol",
+                        "name": "asset",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Account",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.Proof"
+                        }
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/accounts/{holder}/{asset}/{account}/{id}/refund/{amount}": {
+            "post": {
+                "description": "Revert a part of a transaction, the refunded total can't exceed the amount of the transaction. Cancels and refunds can't be refunded",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Accounts"
+                ],
+                "summary": "Refund a Transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account Holder",
+                        "name": "holder",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Asset Symbol",
+                        "name": "asset",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Account",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Amount",
+                        "name": "amount",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "a"
+                            "$ref": "#/definitions/service.Transaction"
                         }
                     },
+                    "400": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "409": {
+                        "description": ""
+                    },
                     "500": {
                         "description": ""
                     }
@@ -360,10 +551,7 @@ const docTemplate = `{
                         "description": ""
                     },
                     "406": {
-                        "description": "Not Acceptable",
-                        "schema": {
-                            "type": "a"
-                        }
+                        "description": ""
                     },
                     "500": {
                         "description": ""
@@ -420,6 +608,12 @@ const docTemplate = `{
                         "description": "Reference",
                         "name": "ref",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Idempotency Key, a retry with the same key returns the original transaction",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -436,10 +630,10 @@ const docTemplate = `{
                         "description": ""
                     },
                     "406": {
-                        "description": "Not Acceptable",
-                        "schema": {
-                            "type": "a"
-                        }
+                        "description": ""
+                    },
+                    "409": {
+                        "description": ""
                     },
                     "500": {
                         "description": ""
@@ -494,6 +688,12 @@ const docTemplate = `{
                         "description": "Reference",
                         "name": "ref",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Idempotency Key, a retry with the same key returns the original transaction",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -510,10 +710,10 @@ const docTemplate = `{
                         "description": ""
                     },
                     "406": {
-                        "description": "Not Acceptable",
-                        "schema": {
-                            "type": "a"
-                        }
+                        "description": ""
+                    },
+                    "409": {
+                        "description": ""
                     },
                     "500": {
                         "description": ""
@@ -521,75 +721,75 @@ const docTemplate = `{
                 }
             }
         },
-        "/assets/": {
+        "/apikeys/": {
             "get": {
-                "description": "Show alle assets with a transaction",
+                "description": "List all stored api keys",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Assets"
+                    "API Keys"
                 ],
-                "summary": "Show list of Assets",
+                "summary": "List API Keys",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/service.Asset"
+                                "$ref": "#/definitions/service.APIKey"
                             }
                         }
                     },
-                    "400": {
+                    "401": {
                         "description": ""
                     },
-                    "404": {
+                    "403": {
                         "description": ""
                     },
                     "500": {
                         "description": ""
                     }
                 }
-            }
-        },
-        "/assets/{asset}": {
-            "get": {
-                "description": "Show the balance of an Asset",
+            },
+            "post": {
+                "description": "Add an api key for the name, the key is only returned once and only its hash is stored",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Assets"
+                    "API Keys"
                 ],
-                "summary": "Asset Balance",
+                "summary": "Add API Key",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Asset Symbol",
-                        "name": "asset",
-                        "in": "path",
-                        "required": true
+                        "description": "API Key",
+                        "name": "key",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.APIKeyRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.AssetBalance"
+                            "$ref": "#/definitions/service.APIKey"
                         }
                     },
                     "400": {
                         "description": ""
                     },
-                    "404": {
+                    "401": {
                         "description": ""
                     },
-                    "406": {
-                        "description": "Not Acceptable",
-                        "schema": {
-                            "type": "string"
-                        }
+                    "403": {
+                        "description": ""
                     },
                     "500": {
                         "description": ""
@@ -597,18 +797,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/health": {
-            "get": {
-                "description": "Show health status",
+        "/apikeys/{id}": {
+            "delete": {
+                "description": "Revoke a stored api key",
                 "produces": [
-                    "plain/text"
+                    "application/json"
                 ],
                 "tags": [
-                    "Health"
+                    "API Keys"
+                ],
+                "summary": "Revoke API Key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Health",
                 "responses": {
                     "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.APIKey"
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "401": {
+                        "description": ""
+                    },
+                    "403": {
+                        "description": ""
+                    },
+                    "404": {
                         "description": ""
                     },
                     "500": {
@@ -617,16 +841,537 @@ const docTemplate = `{
                 }
             }
         },
-        "/info/assets": {
+        "/assets/": {
             "get": {
-                "description": "List of the assets supported by the ledger",
+                "description": "Show alle assets with a transaction",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Info"
+                    "Assets"
                 ],
-                "summary": "Supported Assets",
+                "summary": "Show list of Assets",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.Asset"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/assets/{asset}": {
+            "get": {
+                "description": "Show the balance of an Asset",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Assets"
+                ],
+                "summary": "Asset Balance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Asset Symbol",
+                        "name": "asset",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.AssetBalance"
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "406": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            },
+            "put": {
+                "description": "Add or replace the definition of an asset in the asset registry, all service instances pick it up on their next reload",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Assets"
+                ],
+                "summary": "Define Asset",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Asset Symbol",
+                        "name": "asset",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Asset Definition",
+                        "name": "definition",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.AssetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.Asset"
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "403": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/assets/{asset}/disable": {
+            "post": {
+                "description": "Disable an asset, new transactions of the asset are rejected",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Assets"
+                ],
+                "summary": "Disable Asset",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Asset Symbol",
+                        "name": "asset",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.Asset"
+                        }
+                    },
+                    "403": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/assets/{asset}/enable": {
+            "post": {
+                "description": "Enable an asset of the asset registry",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Assets"
+                ],
+                "summary": "Enable Asset",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Asset Symbol",
+                        "name": "asset",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.Asset"
+                        }
+                    },
+                    "403": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/assets/{asset}/history": {
+            "get": {
+                "description": "List all stored versions of an asset definition",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Assets"
+                ],
+                "summary": "Asset History",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Asset Symbol",
+                        "name": "asset",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.Asset"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/events": {
+            "get": {
+                "description": "Stream new and updated transactions as server-sent events, the event id is the immudb tx of the transaction. A stream resumes after the Last-Event-ID header or the from parameter, without both it starts at the current tx.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Stream Transactions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Resume after this immudb tx",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Start after this immudb tx",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Account Holder",
+                        "name": "holder",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Asset Symbol",
+                        "name": "asset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Show health status",
+                "produces": [
+                    "plain/text"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Health",
+                "responses": {
+                    "200": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/holds/": {
+            "get": {
+                "description": "List all holds of a holder",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Holds"
+                ],
+                "summary": "List Holds",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Holder",
+                        "name": "holder",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Asset",
+                        "name": "asset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.Hold"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            },
+            "post": {
+                "description": "Reserve assets of a holder, the held amount is no longer available until the hold is captured, voided or expired",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Holds"
+                ],
+                "summary": "Hold Assets",
+                "parameters": [
+                    {
+                        "description": "Hold",
+                        "name": "hold",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.HoldRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.Hold"
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "406": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/holds/{id}": {
+            "get": {
+                "description": "Show a hold",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Holds"
+                ],
+                "summary": "Show Hold",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Hold ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.Hold"
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/holds/{id}/capture": {
+            "post": {
+                "description": "Debit the held assets, the hold is released in the same ledger transaction",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Holds"
+                ],
+                "summary": "Capture Hold",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Hold ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.Capture"
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/holds/{id}/void": {
+            "post": {
+                "description": "Release the held assets without a debit",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Holds"
+                ],
+                "summary": "Void Hold",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Hold ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.Hold"
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/info/assets": {
+            "get": {
+                "description": "List of the assets supported by the ledger with their precision and amount limits",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Info"
+                ],
+                "summary": "Supported Assets",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -642,110 +1387,1265 @@ const docTemplate = `{
         },
         "/info/statuses": {
             "get": {
-                "description": "List of the statuses supported by the ledger",
+                "description": "List of the statuses supported by the ledger with the allowed status changes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Info"
+                ],
+                "summary": "Supported Statuses",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/service.Status"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/journals/": {
+            "post": {
+                "description": "Book a multi-leg journal entry, the legs have to sum up to zero per asset",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Journals"
+                ],
+                "summary": "Book Journal",
+                "parameters": [
+                    {
+                        "description": "Journal",
+                        "name": "journal",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.JournalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.Journal"
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "406": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/journals/{id}": {
+            "get": {
+                "description": "List all legs of a journal",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Journals"
+                ],
+                "summary": "Show Journal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Journal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.Journal"
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/orders/{holder}": {
+            "get": {
+                "description": "List the orders of a holder with their transactions, net amounts by asset and status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "List Orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Holder",
+                        "name": "holder",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.Order"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "403": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/orders/{holder}/{order}": {
+            "get": {
+                "description": "Show the transactions of an order or of one of its items, the net amounts by asset and the status derived from the items",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Show Order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Holder",
+                        "name": "holder",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "order",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "item",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.Order"
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "403": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/orders/{holder}/{order}/{item}": {
+            "get": {
+                "description": "Show the transactions of an order or of one of its items, the net amounts by asset and the status derived from the items",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Show Order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Holder",
+                        "name": "holder",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "order",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "item",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.Order"
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "403": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/transactions/": {
+            "get": {
+                "description": "Find the transactions of all holders by reference, case and a 0x prefix are ignored",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Find Transactions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reference",
+                        "name": "ref",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.Transaction"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/transactions/{id}": {
+            "get": {
+                "description": "Show the current state of a transaction, the holder, asset and account are not needed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Show Transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "403": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            },
+            "delete": {
+                "description": "Cancel a transaction, the holder, asset and account are not needed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Revert Transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "403": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "409": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/history": {
+            "get": {
+                "description": "Show all versions of a transaction",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Show Transaction History",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.Transaction"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "403": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/links": {
+            "get": {
+                "description": "List the incoming and outgoing links of a transaction or hold (cancel, refund, transfer, capture)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Transaction Links",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.Links"
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "403": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/status/{status}": {
+            "patch": {
+                "description": "Change the status of a transaction, the holder, asset and account are not needed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Change Transaction Status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transaction Status",
+                        "name": "status",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "403": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/transfers/": {
+            "post": {
+                "description": "Move assets from one holder to another in a single ledger transaction",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Transfer Assets",
+                "parameters": [
+                    {
+                        "description": "Transfer",
+                        "name": "transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.TransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.Transfer"
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "406": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/webhooks/": {
+            "get": {
+                "description": "List all webhooks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List Webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.Webhook"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a webhook for the transactions matching the holder, asset and status filter, it receives the transactions written after its creation. The secret is only returned once, a random secret is generated if none is given.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Add Webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "description": "Show a webhook",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Show Webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "List the delivery attempts of a webhook, the latest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List Deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max number of attempts",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.Delivery"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/failures": {
+            "get": {
+                "description": "List the failed delivery attempts of a webhook, the latest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Info"
+                    "Webhooks"
+                ],
+                "summary": "List Failures",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max number of attempts",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Supported Statuses",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/service.Status"
-                                }
+                                "$ref": "#/definitions/service.Delivery"
                             }
                         }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/replay": {
+            "post": {
+                "description": "Deliver all matching transactions written after the given immudb tx again",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Replay Webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Replay after this immudb tx",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "service.APIKey": {
+            "type": "object",
+            "properties": {
+                "Created": {
+                    "type": "string"
+                },
+                "ID": {
+                    "type": "string"
+                },
+                "Key": {
+                    "type": "string"
+                },
+                "Name": {
+                    "type": "string"
+                },
+                "Revoked": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.APIKeyRequest": {
+            "type": "object",
+            "properties": {
+                "Name": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.Account": {
+            "type": "object",
+            "properties": {
+                "Account": {
+                    "type": "string"
+                },
+                "Asset": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.AccountBalance": {
+            "type": "object",
+            "properties": {
+                "Available": {
+                    "type": "number"
+                },
+                "Canceled": {
+                    "type": "number"
+                },
+                "Count": {
+                    "type": "integer"
+                },
+                "Finished": {
+                    "type": "number"
+                },
+                "Held": {
+                    "type": "number"
+                },
+                "ID": {
+                    "type": "string"
+                },
+                "Pending": {
+                    "type": "number"
+                },
+                "Sum": {
+                    "type": "number"
+                }
+            }
+        },
+        "service.Asset": {
+            "type": "object",
+            "properties": {
+                "Decimals": {
+                    "type": "integer"
+                },
+                "Enabled": {
+                    "type": "boolean"
+                },
+                "Max": {
+                    "type": "number"
+                },
+                "Min": {
+                    "type": "number"
+                },
+                "Modified": {
+                    "type": "string"
+                },
+                "Name": {
+                    "type": "string"
+                },
+                "Symbol": {
+                    "type": "string"
+                },
+                "User": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.AssetBalance": {
+            "type": "object",
+            "properties": {
+                "Asset": {
+                    "type": "string"
+                },
+                "Sum": {
+                    "type": "number"
+                }
+            }
+        },
+        "service.AssetRequest": {
+            "type": "object",
+            "properties": {
+                "Decimals": {
+                    "type": "integer"
+                },
+                "Enabled": {
+                    "type": "boolean"
+                },
+                "Max": {
+                    "type": "number"
+                },
+                "Min": {
+                    "type": "number"
+                },
+                "Name": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.Balance": {
+            "type": "object",
+            "properties": {
+                "Accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.AccountBalance"
                     }
+                },
+                "Asset": {
+                    "type": "string"
+                },
+                "Available": {
+                    "type": "number"
+                },
+                "Canceled": {
+                    "type": "number"
+                },
+                "Count": {
+                    "type": "integer"
+                },
+                "Finished": {
+                    "type": "number"
+                },
+                "Held": {
+                    "type": "number"
+                },
+                "Pending": {
+                    "type": "number"
+                },
+                "Sum": {
+                    "type": "number"
+                }
+            }
+        },
+        "service.Capture": {
+            "type": "object",
+            "properties": {
+                "Hold": {
+                    "$ref": "#/definitions/service.Hold"
+                },
+                "Transaction": {
+                    "$ref": "#/definitions/service.Transaction"
+                }
+            }
+        },
+        "service.Delivery": {
+            "type": "object",
+            "properties": {
+                "Attempt": {
+                    "type": "integer"
+                },
+                "Code": {
+                    "type": "integer"
+                },
+                "Created": {
+                    "type": "string"
+                },
+                "Error": {
+                    "type": "string"
+                },
+                "ID": {
+                    "type": "string"
+                },
+                "Status": {
+                    "type": "string"
+                },
+                "Transaction": {
+                    "type": "string"
+                },
+                "Tx": {
+                    "type": "integer"
+                },
+                "Webhook": {
+                    "type": "string"
                 }
             }
-        }
-    },
-    "definitions": {
-        "service.Account": {
+        },
+        "service.Hold": {
             "type": "object",
             "properties": {
                 "Account": {
                     "type": "string"
                 },
+                "Amount": {
+                    "type": "number"
+                },
                 "Asset": {
                     "type": "string"
+                },
+                "Created": {
+                    "type": "string"
+                },
+                "Expires": {
+                    "type": "string"
+                },
+                "Holder": {
+                    "type": "string"
+                },
+                "ID": {
+                    "type": "string"
+                },
+                "Modified": {
+                    "type": "string"
+                },
+                "Order": {
+                    "type": "string"
+                },
+                "Reference": {
+                    "type": "string"
+                },
+                "Status": {
+                    "type": "string"
+                },
+                "Transaction": {
+                    "type": "string"
                 }
             }
         },
-        "service.AccountBalance": {
+        "service.HoldRequest": {
             "type": "object",
             "properties": {
-                "Count": {
-                    "type": "integer"
+                "Account": {
+                    "type": "string"
+                },
+                "Amount": {
+                    "type": "number"
+                },
+                "Asset": {
+                    "type": "string"
+                },
+                "Expires": {
+                    "type": "string"
+                },
+                "Holder": {
+                    "type": "string"
+                },
+                "Order": {
+                    "type": "string"
+                },
+                "Reference": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.Holder": {
+            "type": "object",
+            "properties": {
+                "Accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.Account"
+                    }
                 },
+                "Name": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.Journal": {
+            "type": "object",
+            "properties": {
                 "ID": {
                     "type": "string"
                 },
-                "Sum": {
+                "Legs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.Transaction"
+                    }
+                }
+            }
+        },
+        "service.JournalLegRequest": {
+            "type": "object",
+            "properties": {
+                "Account": {
+                    "type": "string"
+                },
+                "Amount": {
                     "type": "number"
+                },
+                "Asset": {
+                    "type": "string"
+                },
+                "Holder": {
+                    "type": "string"
                 }
             }
         },
-        "service.Asset": {
+        "service.JournalRequest": {
             "type": "object",
             "properties": {
-                "Name": {
+                "Legs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.JournalLegRequest"
+                    }
+                },
+                "Order": {
                     "type": "string"
                 },
-                "Symbol": {
+                "Reference": {
                     "type": "string"
                 }
             }
         },
-        "service.AssetBalance": {
+        "service.Link": {
             "type": "object",
             "properties": {
-                "Asset": {
+                "Hold": {
+                    "$ref": "#/definitions/service.Hold"
+                },
+                "Relation": {
                     "type": "string"
                 },
-                "Sum": {
-                    "type": "number"
+                "Source": {
+                    "type": "string"
+                },
+                "Target": {
+                    "type": "string"
+                },
+                "Transaction": {
+                    "$ref": "#/definitions/service.Transaction"
                 }
             }
         },
-        "service.Balance": {
+        "service.Links": {
             "type": "object",
             "properties": {
-                "Accounts": {
+                "ID": {
+                    "type": "string"
+                },
+                "Incoming": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/service.AccountBalance"
+                        "$ref": "#/definitions/service.Link"
                     }
                 },
-                "Asset": {
+                "Outgoing": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.Link"
+                    }
+                }
+            }
+        },
+        "service.Order": {
+            "type": "object",
+            "properties": {
+                "Holder": {
                     "type": "string"
                 },
-                "Count": {
-                    "type": "integer"
+                "Items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.OrderItem"
+                    }
                 },
-                "Sum": {
-                    "type": "number"
+                "Net": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.AssetBalance"
+                    }
+                },
+                "Order": {
+                    "type": "string"
+                },
+                "Status": {
+                    "type": "string"
                 }
             }
         },
-        "service.Holder": {
+        "service.OrderItem": {
             "type": "object",
             "properties": {
-                "Accounts": {
+                "Item": {
+                    "type": "string"
+                },
+                "Status": {
+                    "type": "string"
+                },
+                "Transactions": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/service.Account"
+                        "$ref": "#/definitions/service.Transaction"
                     }
+                }
+            }
+        },
+        "service.Proof": {
+            "type": "object",
+            "properties": {
+                "Error": {
+                    "type": "string"
                 },
-                "Name": {
+                "Leaf": {
+                    "type": "integer"
+                },
+                "Signed": {
+                    "type": "boolean"
+                },
+                "SourceTx": {
+                    "type": "integer"
+                },
+                "TargetAlh": {
                     "type": "string"
+                },
+                "TargetTx": {
+                    "type": "integer"
+                },
+                "Transaction": {
+                    "$ref": "#/definitions/service.Transaction"
+                },
+                "Tx": {
+                    "type": "integer"
+                },
+                "Verified": {
+                    "type": "boolean"
+                },
+                "Width": {
+                    "type": "integer"
                 }
             }
         },
@@ -757,6 +2657,15 @@ const docTemplate = `{
                 },
                 "Name": {
                     "type": "string"
+                },
+                "Terminal": {
+                    "type": "boolean"
+                },
+                "Transitions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
@@ -784,6 +2693,9 @@ const docTemplate = `{
                 "Item": {
                     "type": "string"
                 },
+                "Journal": {
+                    "type": "string"
+                },
                 "Modified": {
                     "type": "string"
                 },
@@ -800,6 +2712,95 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "service.Transfer": {
+            "type": "object",
+            "properties": {
+                "From": {
+                    "$ref": "#/definitions/service.Transaction"
+                },
+                "To": {
+                    "$ref": "#/definitions/service.Transaction"
+                }
+            }
+        },
+        "service.TransferRequest": {
+            "type": "object",
+            "properties": {
+                "Amount": {
+                    "type": "number"
+                },
+                "Asset": {
+                    "type": "string"
+                },
+                "From": {
+                    "type": "string"
+                },
+                "Item": {
+                    "type": "string"
+                },
+                "Order": {
+                    "type": "string"
+                },
+                "Reference": {
+                    "type": "string"
+                },
+                "To": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.Webhook": {
+            "type": "object",
+            "properties": {
+                "Asset": {
+                    "type": "string"
+                },
+                "Created": {
+                    "type": "string"
+                },
+                "Holder": {
+                    "type": "string"
+                },
+                "ID": {
+                    "type": "string"
+                },
+                "Modified": {
+                    "type": "string"
+                },
+                "Position": {
+                    "type": "integer"
+                },
+                "Secret": {
+                    "type": "string"
+                },
+                "Status": {
+                    "type": "string"
+                },
+                "URL": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.WebhookRequest": {
+            "type": "object",
+            "properties": {
+                "Asset": {
+                    "type": "string"
+                },
+                "Holder": {
+                    "type": "string"
+                },
+                "Secret": {
+                    "type": "string"
+                },
+                "Status": {
+                    "type": "string"
+                },
+                "URL": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
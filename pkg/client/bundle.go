@@ -0,0 +1,111 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+)
+
+// Bundle is a self-contained proof of a single entry, it can be verified
+// without access to the database
+type Bundle struct {
+	Key            string                 `json:"Key"`
+	Entry          *schema.Entry          `json:"Entry"`
+	TxHeader       *schema.TxHeader       `json:"TxHeader"`
+	InclusionProof *schema.InclusionProof `json:"InclusionProof"`
+	DualProof      *schema.DualProof      `json:"DualProof"`
+	State          *schema.ImmutableState `json:"State"`
+}
+
+// Bundle creates the proof bundle of the key against the current database state
+func (c *Client) Bundle(ctx context.Context, key string) (*Bundle, error) {
+	state, err := c.storage.CurrentState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read database state: %v", err)
+	}
+
+	vEntry, err := c.storage.VerifiableGet(ctx, &schema.VerifiableGetRequest{
+		KeyRequest: &schema.KeyRequest{
+			Key:     []byte(key),
+			SinceTx: state.TxId,
+		},
+		ProveSinceTx: state.TxId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cant get key %v: %v", key, err)
+	}
+
+	b := &Bundle{
+		Key:            key,
+		Entry:          vEntry.Entry,
+		TxHeader:       vEntry.VerifiableTx.Tx.Header,
+		InclusionProof: vEntry.InclusionProof,
+		DualProof:      vEntry.VerifiableTx.DualProof,
+		State:          state,
+	}
+
+	err = b.Verify(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Verify checks the inclusion of the entry, the consistency of its transaction
+// with the state and the signature of the state if a public key is given
+func (b *Bundle) Verify(key *ecdsa.PublicKey) error {
+	v, err := verifyEntry([]byte(b.Key), 0, b.Entry, b.TxHeader, b.InclusionProof, b.DualProof, b.State)
+	if err != nil {
+		return err
+	}
+
+	if v.targetID != b.State.TxId || !bytes.Equal(v.targetAlh[:], b.State.TxHash) {
+		return fmt.Errorf("proof of key %v doesn't end at the state: %v", b.Key, store.ErrCorruptedData)
+	}
+
+	if b.TxHeader.Id != v.tx {
+		return fmt.Errorf("tx header %v doesn't match the entry tx %v: %v", b.TxHeader.Id, v.tx, store.ErrCorruptedData)
+	}
+
+	header := schema.TxHeaderFromProto(b.TxHeader).Alh()
+	proven := schema.DualProofFromProto(b.DualProof).SourceTxHeader.Alh()
+	if v.sourceID == v.tx && header != proven {
+		return fmt.Errorf("tx header %v isn't part of the proof: %v", b.TxHeader.Id, store.ErrCorruptedData)
+	}
+
+	if key != nil {
+		ok, err := b.State.CheckSignature(key)
+		if err != nil {
+			return fmt.Errorf("state signature of tx %v failed: %v", b.State.TxId, err)
+		}
+
+		if !ok {
+			return fmt.Errorf("state signature of tx %v failed: %v", b.State.TxId, store.ErrCorruptedData)
+		}
+	}
+
+	return nil
+}
+
+func (b *Bundle) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(b)
+}
+
+func ReadBundle(r io.Reader) (*Bundle, error) {
+	b := &Bundle{}
+	err := json.NewDecoder(r).Decode(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proof bundle: %v", err)
+	}
+
+	return b, nil
+}
@@ -2,22 +2,32 @@ package client
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"sync"
 
+	"github.com/codenotary/immudb/embedded/store"
 	"github.com/codenotary/immudb/pkg/api/schema"
 	immudb "github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/signer"
 	"github.com/ec-systems/core.ledger.server/pkg/logger"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 type Client struct {
-	client   immudb.ImmuClient
+	storage  Storage
+	owned    bool
 	options  *immudb.Options
 	limit    uint32
 	verified bool
 
+	lock       sync.Mutex
+	state      *schema.ImmutableState
+	unsaved    bool
+	stateDir   string
+	signingKey *ecdsa.PublicKey
+
 	user     []byte
 	password []byte
 	db       string
@@ -36,25 +46,70 @@ func New(ctx context.Context, user string, password string, db string, options .
 		}
 	}
 
-	client, err := immudb.NewImmuClient(cl.options)
+	if cl.options == nil {
+		cl.options = immudb.DefaultOptions()
+	}
+
+	if cl.options.ServerSigningPubKey != "" {
+		key, err := signer.ParsePublicKeyFile(cl.options.ServerSigningPubKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signing key: %v", err)
+		}
+
+		cl.signingKey = key
+	}
+
+	storage, err := newRemote(ctx, cl.options, cl.user, cl.password, cl.db)
 	if err != nil {
 		return nil, err
 	}
 
-	err = client.OpenSession(ctx, cl.user, cl.password, cl.db)
+	cl.stateDir = cl.options.Dir
+
+	cl.storage = storage
+	cl.owned = true
+
+	return cl, nil
+}
+
+// NewEmbedded opens an embedded database in the data directory, the database
+// is closed with the client
+func NewEmbedded(ctx context.Context, dir string, db string, options ...ClientOption) (*Client, error) {
+	storage, err := OpenEmbedded(dir, db)
 	if err != nil {
 		return nil, err
 	}
 
-	logger.Infof("Connected to immudb database '%v' (%v:%v)", cl.db, cl.options.Address, cl.options.Port)
-
-	cl.client = client
+	cl := FromStorage(storage, options...)
+	cl.db = db
+	cl.owned = true
+	cl.stateDir = dir
 
 	return cl, nil
 }
 
+// FromStorage creates a client of an already opened storage, closing the
+// client doesn't close the storage
+func FromStorage(storage Storage, options ...ClientOption) *Client {
+	cl := &Client{
+		storage: storage,
+	}
+
+	for _, option := range options {
+		if option != nil {
+			option.Set(cl)
+		}
+	}
+
+	return cl
+}
+
 func (c *Client) Close(ctx context.Context) error {
-	err := c.client.CloseSession(ctx)
+	if !c.owned {
+		return nil
+	}
+
+	err := c.storage.Close(ctx)
 	if err == nil {
 		logger.Info("Database disconnected")
 	} else {
@@ -65,9 +120,14 @@ func (c *Client) Close(ctx context.Context) error {
 }
 
 func (c *Client) DatabaseExist(ctx context.Context, name string) (bool, error) {
-	resp, err := c.client.DatabaseListV2(ctx)
-	for !c.checkSessionError(ctx, err) {
-		resp, err = c.client.DatabaseListV2(ctx)
+	r, err := c.server()
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := r.client.DatabaseListV2(ctx)
+	for !r.checkSessionError(ctx, err) {
+		resp, err = r.client.DatabaseListV2(ctx)
 	}
 
 	if err != nil {
@@ -84,21 +144,31 @@ func (c *Client) DatabaseExist(ctx context.Context, name string) (bool, error) {
 }
 
 func (c *Client) CreateDatabase(ctx context.Context, name string) error {
-	_, err := c.client.CreateDatabaseV2(ctx, name, nil)
-	for !c.checkSessionError(ctx, err) {
-		_, err = c.client.CreateDatabaseV2(ctx, name, nil)
+	r, err := c.server()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.CreateDatabaseV2(ctx, name, nil)
+	for !r.checkSessionError(ctx, err) {
+		_, err = r.client.CreateDatabaseV2(ctx, name, nil)
 	}
 
 	return err
 }
 
 func (c *Client) UnloadDatabase(ctx context.Context, name string) error {
-	_, err := c.client.UnloadDatabase(ctx, &schema.UnloadDatabaseRequest{
+	r, err := c.server()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.UnloadDatabase(ctx, &schema.UnloadDatabaseRequest{
 		Database: name,
 	})
 
-	for !c.checkSessionError(ctx, err) {
-		_, err = c.client.UnloadDatabase(ctx, &schema.UnloadDatabaseRequest{
+	for !r.checkSessionError(ctx, err) {
+		_, err = r.client.UnloadDatabase(ctx, &schema.UnloadDatabaseRequest{
 			Database: name,
 		})
 	}
@@ -107,12 +177,17 @@ func (c *Client) UnloadDatabase(ctx context.Context, name string) error {
 }
 
 func (c *Client) DeleteDatabase(ctx context.Context, name string) error {
-	_, err := c.client.DeleteDatabase(ctx, &schema.DeleteDatabaseRequest{
+	r, err := c.server()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.DeleteDatabase(ctx, &schema.DeleteDatabaseRequest{
 		Database: name,
 	})
 
-	for !c.checkSessionError(ctx, err) {
-		_, err = c.client.DeleteDatabase(ctx, &schema.DeleteDatabaseRequest{
+	for !r.checkSessionError(ctx, err) {
+		_, err = r.client.DeleteDatabase(ctx, &schema.DeleteDatabaseRequest{
 			Database: name,
 		})
 	}
@@ -170,18 +245,11 @@ func (c *Client) Exec(ctx context.Context, operations ...interface{}) (uint64, e
 		}
 	}
 
-	tx, err := c.client.ExecAll(ctx, &schema.ExecAllRequest{
+	tx, err := c.storage.ExecAll(ctx, &schema.ExecAllRequest{
 		Operations:    ops,
 		Preconditions: pre,
 	})
 
-	for !c.checkSessionError(ctx, err) {
-		tx, err = c.client.ExecAll(ctx, &schema.ExecAllRequest{
-			Operations:    ops,
-			Preconditions: pre,
-		})
-	}
-
 	if err != nil {
 		return 0, err
 	}
@@ -190,19 +258,26 @@ func (c *Client) Exec(ctx context.Context, operations ...interface{}) (uint64, e
 }
 
 func (c *Client) set(ctx context.Context, key []byte, value []byte) (*schema.TxHeader, error) {
-	if c.verified {
-		header, err := c.client.VerifiedSet(ctx, key, value)
-		for !c.checkSessionError(ctx, err) {
-			header, err = c.client.VerifiedSet(ctx, key, value)
-		}
-		return header, err
-	} else {
-		header, err := c.client.Set(ctx, key, value)
-		for !c.checkSessionError(ctx, err) {
-			header, err = c.client.Set(ctx, key, value)
+	if r, err := c.server(); err == nil && c.verified {
+		header, err := r.client.VerifiedSet(ctx, key, value)
+		for !r.checkSessionError(ctx, err) {
+			header, err = r.client.VerifiedSet(ctx, key, value)
 		}
 		return header, err
 	}
+
+	return c.storage.ExecAll(ctx, &schema.ExecAllRequest{
+		Operations: []*schema.Op{
+			{
+				Operation: &schema.Op_Kv{
+					Kv: &schema.KeyValue{
+						Key:   key,
+						Value: value,
+					},
+				},
+			},
+		},
+	})
 }
 
 func (c *Client) Set(ctx context.Context, key []byte, value interface{}) (uint64, error) {
@@ -237,24 +312,13 @@ func (c *Client) Set(ctx context.Context, key []byte, value interface{}) (uint64
 	}
 }
 
-func (c *Client) get(ctx context.Context, key []byte) (*schema.Entry, error) {
+func (c *Client) Get(ctx context.Context, key string) (*schema.Entry, error) {
 	if c.verified {
-		entry, err := c.client.VerifiedGet(ctx, key)
-		for !c.checkSessionError(ctx, err) {
-			entry, err = c.client.VerifiedGet(ctx, key)
-		}
-		return entry, err
-	} else {
-		entry, err := c.client.Get(ctx, key)
-		for !c.checkSessionError(ctx, err) {
-			entry, err = c.client.Get(ctx, key)
-		}
+		entry, _, err := c.VerifiedGet(ctx, key, 0)
 		return entry, err
 	}
-}
 
-func (c *Client) Get(ctx context.Context, key string) (*schema.Entry, error) {
-	tx, err := c.get(ctx, []byte(key))
+	tx, err := c.storage.Get(ctx, []byte(key))
 	if err != nil {
 		return nil, fmt.Errorf("cant get key %v: %v", key, err)
 	}
@@ -262,24 +326,13 @@ func (c *Client) Get(ctx context.Context, key string) (*schema.Entry, error) {
 	return tx, nil
 }
 
-func (c *Client) getAt(ctx context.Context, key []byte, tx uint64) (*schema.Entry, error) {
+func (c *Client) GetAt(ctx context.Context, key string, txID uint64) (*schema.Entry, error) {
 	if c.verified {
-		entry, err := c.client.VerifiedGetAt(ctx, key, tx)
-		for !c.checkSessionError(ctx, err) {
-			entry, err = c.client.VerifiedGetAt(ctx, key, tx)
-		}
-		return entry, err
-	} else {
-		entry, err := c.client.GetAt(ctx, key, tx)
-		for !c.checkSessionError(ctx, err) {
-			entry, err = c.client.GetAt(ctx, key, tx)
-		}
+		entry, _, err := c.VerifiedGet(ctx, key, txID)
 		return entry, err
 	}
-}
 
-func (c *Client) GetAt(ctx context.Context, key string, txID uint64) (*schema.Entry, error) {
-	tx, err := c.getAt(ctx, []byte(key), txID)
+	tx, err := c.storage.GetAt(ctx, []byte(key), txID)
 	if err != nil {
 		return nil, fmt.Errorf("cant get key %v: %v", key, err)
 	}
@@ -287,12 +340,57 @@ func (c *Client) GetAt(ctx context.Context, key string, txID uint64) (*schema.En
 	return tx, nil
 }
 
-func (c *Client) GetTx(ctx context.Context, id uint64) (*schema.Tx, error) {
-	tx, err := c.client.TxByID(ctx, id)
-	for !c.checkSessionError(ctx, err) {
-		tx, err = c.client.TxByID(ctx, id)
+// GetAsOf reads the version of the key which was current at the given
+// transaction, the store returns the history from the latest version
+// backwards so only the versions written after the transaction are read
+func (c *Client) GetAsOf(ctx context.Context, key string, txID uint64) (*schema.Entry, error) {
+	offset := uint64(0)
+
+	for {
+		req := &schema.HistoryRequest{
+			Key:    []byte(key),
+			Limit:  int32(c.limit),
+			Offset: offset,
+			Desc:   true,
+		}
+
+		list, err := c.storage.History(ctx, req)
+		if err != nil && offset > 0 && strings.Contains(err.Error(), store.ErrNoMoreEntries.Error()) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("cant get key %v at tx %v: %v", key, txID, err)
+		}
+
+		err = c.verifyScan(ctx, list.Entries, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range list.Entries {
+			if e.Tx <= txID {
+				return e, c.persistTrustedState()
+			}
+		}
+
+		if len(list.Entries) == 0 || len(list.Entries) < int(req.Limit) {
+			break
+		}
+
+		offset += uint64(len(list.Entries))
 	}
-	return tx, err
+
+	err := c.persistTrustedState()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("cant get key %v at tx %v: %v", key, txID, store.ErrKeyNotFound)
+}
+
+func (c *Client) GetTx(ctx context.Context, id uint64) (*schema.Tx, error) {
+	return c.storage.TxByID(ctx, id)
 }
 
 func (c *Client) History(ctx context.Context, key string, f func(context.Context, *schema.Entry) (bool, error)) error {
@@ -307,15 +405,17 @@ func (c *Client) History(ctx context.Context, key string, f func(context.Context
 			Offset: offset,
 		}
 
-		list, err := c.client.History(ctx, req)
-		for !c.checkSessionError(ctx, err) {
-			list, err = c.client.History(ctx, req)
-		}
+		list, err := c.storage.History(ctx, req)
 
 		if err != nil {
 			return fmt.Errorf("failed to read history of key %v: %v", key, err)
 		}
 
+		err = c.verifyScan(ctx, list.Entries, nil)
+		if err != nil {
+			return err
+		}
+
 		for _, v := range list.Entries {
 			ok, err := f(ctx, v)
 			if err != nil {
@@ -335,14 +435,11 @@ func (c *Client) History(ctx context.Context, key string, f func(context.Context
 		}
 	}
 
-	return nil
+	return c.persistTrustedState()
 }
 
 func (c *Client) LastTX(ctx context.Context) (uint64, error) {
-	state, err := c.client.CurrentState(ctx)
-	for !c.checkSessionError(ctx, err) {
-		state, err = c.client.CurrentState(ctx)
-	}
+	state, err := c.storage.CurrentState(ctx)
 
 	if err != nil {
 		return 0, err
@@ -359,15 +456,22 @@ func (c *Client) Scan(ctx context.Context, prefix string, limit uint64, desc boo
 		Desc:   desc,
 	}
 
-	list, err := c.client.StreamScan(ctx, scanReq)
-	for !c.checkSessionError(ctx, err) {
-		list, err = c.client.StreamScan(ctx, scanReq)
-	}
+	list, err := c.storage.Scan(ctx, scanReq)
 
 	if err != nil {
 		return nil, fmt.Errorf("error scan %v: %v", prefix, err)
 	}
 
+	err = c.verifyScan(ctx, list.Entries, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.persistTrustedState()
+	if err != nil {
+		return nil, err
+	}
+
 	return list.Entries, nil
 }
 
@@ -388,15 +492,17 @@ func (c *Client) ScanSince(ctx context.Context, prefix string, desc bool, since
 			SinceTx: since,
 		}
 
-		list, err := c.client.Scan(ctx, scanReq)
-		for !c.checkSessionError(ctx, err) {
-			list, err = c.client.Scan(ctx, scanReq)
-		}
+		list, err := c.storage.Scan(ctx, scanReq)
 
 		if err != nil {
 			return fmt.Errorf("error scan %v: %v", prefix, err)
 		}
 
+		err = c.verifyScan(ctx, list.Entries, nil)
+		if err != nil {
+			return err
+		}
+
 		for i, v := range list.Entries {
 			ok, err := f(ctx, i, v)
 			if err != nil {
@@ -418,19 +524,36 @@ func (c *Client) ScanSince(ctx context.Context, prefix string, desc bool, since
 		}
 	}
 
-	return nil
+	return c.persistTrustedState()
 }
 
 func (c *Client) ScanSet(ctx context.Context, set string, desc bool, f func(context.Context, *schema.ZEntry) (bool, error)) error {
-	var last *schema.ZEntry
+	return c.scanSet(ctx, set, desc, nil, nil, nil, f)
+}
+
+// ScanSetUntil scans a sorted set up to the given max score
+func (c *Client) ScanSetUntil(ctx context.Context, set string, desc bool, score float64, f func(context.Context, *schema.ZEntry) (bool, error)) error {
+	return c.scanSet(ctx, set, desc, nil, &schema.Score{Score: score}, nil, f)
+}
+
+// ScanSetRange scans a sorted set between the min and max score, the scan
+// continues after the seek entry if given
+func (c *Client) ScanSetRange(ctx context.Context, set string, desc bool, min *schema.Score, max *schema.Score, seek *schema.ZEntry, f func(context.Context, *schema.ZEntry) (bool, error)) error {
+	return c.scanSet(ctx, set, desc, min, max, seek, f)
+}
+
+func (c *Client) scanSet(ctx context.Context, set string, desc bool, min *schema.Score, max *schema.Score, seek *schema.ZEntry, f func(context.Context, *schema.ZEntry) (bool, error)) error {
+	last := seek
 
 	running := true
 
 	for running {
 		scanReq := &schema.ZScanRequest{
-			Set:   []byte(set),
-			Limit: uint64(c.limit),
-			Desc:  desc,
+			Set:      []byte(set),
+			Limit:    uint64(c.limit),
+			Desc:     desc,
+			MinScore: min,
+			MaxScore: max,
 		}
 
 		if last != nil {
@@ -439,15 +562,17 @@ func (c *Client) ScanSet(ctx context.Context, set string, desc bool, f func(cont
 			scanReq.SeekAtTx = last.AtTx
 		}
 
-		list, err := c.client.ZScan(ctx, scanReq)
-		for !c.checkSessionError(ctx, err) {
-			list, err = c.client.ZScan(ctx, scanReq)
-		}
+		list, err := c.storage.ZScan(ctx, scanReq)
 
 		if err != nil {
 			return fmt.Errorf("error scan set %v: %v", set, err)
 		}
 
+		err = c.verifyScan(ctx, nil, list.Entries)
+		if err != nil {
+			return err
+		}
+
 		for _, v := range list.Entries {
 			ok, err := f(ctx, v)
 			if err != nil {
@@ -467,61 +592,41 @@ func (c *Client) ScanSet(ctx context.Context, set string, desc bool, f func(cont
 		last = list.Entries[len(list.Entries)-1]
 	}
 
-	return nil
+	return c.persistTrustedState()
 }
 
 func (c *Client) Export(ctx context.Context, tx uint64) (schema.ImmuService_ExportTxClient, error) {
+	r, err := c.server()
+	if err != nil {
+		return nil, err
+	}
+
 	req := &schema.ExportTxRequest{
 		Tx: tx,
 	}
 
-	client, err := c.client.ExportTx(ctx, req)
-	for !c.checkSessionError(ctx, err) {
-		client, err = c.client.ExportTx(ctx, req)
+	client, err := r.client.ExportTx(ctx, req)
+	for !r.checkSessionError(ctx, err) {
+		client, err = r.client.ExportTx(ctx, req)
 	}
 
 	return client, err
 }
 
 func (c *Client) Replicate(ctx context.Context) (schema.ImmuService_ReplicateTxClient, error) {
-	client, err := c.client.ReplicateTx(ctx)
-	for !c.checkSessionError(ctx, err) {
-		client, err = c.client.ReplicateTx(ctx)
+	r, err := c.server()
+	if err != nil {
+		return nil, err
 	}
 
-	return client, err
-}
-
-func (c *Client) Health(ctx context.Context) (*schema.DatabaseHealthResponse, error) {
-	response, err := c.client.Health(ctx)
-	for !c.checkSessionError(ctx, err) {
-		response, err = c.client.Health(ctx)
+	client, err := r.client.ReplicateTx(ctx)
+	for !r.checkSessionError(ctx, err) {
+		client, err = r.client.ReplicateTx(ctx)
 	}
 
-	return response, err
+	return client, err
 }
 
-func (c *Client) checkSessionError(ctx context.Context, err error) bool {
-	if err == nil {
-		return true
-	}
-
-	code, ok := status.FromError(err)
-	if ok {
-
-		if code.Code() == codes.PermissionDenied {
-
-			c.client.CloseSession(ctx)
-
-			err = c.client.OpenSession(ctx, c.user, c.password, c.db)
-			if err != nil {
-				logger.Error(err)
-				return false
-			}
-
-			return false
-		}
-	}
-
-	return true
+func (c *Client) Health(ctx context.Context) (*schema.DatabaseHealthResponse, error) {
+	return c.storage.Health(ctx)
 }
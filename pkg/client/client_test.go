@@ -3,8 +3,11 @@ package client_test
 import (
 	"context"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/codenotary/immudb/pkg/api/schema"
 	"github.com/ec-systems/core.ledger.server/pkg/client"
@@ -14,15 +17,7 @@ import (
 func Test_Get(t *testing.T) {
 	ctx := context.Background()
 
-	client, err := client.New(ctx, cfg.Username, cfg.Password, cfg.Database,
-		client.ClientOptions(cfg),
-		client.Limit(5),
-	)
-
-	if !assert.NoError(t, err) {
-		return
-	}
-
+	client := client.FromStorage(store, client.Limit(5))
 	defer client.Close(ctx)
 
 	key := randomName()
@@ -108,3 +103,116 @@ func Test_Get(t *testing.T) {
 	assert.Contains(t, sets, setName1)
 	assert.Contains(t, sets, setName2)
 }
+
+func Test_NewNilOptions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// the default options are used, there is no server to connect to
+	assert.NotPanics(t, func() {
+		_, err := client.New(ctx, "immudb", "immudb", "defaultdb", nil, client.ClientOptions(nil))
+		assert.Error(t, err)
+	})
+}
+
+func Test_VerifiedScan(t *testing.T) {
+	ctx := context.Background()
+
+	dir, err := os.MkdirTemp("", "verified")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	cl, err := client.NewEmbedded(ctx, dir, "verified", client.Limit(5), client.Verified())
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer cl.Close(ctx)
+
+	prefix := randomName()
+	set := randomName()
+	key1 := prefix + ".1"
+	key2 := prefix + ".2"
+
+	add := func(key string, value string, score float64) {
+		_, err := cl.Exec(ctx,
+			&schema.Op_Kv{Kv: &schema.KeyValue{Key: []byte(key), Value: []byte(value)}},
+			&schema.Op_ZAdd{ZAdd: &schema.ZAddRequest{Key: []byte(key), Set: []byte(set), Score: score}},
+		)
+		assert.NoError(t, err)
+	}
+
+	add(key1, "a", 1)
+	add(key2, "b", 2)
+
+	// the member is only written with the first version of the key
+	last, err := cl.Set(ctx, []byte(key1), "c")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	values := []string{}
+	err = cl.ScanSet(ctx, set, false, func(ctx context.Context, e *schema.ZEntry) (bool, error) {
+		values = append(values, string(e.Entry.Value))
+		return true, nil
+	})
+	if assert.NoError(t, err) {
+		assert.Len(t, values, 2)
+	}
+
+	state, err := cl.TrustedState()
+	if assert.NoError(t, err) {
+		assert.Equal(t, last, state.TxId)
+	}
+
+	_, err = os.Stat(filepath.Join(dir, ".ledger-state-verified"))
+	assert.NoError(t, err)
+
+	versions := 0
+	err = cl.History(ctx, key1, func(ctx context.Context, e *schema.Entry) (bool, error) {
+		versions++
+		return true, nil
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, versions)
+	}
+
+	entries, err := cl.Scan(ctx, prefix, 10, false)
+	if assert.NoError(t, err) {
+		assert.Len(t, entries, 2)
+	}
+}
+
+func Test_GetAsOf(t *testing.T) {
+	ctx := context.Background()
+
+	client := client.FromStorage(store, client.Limit(1))
+	defer client.Close(ctx)
+
+	key := randomName()
+
+	tx1, err := client.Set(ctx, []byte(key), "a")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	tx2, err := client.Set(ctx, []byte(key), "b")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	e, err := client.GetAsOf(ctx, key, tx2-1)
+	if assert.NoError(t, err) {
+		assert.Equal(t, tx1, e.Tx)
+		assert.Equal(t, "a", string(e.Value))
+	}
+
+	e, err = client.GetAsOf(ctx, key, tx2)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "b", string(e.Value))
+	}
+
+	_, err = client.GetAsOf(ctx, key, tx1-1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/database"
+	immulogger "github.com/codenotary/immudb/pkg/logger"
+	"github.com/ec-systems/core.ledger.server/pkg/logger"
+)
+
+// embedded is an immudb database running in-process, it needs no server
+// and is used for tests and single node deployments
+type embedded struct {
+	db database.DB
+}
+
+// OpenEmbedded opens the database in the data directory, it is created if
+// it doesn't exist yet
+func OpenEmbedded(dir string, db string) (Storage, error) {
+	options := database.DefaultOption().WithDBRootPath(dir)
+
+	var d database.DB
+	var err error
+
+	_, err = os.Stat(filepath.Join(dir, db))
+	if os.IsNotExist(err) {
+		d, err = database.NewDB(db, nil, options, &dbLogger{})
+	} else {
+		d, err = database.OpenDB(db, nil, options, &dbLogger{})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Opened embedded database '%v' (%v)", db, dir)
+
+	return &embedded{
+		db: d,
+	}, nil
+}
+
+func (e *embedded) Close(ctx context.Context) error {
+	return e.db.Close()
+}
+
+func (e *embedded) ExecAll(ctx context.Context, req *schema.ExecAllRequest) (*schema.TxHeader, error) {
+	return e.db.ExecAll(req)
+}
+
+func (e *embedded) Get(ctx context.Context, key []byte) (*schema.Entry, error) {
+	return e.db.Get(&schema.KeyRequest{
+		Key: key,
+	})
+}
+
+func (e *embedded) GetAt(ctx context.Context, key []byte, tx uint64) (*schema.Entry, error) {
+	return e.db.Get(&schema.KeyRequest{
+		Key:  key,
+		AtTx: tx,
+	})
+}
+
+func (e *embedded) VerifiableGet(ctx context.Context, req *schema.VerifiableGetRequest) (*schema.VerifiableEntry, error) {
+	return e.db.VerifiableGet(req)
+}
+
+func (e *embedded) VerifiableTxByID(ctx context.Context, req *schema.VerifiableTxRequest) (*schema.VerifiableTx, error) {
+	return e.db.VerifiableTxByID(req)
+}
+
+func (e *embedded) Scan(ctx context.Context, req *schema.ScanRequest) (*schema.Entries, error) {
+	return e.db.Scan(req)
+}
+
+func (e *embedded) ZScan(ctx context.Context, req *schema.ZScanRequest) (*schema.ZEntries, error) {
+	return e.db.ZScan(req)
+}
+
+func (e *embedded) History(ctx context.Context, req *schema.HistoryRequest) (*schema.Entries, error) {
+	return e.db.History(req)
+}
+
+func (e *embedded) TxByID(ctx context.Context, id uint64) (*schema.Tx, error) {
+	tx, err := e.db.TxByID(&schema.TxRequest{
+		Tx: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// strip the key prefix like the remote client does
+	for _, entry := range tx.Entries {
+		entry.Key = entry.Key[1:]
+	}
+
+	return tx, nil
+}
+
+func (e *embedded) CurrentState(ctx context.Context) (*schema.ImmutableState, error) {
+	return e.db.CurrentState()
+}
+
+func (e *embedded) Health(ctx context.Context) (*schema.DatabaseHealthResponse, error) {
+	pending, last := e.db.Health()
+
+	return &schema.DatabaseHealthResponse{
+		PendingRequests:        uint32(pending),
+		LastRequestCompletedAt: last.UnixNano() / int64(time.Millisecond),
+	}, nil
+}
+
+// dbLogger writes the logs of the embedded database to the ledger logger
+type dbLogger struct{}
+
+func (l *dbLogger) Errorf(format string, args ...interface{}) {
+	logger.Errorf(format, args...)
+}
+
+func (l *dbLogger) Warningf(format string, args ...interface{}) {
+	logger.Warnf(format, args...)
+}
+
+func (l *dbLogger) Infof(format string, args ...interface{}) {
+	logger.Debugf(format, args...)
+}
+
+func (l *dbLogger) Debugf(format string, args ...interface{}) {
+	logger.Debugf(format, args...)
+}
+
+func (l *dbLogger) CloneWithLevel(level immulogger.LogLevel) immulogger.Logger {
+	return l
+}
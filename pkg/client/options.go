@@ -28,6 +28,7 @@ func Verified(value ...bool) ClientOption {
 	return ClientOptionFunc(func(c *Client) {
 		if len(value) == 0 {
 			c.verified = true
+			return
 		}
 
 		c.verified = value[0]
@@ -32,6 +32,8 @@ const (
 )
 
 var (
+	store client.Storage
+
 	cfg = &immudb.Options{
 		Dir:                "./testdata",
 		Address:            CLIENT_OPTIONS_ADDRESS,
@@ -62,47 +64,21 @@ func TestMain(m *testing.M) {
 	rand.Seed(time.Now().UTC().UnixNano())
 
 	ctx := context.Background()
-	var cl *client.Client
-	var err error
-
-	cl, err = client.New(ctx, cfg.Username, cfg.Password, "defaultdb",
-		client.ClientOptions(cfg),
-		client.Limit(5),
-	)
-
-	if err != nil {
-		log.Fatal(err)
-	}
 
-	exists, err := cl.DatabaseExist(ctx, cfg.Database)
+	dir, err := os.MkdirTemp("", "client")
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	if exists {
-		log.Printf("Delete test database: %v", cfg.Database)
-
-		err := cl.UnloadDatabase(ctx, cfg.Database)
-		if err != nil {
-			log.Fatal(err)
-		}
-
-		err = cl.DeleteDatabase(ctx, cfg.Database)
-		if err != nil {
-			log.Fatal(err)
-		}
-	}
-
-	log.Printf("Create test database: %v", cfg.Database)
-
-	err = cl.CreateDatabase(ctx, cfg.Database)
+	store, err = client.OpenEmbedded(dir, cfg.Database)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	code := m.Run()
 
-	cl.Close(ctx)
+	store.Close(ctx)
+	os.RemoveAll(dir)
 
 	os.Exit(code)
 }
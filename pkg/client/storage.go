@@ -0,0 +1,196 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	immudb "github.com/codenotary/immudb/pkg/client"
+	"github.com/ec-systems/core.ledger.server/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Storage is the database behind a client, either a remote immudb server
+// or an embedded in-process immudb database
+type Storage interface {
+	ExecAll(ctx context.Context, req *schema.ExecAllRequest) (*schema.TxHeader, error)
+	Get(ctx context.Context, key []byte) (*schema.Entry, error)
+	GetAt(ctx context.Context, key []byte, tx uint64) (*schema.Entry, error)
+	Scan(ctx context.Context, req *schema.ScanRequest) (*schema.Entries, error)
+	ZScan(ctx context.Context, req *schema.ZScanRequest) (*schema.ZEntries, error)
+	History(ctx context.Context, req *schema.HistoryRequest) (*schema.Entries, error)
+	VerifiableGet(ctx context.Context, req *schema.VerifiableGetRequest) (*schema.VerifiableEntry, error)
+	VerifiableTxByID(ctx context.Context, req *schema.VerifiableTxRequest) (*schema.VerifiableTx, error)
+	TxByID(ctx context.Context, id uint64) (*schema.Tx, error)
+	CurrentState(ctx context.Context) (*schema.ImmutableState, error)
+	Health(ctx context.Context) (*schema.DatabaseHealthResponse, error)
+	Close(ctx context.Context) error
+}
+
+// remote is the storage of an immudb server, the session is reopened if it expired
+type remote struct {
+	client immudb.ImmuClient
+
+	user     []byte
+	password []byte
+	db       string
+}
+
+func newRemote(ctx context.Context, options *immudb.Options, user []byte, password []byte, db string) (*remote, error) {
+	client, err := immudb.NewImmuClient(options)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.OpenSession(ctx, user, password, db)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Connected to immudb database '%v' (%v:%v)", db, options.Address, options.Port)
+
+	return &remote{
+		client:   client,
+		user:     user,
+		password: password,
+		db:       db,
+	}, nil
+}
+
+func (r *remote) Close(ctx context.Context) error {
+	return r.client.CloseSession(ctx)
+}
+
+func (r *remote) ExecAll(ctx context.Context, req *schema.ExecAllRequest) (*schema.TxHeader, error) {
+	tx, err := r.client.ExecAll(ctx, req)
+	for !r.checkSessionError(ctx, err) {
+		tx, err = r.client.ExecAll(ctx, req)
+	}
+
+	return tx, err
+}
+
+func (r *remote) Get(ctx context.Context, key []byte) (*schema.Entry, error) {
+	entry, err := r.client.Get(ctx, key)
+	for !r.checkSessionError(ctx, err) {
+		entry, err = r.client.Get(ctx, key)
+	}
+
+	return entry, err
+}
+
+func (r *remote) GetAt(ctx context.Context, key []byte, tx uint64) (*schema.Entry, error) {
+	entry, err := r.client.GetAt(ctx, key, tx)
+	for !r.checkSessionError(ctx, err) {
+		entry, err = r.client.GetAt(ctx, key, tx)
+	}
+
+	return entry, err
+}
+
+func (r *remote) VerifiableGet(ctx context.Context, req *schema.VerifiableGetRequest) (*schema.VerifiableEntry, error) {
+	entry, err := r.client.GetServiceClient().VerifiableGet(ctx, req)
+	for !r.checkSessionError(ctx, err) {
+		entry, err = r.client.GetServiceClient().VerifiableGet(ctx, req)
+	}
+
+	return entry, err
+}
+
+func (r *remote) VerifiableTxByID(ctx context.Context, req *schema.VerifiableTxRequest) (*schema.VerifiableTx, error) {
+	tx, err := r.client.GetServiceClient().VerifiableTxById(ctx, req)
+	for !r.checkSessionError(ctx, err) {
+		tx, err = r.client.GetServiceClient().VerifiableTxById(ctx, req)
+	}
+
+	return tx, err
+}
+
+func (r *remote) Scan(ctx context.Context, req *schema.ScanRequest) (*schema.Entries, error) {
+	list, err := r.client.Scan(ctx, req)
+	for !r.checkSessionError(ctx, err) {
+		list, err = r.client.Scan(ctx, req)
+	}
+
+	return list, err
+}
+
+func (r *remote) ZScan(ctx context.Context, req *schema.ZScanRequest) (*schema.ZEntries, error) {
+	list, err := r.client.ZScan(ctx, req)
+	for !r.checkSessionError(ctx, err) {
+		list, err = r.client.ZScan(ctx, req)
+	}
+
+	return list, err
+}
+
+func (r *remote) History(ctx context.Context, req *schema.HistoryRequest) (*schema.Entries, error) {
+	list, err := r.client.History(ctx, req)
+	for !r.checkSessionError(ctx, err) {
+		list, err = r.client.History(ctx, req)
+	}
+
+	return list, err
+}
+
+func (r *remote) TxByID(ctx context.Context, id uint64) (*schema.Tx, error) {
+	tx, err := r.client.TxByID(ctx, id)
+	for !r.checkSessionError(ctx, err) {
+		tx, err = r.client.TxByID(ctx, id)
+	}
+
+	return tx, err
+}
+
+func (r *remote) CurrentState(ctx context.Context) (*schema.ImmutableState, error) {
+	state, err := r.client.CurrentState(ctx)
+	for !r.checkSessionError(ctx, err) {
+		state, err = r.client.CurrentState(ctx)
+	}
+
+	return state, err
+}
+
+func (r *remote) Health(ctx context.Context) (*schema.DatabaseHealthResponse, error) {
+	response, err := r.client.Health(ctx)
+	for !r.checkSessionError(ctx, err) {
+		response, err = r.client.Health(ctx)
+	}
+
+	return response, err
+}
+
+func (r *remote) checkSessionError(ctx context.Context, err error) bool {
+	if err == nil {
+		return true
+	}
+
+	code, ok := status.FromError(err)
+	if ok {
+
+		if code.Code() == codes.PermissionDenied {
+
+			r.client.CloseSession(ctx)
+
+			err = r.client.OpenSession(ctx, r.user, r.password, r.db)
+			if err != nil {
+				logger.Error(err)
+				return false
+			}
+
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *Client) server() (*remote, error) {
+	r, ok := c.storage.(*remote)
+	if !ok {
+		return nil, fmt.Errorf("not supported by the embedded storage")
+	}
+
+	return r, nil
+}
@@ -0,0 +1,470 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/database"
+)
+
+// Proof is the result of a verified read
+type Proof struct {
+	Key       string `json:"Key"`
+	Tx        uint64 `json:"Tx"`
+	Verified  bool   `json:"Verified"`
+	Signed    bool   `json:"Signed"`
+	SourceTx  uint64 `json:"SourceTx"`
+	TargetTx  uint64 `json:"TargetTx"`
+	TargetAlh string `json:"TargetAlh"`
+	Leaf      int32  `json:"Leaf"`
+	Width     int32  `json:"Width"`
+}
+
+// VerifiedGet reads the key and verifies the entry against the trusted state,
+// the trusted state moves forward to the proven transaction
+func (c *Client) VerifiedGet(ctx context.Context, key string, atTx uint64) (*schema.Entry, *Proof, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	state, err := c.trustedState()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vEntry, err := c.storage.VerifiableGet(ctx, &schema.VerifiableGetRequest{
+		KeyRequest: &schema.KeyRequest{
+			Key:  []byte(key),
+			AtTx: atTx,
+		},
+		ProveSinceTx: state.TxId,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("cant get key %v: %v", key, err)
+	}
+
+	v, err := verifyEntry([]byte(key), atTx, vEntry.Entry, vEntry.VerifiableTx.Tx.Header, vEntry.InclusionProof, vEntry.VerifiableTx.DualProof, state)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	newState := &schema.ImmutableState{
+		Db:        c.db,
+		TxId:      v.targetID,
+		TxHash:    v.targetAlh[:],
+		Signature: vEntry.VerifiableTx.Signature,
+	}
+
+	if c.signingKey != nil {
+		ok, err := newState.CheckSignature(c.signingKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("state signature of tx %v failed: %v", v.targetID, err)
+		}
+
+		if !ok {
+			return nil, nil, fmt.Errorf("state signature of tx %v failed: %v", v.targetID, store.ErrCorruptedData)
+		}
+	}
+
+	err = c.setTrustedState(newState)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return vEntry.Entry, &Proof{
+		Key:       key,
+		Tx:        v.tx,
+		Verified:  true,
+		Signed:    c.signingKey != nil,
+		SourceTx:  v.sourceID,
+		TargetTx:  v.targetID,
+		TargetAlh: hex.EncodeToString(v.targetAlh[:]),
+		Leaf:      vEntry.InclusionProof.Leaf,
+		Width:     vEntry.InclusionProof.Width,
+	}, nil
+}
+
+type verification struct {
+	tx        uint64
+	sourceID  uint64
+	targetID  uint64
+	targetAlh [sha256.Size]byte
+}
+
+// verifyEntry checks the inclusion of the entry in its transaction and the
+// consistency of that transaction with the state
+func verifyEntry(key []byte, atTx uint64, entry *schema.Entry, header *schema.TxHeader, inclusion *schema.InclusionProof, dual *schema.DualProof, state *schema.ImmutableState) (*verification, error) {
+	if entry == nil || header == nil || inclusion == nil || dual == nil || state == nil {
+		return nil, fmt.Errorf("incomplete proof of key %v: %v", string(key), store.ErrCorruptedData)
+	}
+
+	entrySpecDigest, err := store.EntrySpecDigestFor(int(header.Version))
+	if err != nil {
+		return nil, err
+	}
+
+	inclusionProof := schema.InclusionProofFromProto(inclusion)
+	dualProof := schema.DualProofFromProto(dual)
+
+	var eh [sha256.Size]byte
+	var sourceAlh [sha256.Size]byte
+	var e *store.EntrySpec
+
+	v := &verification{
+		tx: atTx,
+	}
+
+	if entry.ReferencedBy == nil {
+		if atTx == 0 {
+			v.tx = entry.Tx
+		}
+
+		e = database.EncodeEntrySpec(key, schema.KVMetadataFromProto(entry.Metadata), entry.Value)
+	} else {
+		ref := entry.ReferencedBy
+
+		if atTx == 0 {
+			v.tx = ref.Tx
+		}
+
+		e = database.EncodeReference(key, schema.KVMetadataFromProto(ref.Metadata), entry.Key, ref.AtTx)
+	}
+
+	if state.TxId <= v.tx {
+		eh = schema.DigestFromProto(dual.TargetTxHeader.EH)
+
+		v.sourceID = state.TxId
+		sourceAlh = schema.DigestFromProto(state.TxHash)
+		v.targetID = v.tx
+		v.targetAlh = dualProof.TargetTxHeader.Alh()
+	} else {
+		eh = schema.DigestFromProto(dual.SourceTxHeader.EH)
+
+		v.sourceID = v.tx
+		sourceAlh = dualProof.SourceTxHeader.Alh()
+		v.targetID = state.TxId
+		v.targetAlh = schema.DigestFromProto(state.TxHash)
+	}
+
+	if !store.VerifyInclusion(inclusionProof, entrySpecDigest(e), eh) {
+		return nil, fmt.Errorf("inclusion proof of key %v failed: %v", string(key), store.ErrCorruptedData)
+	}
+
+	if state.TxId > 0 && !store.VerifyDualProof(dualProof, v.sourceID, v.targetID, sourceAlh, v.targetAlh) {
+		return nil, fmt.Errorf("consistency proof of key %v failed: %v", string(key), store.ErrCorruptedData)
+	}
+
+	return v, nil
+}
+
+// scanProof proves the entries of a scan against one trusted state, the
+// entries of a transaction share a single proof of that transaction
+type scanProof struct {
+	c     *Client
+	state *schema.ImmutableState
+	txs   map[uint64]*provenTx
+}
+
+// provenTx is a transaction whose header is proven against the trusted state
+type provenTx struct {
+	tx     *store.Tx
+	eh     [sha256.Size]byte
+	digest store.EntrySpecDigest
+}
+
+// includes checks the inclusion of the entry spec in the transaction
+func (t *provenTx) includes(spec *store.EntrySpec) bool {
+	proof, err := t.tx.Proof(spec.Key)
+	if err != nil {
+		return false
+	}
+
+	return store.VerifyInclusion(proof, t.digest(spec), t.eh)
+}
+
+// verifyScan checks the entries and set members of a scanned page, the state
+// first moves forward to the latest transaction of the page and all entries
+// are proven against it. The state is kept in memory, the scan persists it
+// once with persistTrustedState when it is done
+func (c *Client) verifyScan(ctx context.Context, entries []*schema.Entry, members []*schema.ZEntry) error {
+	if !c.verified {
+		return nil
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	state, err := c.trustedState()
+	if err != nil {
+		return err
+	}
+
+	p := &scanProof{
+		c:     c,
+		state: state,
+		txs:   map[uint64]*provenTx{},
+	}
+
+	for _, m := range members {
+		entries = append(entries, m.Entry)
+	}
+
+	last := uint64(0)
+	for _, entry := range entries {
+		if entry != nil && entry.Tx > last {
+			last = entry.Tx
+		}
+	}
+
+	if last > state.TxId {
+		_, err := p.prove(ctx, last)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, entry := range entries {
+		err := p.verifyEntry(ctx, entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, m := range members {
+		err := p.verifyMember(ctx, m)
+		if err != nil {
+			return err
+		}
+	}
+
+	if p.state != state {
+		c.state = p.state
+		c.unsaved = true
+	}
+
+	return nil
+}
+
+// prove reads the transaction with a proof against the state, the state
+// moves forward if the transaction is newer
+func (p *scanProof) prove(ctx context.Context, id uint64) (*provenTx, error) {
+	if t, ok := p.txs[id]; ok {
+		return t, nil
+	}
+
+	vTx, err := p.c.storage.VerifiableTxByID(ctx, &schema.VerifiableTxRequest{
+		Tx:                       id,
+		ProveSinceTx:             p.state.TxId,
+		KeepReferencesUnresolved: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cant get tx %v: %v", id, err)
+	}
+
+	if vTx.Tx == nil || vTx.Tx.Header == nil || vTx.DualProof == nil {
+		return nil, fmt.Errorf("incomplete proof of tx %v: %v", id, store.ErrCorruptedData)
+	}
+
+	dualProof := schema.DualProofFromProto(vTx.DualProof)
+
+	var eh [sha256.Size]byte
+	var sourceID, targetID uint64
+	var sourceAlh, targetAlh [sha256.Size]byte
+
+	if p.state.TxId <= id {
+		eh = dualProof.TargetTxHeader.Eh
+
+		sourceID = p.state.TxId
+		sourceAlh = schema.DigestFromProto(p.state.TxHash)
+		targetID = id
+		targetAlh = dualProof.TargetTxHeader.Alh()
+	} else {
+		eh = dualProof.SourceTxHeader.Eh
+
+		sourceID = id
+		sourceAlh = dualProof.SourceTxHeader.Alh()
+		targetID = p.state.TxId
+		targetAlh = schema.DigestFromProto(p.state.TxHash)
+	}
+
+	if p.state.TxId > 0 && !store.VerifyDualProof(dualProof, sourceID, targetID, sourceAlh, targetAlh) {
+		return nil, fmt.Errorf("consistency proof of tx %v failed: %v", id, store.ErrCorruptedData)
+	}
+
+	if targetID > p.state.TxId {
+		state := &schema.ImmutableState{
+			Db:        p.c.db,
+			TxId:      targetID,
+			TxHash:    targetAlh[:],
+			Signature: vTx.Signature,
+		}
+
+		if p.c.signingKey != nil {
+			ok, err := state.CheckSignature(p.c.signingKey)
+			if err != nil {
+				return nil, fmt.Errorf("state signature of tx %v failed: %v", targetID, err)
+			}
+
+			if !ok {
+				return nil, fmt.Errorf("state signature of tx %v failed: %v", targetID, store.ErrCorruptedData)
+			}
+		}
+
+		p.state = state
+	}
+
+	digest, err := store.EntrySpecDigestFor(int(vTx.Tx.Header.Version))
+	if err != nil {
+		return nil, err
+	}
+
+	t := &provenTx{
+		tx:     schema.TxFromProto(vTx.Tx),
+		eh:     eh,
+		digest: digest,
+	}
+
+	p.txs[id] = t
+
+	return t, nil
+}
+
+// verifyEntry checks the inclusion of the entry in its proven transaction,
+// references are checked by the entry they resolve to
+func (p *scanProof) verifyEntry(ctx context.Context, entry *schema.Entry) error {
+	if entry == nil {
+		return fmt.Errorf("incomplete scan entry: %v", store.ErrCorruptedData)
+	}
+
+	t, err := p.prove(ctx, entry.Tx)
+	if err != nil {
+		return err
+	}
+
+	if !t.includes(database.EncodeEntrySpec(entry.Key, schema.KVMetadataFromProto(entry.Metadata), entry.Value)) {
+		return fmt.Errorf("inclusion proof of key %v failed: %v", string(entry.Key), store.ErrCorruptedData)
+	}
+
+	return nil
+}
+
+// verifyMember checks the inclusion of the set member, the ledger adds a key
+// to a set with the first or with the latest version of the key
+func (p *scanProof) verifyMember(ctx context.Context, member *schema.ZEntry) error {
+	spec := database.EncodeZAdd(member.Set, member.Score, database.EncodeKey(member.Key), member.AtTx)
+
+	t, err := p.prove(ctx, member.Entry.Tx)
+	if err != nil {
+		return err
+	}
+
+	if t.includes(spec) {
+		return nil
+	}
+
+	first, err := p.c.storage.History(ctx, &schema.HistoryRequest{
+		Key:   member.Key,
+		Limit: 1,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read history of key %v: %v", string(member.Key), err)
+	}
+
+	if len(first.Entries) == 1 && first.Entries[0].Tx != member.Entry.Tx {
+		t, err = p.prove(ctx, first.Entries[0].Tx)
+		if err != nil {
+			return err
+		}
+
+		if t.includes(spec) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("membership of key %v in set %v not proven: %v", string(member.Key), string(member.Set), store.ErrCorruptedData)
+}
+
+// persistTrustedState writes the state moved forward by a scan
+func (c *Client) persistTrustedState() error {
+	if !c.verified {
+		return nil
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.unsaved {
+		return nil
+	}
+
+	return c.setTrustedState(c.state)
+}
+
+// TrustedState returns the last verified state
+func (c *Client) TrustedState() (*schema.ImmutableState, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.trustedState()
+}
+
+func (c *Client) stateFile() string {
+	return filepath.Join(c.stateDir, fmt.Sprintf(".ledger-state-%v", c.db))
+}
+
+func (c *Client) trustedState() (*schema.ImmutableState, error) {
+	if c.state != nil {
+		return c.state, nil
+	}
+
+	if c.stateDir == "" {
+		return &schema.ImmutableState{Db: c.db}, nil
+	}
+
+	data, err := os.ReadFile(c.stateFile())
+	if os.IsNotExist(err) {
+		return &schema.ImmutableState{Db: c.db}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trusted state: %v", err)
+	}
+
+	state := &schema.ImmutableState{}
+	err = json.Unmarshal(data, state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse trusted state: %v", err)
+	}
+
+	c.state = state
+
+	return state, nil
+}
+
+func (c *Client) setTrustedState(state *schema.ImmutableState) error {
+	c.state = state
+
+	if c.stateDir == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(c.stateFile(), data, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write trusted state: %v", err)
+	}
+
+	c.unsaved = false
+
+	return nil
+}
@@ -3,6 +3,7 @@ package config
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/ec-systems/core.ledger.server/pkg/logger"
 	"github.com/ec-systems/core.ledger.server/pkg/types"
@@ -30,27 +31,53 @@ type Config struct {
 	ClientOptions *immudb.Options
 	Service       ServiceConfig
 
-	Assets   types.Assets
-	Statuses types.Statuses
+	Assets      types.Assets
+	Statuses    types.Statuses
+	Transitions types.Transitions
 
 	BatchSize int          `default:"25"`
 	Format    types.Format `default:"json"`
+	Verified  bool         `default:"false"`
 }
 
 type ServiceConfig struct {
 	Device       string
 	Port         int `default:"8888"`
 	Production   bool
-	AccessLogger bool `default:"true"`
-	ReadOnly     bool `default:"false"`
-	Metrics      int  `default:"9094"`
+	AccessLogger bool          `default:"true"`
+	ReadOnly     bool          `default:"false"`
+	SpendPending bool          `default:"false"`
+	UniqueRefs   bool          `default:"false"`
+	HoldSweep    time.Duration `default:"1m"`
+	AssetReload  time.Duration `default:"10s"`
+	Metrics      int           `default:"9094"`
+	Grpc         int           `default:"9095"`
 	Servername   string
+	Embedded     bool   `default:"false"`
+	DataDir      string `default:"./data"`
+	Policy       string
+
+	WebhookInterval time.Duration `default:"1s"`
+	WebhookRetries  int           `default:"5"`
+	WebhookBackoff  time.Duration `default:"1s"`
+	WebhookTimeout  time.Duration `default:"10s"`
 
 	MTls *MTLsOptions `json:",omitempty" yaml:",omitempty"`
+	Auth *AuthOptions `json:",omitempty" yaml:",omitempty"`
 }
 
 type MTLsOptions immudb.MTLsOptions
 
+// AuthOptions requires the authentication of all requests, Keys are the
+// sha256 hashes of the api keys by name and JWKS the file with the keys of
+// the jwt tokens
+type AuthOptions struct {
+	Keys     map[string]string `json:",omitempty" yaml:",omitempty"`
+	JWKS     string
+	Issuer   string
+	Audience string
+}
+
 func (c *Config) String() string {
 
 	data, err := yaml.Marshal(c)
@@ -10,6 +10,10 @@ LOG_LEVEL=
 
 STATUSES=
 
+TRANSITIONS=
+
+VERIFIED=
+
 CLIENT_OPTIONS_ADDRESS=
 CLIENT_OPTIONS_AUTH=
 CLIENT_OPTIONS_CONFIG=
@@ -33,18 +37,35 @@ CLIENT_OPTIONS_TOKEN_FILE_NAME=
 CLIENT_OPTIONS_USERNAME=
 
 SERVICE_ACCESS_LOGGER=
+SERVICE_ASSET_RELOAD=
+SERVICE_DATA_DIR=
 SERVICE_DEVICE=
+SERVICE_EMBEDDED=
+SERVICE_GRPC=
+SERVICE_HOLD_SWEEP=
 SERVICE_METRICS=
+SERVICE_POLICY=
 SERVICE_PORT=
 SERVICE_PRODUCTION=
 SERVICE_READ_ONLY=
 SERVICE_SERVERNAME=
+SERVICE_SPEND_PENDING=
+SERVICE_UNIQUE_REFS=
+SERVICE_WEBHOOK_BACKOFF=
+SERVICE_WEBHOOK_INTERVAL=
+SERVICE_WEBHOOK_RETRIES=
+SERVICE_WEBHOOK_TIMEOUT=
 
 CLIENT_OPTIONS_MTLS_OPTIONS_CERTIFICATE=
 CLIENT_OPTIONS_MTLS_OPTIONS_CLIENT_CAS=
 CLIENT_OPTIONS_MTLS_OPTIONS_PKEY=
 CLIENT_OPTIONS_MTLS_OPTIONS_SERVERNAME=
 
+SERVICE_AUTH_AUDIENCE=
+SERVICE_AUTH_ISSUER=
+SERVICE_AUTH_JWKS=
+SERVICE_AUTH_KEYS=
+
 SERVICE_MTLS_CERTIFICATE=
 SERVICE_MTLS_CLIENT_CAS=
 SERVICE_MTLS_PKEY=
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/ec-systems/core.ledger.server/pkg/logger"
+	"github.com/shopspring/decimal"
 	"golang.org/x/exp/maps"
 	"gopkg.in/yaml.v3"
 )
@@ -20,6 +21,44 @@ var (
 	doNotEdit = "//Code generated by assets generator. DO NOT EDIT.\n"
 )
 
+// asset is either only the name or the definition with the amount limits
+type asset struct {
+	Name     string `yaml:"name"`
+	Decimals *int32 `yaml:"decimals"`
+	Min      string `yaml:"min"`
+	Max      string `yaml:"max"`
+	Enabled  *bool  `yaml:"enabled"`
+}
+
+func (a *asset) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		return value.Decode(&a.Name)
+	}
+
+	type plain asset
+	return value.Decode((*plain)(a))
+}
+
+func (a *asset) code() string {
+	fields := []string{fmt.Sprintf("Name: %q", a.Name)}
+
+	if a.Decimals != nil {
+		fields = append(fields, fmt.Sprintf("Decimals: Decimals(%v)", *a.Decimals))
+	}
+
+	if a.Min != "" {
+		fields = append(fields, fmt.Sprintf("Min: decimal.RequireFromString(%q)", a.Min))
+	}
+
+	if a.Max != "" {
+		fields = append(fields, fmt.Sprintf("Max: decimal.RequireFromString(%q)", a.Max))
+	}
+
+	fields = append(fields, fmt.Sprintf("Enabled: %v", a.Enabled == nil || *a.Enabled))
+
+	return "{" + strings.Join(fields, ", ") + "}"
+}
+
 func main() {
 	path, err := os.Getwd()
 	if err != nil {
@@ -31,26 +70,45 @@ func main() {
 		log.Printf("yamlFile.Get err   #%v ", err)
 	}
 
-	var assets map[string]string
+	var assets map[string]*asset
 
 	err = yaml.Unmarshal(yamlFile, &assets)
 	if err != nil {
 		log.Fatalf("Unmarshal: %v", err)
 	}
 
+	limits := false
+
+	for s, a := range assets {
+		for _, amount := range []string{a.Min, a.Max} {
+			if amount == "" {
+				continue
+			}
+
+			if _, err := decimal.NewFromString(amount); err != nil {
+				logger.Fatalf("invalid amount %v of asset %v: %v", amount, s, err)
+			}
+
+			limits = true
+		}
+	}
+
 	var sb strings.Builder
 
 	sb.WriteString(doNotEdit)
 	sb.WriteString("package types\n\n")
 
+	if limits {
+		sb.WriteString("import \"github.com/shopspring/decimal\"\n\n")
+	}
+
 	symbols := maps.Keys(assets)
 	sort.Strings(symbols)
 
 	sb.WriteString("var DefaultAssetMap = Assets{\n")
 
 	for _, s := range symbols {
-		v := assets[s]
-		sb.WriteString(fmt.Sprintf("\tAsset(\"%v\"): \"%v\",\n", s, v))
+		sb.WriteString(fmt.Sprintf("\tAsset(\"%v\"): %v,\n", s, assets[s].code()))
 	}
 
 	sb.WriteString("}\n\n")
@@ -65,6 +65,7 @@ func main() {
 	cfg := config.Configuration()
 	cfg.Assets = types.DefaultAssetMap
 	cfg.Statuses = types.DefaultStatusMap
+	cfg.Transitions = types.DefaultTransitions
 	cfg.Format = types.Protobuf
 
 	exampleConfPath := filepath.Join(path, "examples", exampleConf)
@@ -19,10 +19,10 @@ func TestGroupBindings(t *testing.T) {
 	rootCmd := cmd.GetRootCmd(&cmd.Version{})
 
 	b := rootCmd.EnvBindings()
-	assert.Len(t, b, 41)
+	assert.Len(t, b, 59)
 
 	r, err := generator.GroupBindings(b)
 	assert.NoError(t, err)
-	assert.Len(t, r, 9)
+	assert.Len(t, r, 12)
 
 }
@@ -20,6 +20,12 @@ var (
 	doNotEdit = "//Code generated by statuses generator. DO NOT EDIT.\n"
 )
 
+type config struct {
+	Statuses    map[string]int      `yaml:"statuses"`
+	Transitions map[string][]string `yaml:"transitions"`
+	Terminal    []string            `yaml:"terminal"`
+}
+
 func main() {
 	path, err := os.Getwd()
 	if err != nil {
@@ -31,13 +37,14 @@ func main() {
 		log.Printf("yamlFile.Get err   #%v ", err)
 	}
 
-	var statuses map[string]int
+	var cfg config
 
-	err = yaml.Unmarshal(yamlFile, &statuses)
+	err = yaml.Unmarshal(yamlFile, &cfg)
 	if err != nil {
 		log.Fatalf("Unmarshal: %v", err)
 	}
 
+	statuses := cfg.Statuses
 	if statuses == nil {
 		statuses = make(map[string]int)
 	}
@@ -48,6 +55,31 @@ func main() {
 	statuses["Finished"] = 1000
 	statuses["CancellationFinished"] = 998
 
+	transitions := cfg.Transitions
+	if transitions == nil {
+		transitions = make(map[string][]string)
+	}
+
+	for _, name := range cfg.Terminal {
+		if len(transitions[name]) > 0 {
+			logger.Fatalf("terminal status %v has transitions", name)
+		}
+
+		transitions[name] = []string{}
+	}
+
+	for from, next := range transitions {
+		if _, ok := statuses[from]; !ok {
+			logger.Fatalf("unknown status %v in transitions", from)
+		}
+
+		for _, to := range next {
+			if _, ok := statuses[to]; !ok {
+				logger.Fatalf("unknown status %v in transitions of %v", to, from)
+			}
+		}
+	}
+
 	values := maps.Values(statuses)
 	sort.Ints(values)
 
@@ -78,6 +110,25 @@ func main() {
 		}
 	}
 
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("var DefaultTransitions = Transitions{\n")
+
+	for _, val := range values {
+		for k, v := range statuses {
+			if next, ok := transitions[k]; ok && v == val {
+				sb.WriteString(fmt.Sprintf("\t\"%v\": {", k))
+				for i, n := range next {
+					if i > 0 {
+						sb.WriteString(", ")
+					}
+					sb.WriteString(fmt.Sprintf("\"%v\"", n))
+				}
+				sb.WriteString("},\n")
+			}
+		}
+	}
+
 	sb.WriteString("}\n")
 
 	fp := filepath.Join(path, filename)
@@ -0,0 +1,228 @@
+package ledger
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/ec-systems/core.ledger.server/pkg/ledger/index"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+)
+
+// APIKey is a stored api key, only the sha256 hash of the key is kept
+type APIKey struct {
+	tx uint64
+
+	ID      types.ID   `json:"ID" swaggertype:"primitive,string"`
+	Name    string     `json:"Name"`
+	Hash    string     `json:"Hash"`
+	Created *time.Time `json:"Created"`
+	Revoked *time.Time `json:"Revoked,omitempty"`
+}
+
+// HashAPIKey returns the hex encoded sha256 hash of an api key
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewAPIKey generates a random api key
+func NewAPIKey() (string, error) {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	if err != nil {
+		return "", NewError(InternalError, "can't generate api key: %v", err)
+	}
+
+	return hex.EncodeToString(key), nil
+}
+
+// AddAPIKey stores a new api key for the name, the key itself is only
+// returned here
+func (l *Ledger) AddAPIKey(ctx context.Context, name string) (*APIKey, string, error) {
+	if l.readOnly {
+		return nil, "", NewError(NotFoundError, "read-only instance")
+	}
+
+	if strings.TrimSpace(name) == "" {
+		return nil, "", NewError(BadRequestError, "api key name is empty")
+	}
+
+	key, err := NewAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+
+	id, err := l.NewID()
+	if err != nil {
+		return nil, "", NewError(InternalError, "can't generate api key id: %v", err)
+	}
+
+	apiKey := &APIKey{
+		ID:   id,
+		Name: name,
+		Hash: HashAPIKey(key),
+	}
+
+	ops, err := l.APIKeyOperations(apiKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	apiKey.tx, err = l.client.Exec(ctx, ops...)
+	if err != nil {
+		return nil, "", NewError(InternalError, "failed to store api key %v: %v", name, err)
+	}
+
+	return apiKey, key, nil
+}
+
+// GetAPIKey reads the api key with the given hash
+func (l *Ledger) GetAPIKey(ctx context.Context, hash string) (*APIKey, error) {
+	entry, err := l.client.Get(ctx, string(index.APIKey.Key(hash)))
+	if err != nil {
+		if strings.Contains(err.Error(), store.ErrKeyNotFound.Error()) {
+			return nil, NewError(NotFoundError, "api key not found")
+		}
+
+		return nil, NewError(InternalError, "failed to read api key: %v", err)
+	}
+
+	apiKey := &APIKey{}
+	err = Unmarshal(entry, apiKey)
+	if err != nil {
+		return nil, NewError(InternalError, "failed to parse the api key: %v", err)
+	}
+
+	apiKey.tx = entry.Tx
+
+	return apiKey, nil
+}
+
+func (l *Ledger) APIKeys(ctx context.Context, f func(context.Context, *APIKey) (bool, error)) error {
+	return l.client.ScanSet(ctx, string(index.APIKeys.Key()), false, func(ctx context.Context, e *schema.ZEntry) (bool, error) {
+		apiKey := &APIKey{}
+		err := Unmarshal(e.Entry, apiKey)
+		if err != nil {
+			return false, NewError(InternalError, "failed to parse the api key (%v): %v", err, string(e.Entry.Value))
+		}
+
+		apiKey.tx = e.Entry.Tx
+
+		return f(ctx, apiKey)
+	})
+}
+
+// RevokeAPIKey marks the api key as revoked, a revoked key doesn't
+// authenticate anymore
+func (l *Ledger) RevokeAPIKey(ctx context.Context, id types.ID) (*APIKey, error) {
+	if l.readOnly {
+		return nil, NewError(NotFoundError, "read-only instance")
+	}
+
+	var apiKey *APIKey
+
+	err := l.retry(ctx, func() error {
+		apiKey = nil
+
+		err := l.APIKeys(ctx, func(ctx context.Context, k *APIKey) (bool, error) {
+			if k.ID == id {
+				apiKey = k
+			}
+
+			return apiKey == nil, nil
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if apiKey == nil {
+			return NewError(NotFoundError, "api key %v not found", id)
+		}
+
+		if apiKey.Revoked != nil {
+			return nil
+		}
+
+		now := time.Now()
+		apiKey.Revoked = &now
+
+		ops, err := l.APIKeyOperations(apiKey)
+		if err != nil {
+			return err
+		}
+
+		apiKey.tx, err = l.client.Exec(ctx, ops...)
+		return err
+	})
+
+	if err != nil {
+		if _, ok := err.(Error); ok {
+			return nil, err
+		}
+
+		return nil, NewError(InternalError, "failed to revoke api key %v: %v", id, err)
+	}
+
+	return apiKey, nil
+}
+
+func (l *Ledger) APIKeyOperations(apiKey *APIKey) ([]interface{}, error) {
+	if apiKey.ID.IsEmpty() {
+		return nil, NewError(BadRequestError, "api key id is empty")
+	}
+
+	if apiKey.Created == nil {
+		now := time.Now()
+		apiKey.Created = &now
+	}
+
+	data, err := Marshal(apiKey, types.JSON, Version)
+	if err != nil {
+		return nil, NewError(InternalError, "marshal api key failed: %v", err)
+	}
+
+	key := index.APIKey.Key(apiKey.Hash)
+
+	ops := []interface{}{
+		&schema.Op_Kv{
+			Kv: &schema.KeyValue{
+				Key:   key,
+				Value: data,
+			},
+		},
+	}
+
+	if apiKey.tx == 0 {
+		ops = append(ops,
+			&schema.Op_ZAdd{
+				ZAdd: &schema.ZAddRequest{
+					Key:      key,
+					Set:      index.APIKeys.Key(),
+					Score:    float64(apiKey.Created.Local().UnixMilli()),
+					BoundRef: false,
+				},
+			},
+			&schema.Precondition_KeyMustNotExist{
+				KeyMustNotExist: &schema.Precondition_KeyMustNotExistPrecondition{
+					Key: key,
+				},
+			},
+		)
+	} else {
+		ops = append(ops, &schema.Precondition_KeyNotModifiedAfterTX{
+			KeyNotModifiedAfterTX: &schema.Precondition_KeyNotModifiedAfterTXPrecondition{
+				Key:  key,
+				TxID: apiKey.tx,
+			},
+		})
+	}
+
+	return ops, nil
+}
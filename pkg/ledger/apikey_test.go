@@ -0,0 +1,105 @@
+package ledger_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_APIKey(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	l := ledger.New(client,
+		ledger.SupportedAssets(cfg.Assets),
+	)
+
+	_, _, err = l.AddAPIKey(ctx, " ")
+	assert.Error(t, err)
+
+	name := randomName()
+	apiKey, key, err := l.AddAPIKey(ctx, name)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NotEmpty(t, key)
+	assert.Equal(t, ledger.HashAPIKey(key), apiKey.Hash)
+
+	stored, err := l.GetAPIKey(ctx, ledger.HashAPIKey(key))
+	if assert.NoError(t, err) {
+		assert.Equal(t, apiKey.ID, stored.ID)
+		assert.Equal(t, name, stored.Name)
+		assert.Nil(t, stored.Revoked)
+	}
+
+	_, err = l.GetAPIKey(ctx, ledger.HashAPIKey("unknown"))
+	if assert.Error(t, err) {
+		assert.True(t, err.(ledger.Error).IsError(ledger.NotFoundError))
+	}
+
+	revoked, err := l.RevokeAPIKey(ctx, apiKey.ID)
+	if assert.NoError(t, err) {
+		assert.NotNil(t, revoked.Revoked)
+	}
+
+	found := false
+	err = l.APIKeys(ctx, func(ctx context.Context, k *ledger.APIKey) (bool, error) {
+		if k.ID == apiKey.ID {
+			found = true
+			assert.NotNil(t, k.Revoked)
+		}
+
+		return true, nil
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, found)
+
+	id, _ := l.NewID()
+	_, err = l.RevokeAPIKey(ctx, id)
+	assert.Error(t, err)
+}
+
+func Test_User(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	l := ledger.New(client,
+		ledger.SupportedAssets(cfg.Assets),
+	)
+
+	holder := randomName()
+	asset := randomAsset(assets)
+
+	tx, err := l.Add(ledger.WithUser(ctx, "alice"), holder, asset, decimal.NewFromInt(1))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "alice", tx.User)
+
+	stored, err := l.Get(ctx, tx.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "alice", stored.User)
+	}
+
+	tx, err = l.Status(ledger.WithUser(ctx, "bob"), tx, types.Finished)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "bob", tx.User)
+	}
+}
@@ -0,0 +1,286 @@
+package ledger
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/ec-systems/core.ledger.server/pkg/ledger/index"
+	"github.com/ec-systems/core.ledger.server/pkg/logger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+)
+
+// RegisteredAsset is an asset definition stored in the ledger, it overrides
+// the configured definition of the asset. Every change is a new version of
+// the key, immudb keeps the history.
+type RegisteredAsset struct {
+	tx uint64
+
+	Symbol     types.Asset           `json:"Symbol"`
+	Definition types.AssetDefinition `json:"Definition"`
+	Modified   *time.Time            `json:"Modified"`
+	User       string                `json:"User,omitempty"`
+}
+
+// AddAsset stores the definition of an asset, an existing definition is
+// replaced
+func (l *Ledger) AddAsset(ctx context.Context, symbol types.Asset, definition types.AssetDefinition) (*RegisteredAsset, error) {
+	symbol = types.Asset(strings.TrimSpace(symbol.String()))
+	if symbol == types.AllAssets || strings.ContainsAny(symbol.String(), ": ") {
+		return nil, NewError(BadRequestError, "invalid asset symbol '%v'", symbol)
+	}
+
+	if strings.TrimSpace(definition.Name) == "" {
+		return nil, NewError(BadRequestError, "name of asset %v is empty", symbol)
+	}
+
+	if (definition.Decimals != nil && *definition.Decimals < 0) || definition.Min.IsNegative() || definition.Max.IsNegative() {
+		return nil, NewError(BadRequestError, "negative limits for asset %v", symbol)
+	}
+
+	if definition.Max.IsPositive() && definition.Min.GreaterThan(definition.Max) {
+		return nil, NewError(BadRequestError, "min amount of asset %v is greater than the max amount", symbol)
+	}
+
+	return l.updateAsset(ctx, symbol, func(asset *RegisteredAsset) {
+		asset.Definition = definition
+	})
+}
+
+// EnableAsset enables or disables an asset, new transactions of a disabled
+// asset are rejected
+func (l *Ledger) EnableAsset(ctx context.Context, symbol types.Asset, enabled bool) (*RegisteredAsset, error) {
+	return l.updateAsset(ctx, symbol, func(asset *RegisteredAsset) {
+		asset.Definition.Enabled = enabled
+	})
+}
+
+func (l *Ledger) updateAsset(ctx context.Context, symbol types.Asset, update func(*RegisteredAsset)) (*RegisteredAsset, error) {
+	if l.readOnly {
+		return nil, NewError(NotFoundError, "read-only instance")
+	}
+
+	var asset *RegisteredAsset
+
+	err := l.retry(ctx, func() error {
+		var err error
+
+		asset, err = l.GetAsset(ctx, symbol)
+		if err != nil {
+			lerr, ok := err.(Error)
+			if !ok || !lerr.IsError(NotFoundError) {
+				return err
+			}
+
+			asset = &RegisteredAsset{
+				Symbol:     symbol,
+				Definition: l.seed[symbol],
+			}
+		}
+
+		update(asset)
+
+		if asset.Definition.Name == "" {
+			return NewError(NotFoundError, "asset %v not found", symbol)
+		}
+
+		now := time.Now()
+		asset.Modified = &now
+		asset.User = UserFrom(ctx)
+
+		ops, err := l.AssetOperations(asset)
+		if err != nil {
+			return err
+		}
+
+		asset.tx, err = l.client.Exec(ctx, ops...)
+		return err
+	})
+
+	if err != nil {
+		if _, ok := err.(Error); ok {
+			return nil, err
+		}
+
+		return nil, NewError(InternalError, "failed to store asset %v: %v", symbol, err)
+	}
+
+	l.setAsset(asset.Symbol, asset.Definition)
+
+	return asset, nil
+}
+
+// GetAsset reads the stored definition of an asset
+func (l *Ledger) GetAsset(ctx context.Context, symbol types.Asset) (*RegisteredAsset, error) {
+	entry, err := l.client.Get(ctx, string(index.AssetDefinition.Key(symbol)))
+	if err != nil {
+		if strings.Contains(err.Error(), store.ErrKeyNotFound.Error()) {
+			return nil, NewError(NotFoundError, "asset %v not registered", symbol)
+		}
+
+		return nil, NewError(InternalError, "failed to read asset %v: %v", symbol, err)
+	}
+
+	asset := &RegisteredAsset{}
+	err = Unmarshal(entry, asset)
+	if err != nil {
+		return nil, NewError(InternalError, "failed to parse asset %v: %v", symbol, err)
+	}
+
+	asset.tx = entry.Tx
+
+	return asset, nil
+}
+
+// RegisteredAssets reads all stored asset definitions
+func (l *Ledger) RegisteredAssets(ctx context.Context, f func(context.Context, *RegisteredAsset) (bool, error)) error {
+	return l.client.ScanSet(ctx, string(index.AssetRegistry.Assets()), false, func(ctx context.Context, e *schema.ZEntry) (bool, error) {
+		asset := &RegisteredAsset{}
+		err := Unmarshal(e.Entry, asset)
+		if err != nil {
+			return false, NewError(InternalError, "failed to parse the asset (%v): %v", err, string(e.Entry.Value))
+		}
+
+		asset.tx = e.Entry.Tx
+
+		return f(ctx, asset)
+	})
+}
+
+// AssetHistory reads all versions of the stored definition of an asset
+func (l *Ledger) AssetHistory(ctx context.Context, symbol types.Asset, f func(context.Context, *RegisteredAsset) (bool, error)) error {
+	err := l.client.History(ctx, string(index.AssetDefinition.Key(symbol)), func(ctx context.Context, e *schema.Entry) (bool, error) {
+		asset := &RegisteredAsset{}
+		err := Unmarshal(e, asset)
+		if err != nil {
+			return false, NewError(InternalError, "failed to parse the asset (%v): %v", err, string(e.Value))
+		}
+
+		asset.tx = e.Tx
+
+		return f(ctx, asset)
+	})
+
+	if err != nil && strings.Contains(err.Error(), store.ErrKeyNotFound.Error()) {
+		return NewError(NotFoundError, "asset %v not registered", symbol)
+	}
+
+	return err
+}
+
+// LoadAssets merges the stored asset definitions into the configured assets
+func (l *Ledger) LoadAssets(ctx context.Context) error {
+	assets := types.Assets{}
+	for k, v := range l.seed {
+		assets[k] = v
+	}
+
+	err := l.RegisteredAssets(ctx, func(ctx context.Context, asset *RegisteredAsset) (bool, error) {
+		assets[asset.Symbol] = asset.Definition
+		return true, nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	l.assetsLock.Lock()
+	defer l.assetsLock.Unlock()
+
+	l.assets = assets
+
+	return nil
+}
+
+// WatchAssets reloads the asset registry in the given interval until the
+// context is done, changes of other instances are visible after the next
+// reload
+func (l *Ledger) WatchAssets(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := l.LoadAssets(ctx)
+			if err != nil && ctx.Err() == nil {
+				logger.Errorf("reload assets failed: %v", err)
+			}
+		}
+	}
+}
+
+func (l *Ledger) setAsset(symbol types.Asset, definition types.AssetDefinition) {
+	l.assetsLock.Lock()
+	defer l.assetsLock.Unlock()
+
+	assets := types.Assets{}
+	for k, v := range l.assets {
+		assets[k] = v
+	}
+
+	assets[symbol] = definition
+	l.assets = assets
+}
+
+func (l *Ledger) AssetOperations(asset *RegisteredAsset) ([]interface{}, error) {
+	if asset.Symbol == types.AllAssets {
+		return nil, NewError(BadRequestError, "asset symbol is empty")
+	}
+
+	if asset.Modified == nil {
+		now := time.Now()
+		asset.Modified = &now
+	}
+
+	data, err := Marshal(asset, types.JSON, Version)
+	if err != nil {
+		return nil, NewError(InternalError, "marshal asset failed: %v", err)
+	}
+
+	key := index.AssetDefinition.Key(asset.Symbol)
+
+	ops := []interface{}{
+		&schema.Op_Kv{
+			Kv: &schema.KeyValue{
+				Key:   key,
+				Value: data,
+			},
+		},
+	}
+
+	if asset.tx == 0 {
+		ops = append(ops,
+			&schema.Op_ZAdd{
+				ZAdd: &schema.ZAddRequest{
+					Key:      key,
+					Set:      []byte(index.AssetRegistry.Assets()),
+					Score:    float64(asset.Modified.Local().UnixMilli()),
+					BoundRef: false,
+				},
+			},
+			&schema.Precondition_KeyMustNotExist{
+				KeyMustNotExist: &schema.Precondition_KeyMustNotExistPrecondition{
+					Key: key,
+				},
+			},
+		)
+	} else {
+		ops = append(ops, &schema.Precondition_KeyNotModifiedAfterTX{
+			KeyNotModifiedAfterTX: &schema.Precondition_KeyNotModifiedAfterTXPrecondition{
+				Key:  key,
+				TxID: asset.tx,
+			},
+		})
+	}
+
+	return ops, nil
+}
@@ -0,0 +1,198 @@
+package ledger_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AssetDefinition(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	limited := types.Asset("LIMITED")
+	disabled := types.Asset("DISABLED")
+
+	l := ledger.New(client,
+		ledger.SupportedAssets(types.Assets{
+			limited: {
+				Name:     "Limited",
+				Decimals: types.Decimals(2),
+				Min:      decimal.RequireFromString("0.1"),
+				Max:      decimal.RequireFromString("100"),
+				Enabled:  true,
+			},
+			disabled: {Name: "Disabled"},
+		}),
+	)
+
+	holder := randomName()
+
+	tests := []struct {
+		name   string
+		asset  types.Asset
+		amount string
+		valid  bool
+	}{
+		{"valid", limited, "10.5", true},
+		{"trailing zeros", limited, "10.500", true},
+		{"min", limited, "0.1", true},
+		{"max", limited, "100", true},
+		{"decimals", limited, "0.0000000000001", false},
+		{"below min", limited, "0.01", false},
+		{"above max", limited, "100.01", false},
+		{"negative decimals", limited, "-1.001", false},
+		{"negative above max", limited, "-101", false},
+		{"disabled", disabled, "1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount := decimal.RequireFromString(tt.amount)
+
+			_, err := l.CreateTx(ctx, holder, tt.asset, amount)
+			if tt.valid {
+				assert.NoError(t, err)
+				return
+			}
+
+			if assert.Error(t, err) {
+				assert.True(t, err.(ledger.Error).IsError(ledger.InvalidAmountError), err.Error())
+			}
+		})
+	}
+
+	_, _, err = l.Transfer(ctx, holder, holder+"_to", limited, decimal.RequireFromString("0.001"))
+	if assert.Error(t, err) {
+		assert.True(t, err.(ledger.Error).IsError(ledger.InvalidAmountError))
+	}
+
+	tx, err := l.CreateTx(ctx, holder, limited, decimal.RequireFromString("1"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = l.Refund(ctx, tx.ID, decimal.RequireFromString("0.001"))
+	if assert.Error(t, err) {
+		assert.True(t, err.(ledger.Error).IsError(ledger.InvalidAmountError), err.Error())
+	}
+}
+
+func Test_AssetRegistry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	l1 := ledger.New(client, ledger.SupportedAssets(cfg.Assets))
+	l2 := ledger.New(client, ledger.SupportedAssets(cfg.Assets))
+
+	symbol := types.Asset("REG-" + randomName())
+	holder := randomName()
+	one := decimal.NewFromInt(1)
+
+	_, err = l1.Add(ctx, holder, symbol, one)
+	assert.Error(t, err)
+
+	_, err = l1.AddAsset(ctx, symbol, types.AssetDefinition{Enabled: true})
+	assert.Error(t, err)
+
+	_, err = l1.AddAsset(ctx, symbol, types.AssetDefinition{Name: "Registered", Min: decimal.NewFromInt(2), Max: one, Enabled: true})
+	assert.Error(t, err)
+
+	_, err = l1.EnableAsset(ctx, symbol, true)
+	if assert.Error(t, err) {
+		assert.True(t, err.(ledger.Error).IsError(ledger.NotFoundError))
+	}
+
+	asset, err := l1.AddAsset(ledger.WithUser(ctx, "alice"), symbol, types.AssetDefinition{Name: "Registered", Decimals: types.Decimals(2), Enabled: true})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "alice", asset.User)
+
+	// the changing instance uses the asset immediately
+	_, err = l1.Add(ctx, holder, symbol, one)
+	assert.NoError(t, err)
+
+	_, err = l1.Add(ctx, holder, symbol, decimal.RequireFromString("0.001"))
+	assert.Error(t, err)
+
+	// other instances after a reload
+	_, err = l2.Add(ctx, holder, symbol, one)
+	assert.Error(t, err)
+
+	if assert.NoError(t, l2.LoadAssets(ctx)) {
+		_, err = l2.Add(ctx, holder, symbol, one)
+		assert.NoError(t, err)
+	}
+
+	go l2.WatchAssets(ctx, 10*time.Millisecond)
+
+	_, err = l1.EnableAsset(ctx, symbol, false)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Eventually(t, func() bool {
+		return !l2.SupportedAssets()[symbol].Enabled
+	}, time.Second, 10*time.Millisecond)
+
+	_, err = l2.Add(ctx, holder, symbol, one)
+	if assert.Error(t, err) {
+		assert.True(t, err.(ledger.Error).IsError(ledger.InvalidAmountError))
+	}
+
+	_, err = l1.EnableAsset(ctx, symbol, true)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Eventually(t, func() bool {
+		return l2.SupportedAssets()[symbol].Enabled
+	}, time.Second, 10*time.Millisecond)
+
+	// immudb keeps all versions
+	enabled := []bool{}
+	err = l1.AssetHistory(ctx, symbol, func(ctx context.Context, asset *ledger.RegisteredAsset) (bool, error) {
+		assert.Equal(t, "Registered", asset.Definition.Name)
+		enabled = append(enabled, asset.Definition.Enabled)
+		return true, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, true}, enabled)
+
+	found := false
+	err = l1.RegisteredAssets(ctx, func(ctx context.Context, asset *ledger.RegisteredAsset) (bool, error) {
+		found = found || asset.Symbol == symbol
+		return true, nil
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, found)
+
+	err = l1.AssetHistory(ctx, types.Asset("REG-"+randomName()+"-unknown"), func(ctx context.Context, asset *ledger.RegisteredAsset) (bool, error) {
+		return true, nil
+	})
+
+	if assert.Error(t, err) {
+		assert.True(t, err.(ledger.Error).IsError(ledger.NotFoundError))
+	}
+}
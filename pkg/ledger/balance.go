@@ -0,0 +1,140 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/ec-systems/core.ledger.server/pkg/ledger/index"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+)
+
+// BalanceAt returns the balance of a holder at the given point in time,
+// status changes after that point are not applied
+func (l *Ledger) BalanceAt(ctx context.Context, holder string, asset types.Asset, account types.Account, at time.Time) (map[types.Asset]*types.Balance, error) {
+	if at.IsZero() {
+		return nil, NewError(BadRequestError, "balance: point in time is mandatory")
+	}
+
+	valid := func(tx *Transaction) bool {
+		modified := tx.Created
+		if tx.Modified != nil {
+			modified = tx.Modified
+		}
+
+		return modified == nil || !modified.After(at)
+	}
+
+	return l.balanceAt(ctx, holder, asset, account, types.AllStatuses, float64(at.UnixMilli()), func(ctx context.Context, tx *Transaction, revision uint64) (*Transaction, error) {
+		if valid(tx) {
+			return tx, nil
+		}
+
+		var version *Transaction
+
+		err := l.History(ctx, tx.ID, func(ctx context.Context, tx *Transaction) (bool, error) {
+			if !valid(tx) {
+				return false, nil
+			}
+
+			version = tx
+			return true, nil
+		})
+
+		return version, err
+	})
+}
+
+// BalanceAsOfTx returns the balance of a holder as of the given immudb transaction
+func (l *Ledger) BalanceAsOfTx(ctx context.Context, holder string, asset types.Asset, account types.Account, txID uint64) (map[types.Asset]*types.Balance, error) {
+	if txID == 0 {
+		return nil, NewError(BadRequestError, "balance: tx is mandatory")
+	}
+
+	return l.balanceAt(ctx, holder, asset, account, types.AllStatuses, math.MaxFloat64, func(ctx context.Context, tx *Transaction, revision uint64) (*Transaction, error) {
+		if tx.tx <= txID {
+			return tx, nil
+		}
+
+		// the transaction was created after txID
+		if revision == 1 {
+			return nil, nil
+		}
+
+		e, err := l.client.GetAsOf(ctx, tx.key, txID)
+		if err != nil {
+			if strings.Contains(err.Error(), store.ErrKeyNotFound.Error()) {
+				return nil, nil
+			}
+
+			return nil, NewError(InternalError, "failed to read transaction %v as of tx %v: %v", tx.ID, txID, err)
+		}
+
+		return l.version(ctx, e)
+	})
+}
+
+// balanceAt sums up the transactions with a score up to max, the version
+// function returns the version of a transaction to apply or nil to skip it
+func (l *Ledger) balanceAt(ctx context.Context, holder string, asset types.Asset, account types.Account, status types.Status, max float64, version func(ctx context.Context, tx *Transaction, revision uint64) (*Transaction, error)) (map[types.Asset]*types.Balance, error) {
+	assets := map[types.Asset]*types.Balance{}
+	var accounts []types.Account
+
+	if holder == "" {
+		return nil, NewError(BadRequestError, "balance: holder is mandatory")
+	}
+
+	if account.Empty() {
+		acc, err := l.Accounts(ctx, holder, asset)
+		if err != nil {
+			return nil, err
+		}
+
+		accounts = acc
+	} else {
+		accounts = []types.Account{account}
+	}
+
+	for _, a := range accounts {
+		err := l.client.ScanSetUntil(ctx, index.Transaction.Scan(a), false, max, func(ctx context.Context, e *schema.ZEntry) (bool, error) {
+			tx := &Transaction{}
+			err := tx.Parse(e.Entry)
+			if err != nil {
+				return true, NewError(InternalError, "failed to parse the transaction (%v): %v", err, string(e.Entry.Value))
+			}
+
+			tx, err = version(ctx, tx, e.Entry.Revision)
+			if err != nil {
+				return false, err
+			}
+
+			if tx == nil {
+				return true, nil
+			}
+
+			if holder != tx.Holder {
+				return false, fmt.Errorf("balance: unexpected holder %v!=%v in %v", holder, tx.Holder, tx.tx)
+			}
+
+			balance, ok := assets[tx.Asset]
+			if !ok {
+				balance = types.NewBalance(status)
+				assets[tx.Asset] = balance
+			}
+
+			balance.Add(tx.Account, tx.Amount, tx.Status)
+
+			return true, nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return assets, nil
+}
@@ -0,0 +1,185 @@
+package ledger_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Balance_At(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	for _, f := range formats {
+		t.Run(t.Name()+"_"+f.String(), func(t *testing.T) {
+			l := ledger.New(client,
+				ledger.SpendPending(),
+				ledger.SupportedAssets(cfg.Assets),
+				ledger.Format(f),
+			)
+
+			asset := randomAsset(assets)
+			holder := randomName()
+
+			tx1, ok := add(ctx, t, l, holder, asset, three)
+			if !ok {
+				return
+			}
+
+			at := *tx1.Created
+			time.Sleep(10 * time.Millisecond)
+
+			tx2, err := remove(ctx, t, l, holder, asset, one)
+			if err != nil {
+				return
+			}
+
+			_, err = l.Status(ctx, tx1, types.Finished)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			b, err := l.BalanceAt(ctx, holder, types.AllAssets, types.AllAccounts, at)
+			if assert.NoError(t, err) && assert.Contains(t, b, asset) {
+				assert.Equal(t, three.String(), b[asset].Sum.String())
+				assert.Equal(t, uint(1), b[asset].Count)
+			}
+
+			b, err = l.BalanceAsOfTx(ctx, holder, types.AllAssets, types.AllAccounts, tx1.TX())
+			if assert.NoError(t, err) && assert.Contains(t, b, asset) {
+				assert.Equal(t, three.String(), b[asset].Sum.String())
+				assert.Equal(t, uint(1), b[asset].Count)
+			}
+
+			b, err = l.BalanceAsOfTx(ctx, holder, types.AllAssets, types.AllAccounts, tx2.TX())
+			if assert.NoError(t, err) && assert.Contains(t, b, asset) {
+				assert.Equal(t, two.String(), b[asset].Sum.String())
+				assert.Equal(t, uint(2), b[asset].Count)
+			}
+
+			b, err = l.BalanceAt(ctx, holder, types.AllAssets, types.AllAccounts, time.Now())
+			if assert.NoError(t, err) && assert.Contains(t, b, asset) {
+				assert.Equal(t, two.String(), b[asset].Sum.String())
+				assert.Equal(t, uint(2), b[asset].Count)
+			}
+
+			b, err = l.BalanceAt(ctx, holder, types.AllAssets, types.AllAccounts, at.Add(-time.Second))
+			if assert.NoError(t, err) {
+				assert.Empty(t, b)
+			}
+
+			_, err = l.BalanceAsOfTx(ctx, holder, types.AllAssets, types.AllAccounts, 0)
+			assert.EqualError(t, err, "balance: tx is mandatory")
+		})
+	}
+}
+
+func Test_Balance_Statuses(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	statuses := types.Statuses{"Approved": 500}
+	for k, v := range types.DefaultStatusMap {
+		statuses[k] = v
+	}
+
+	// pending credits aren't spendable by default
+	l := ledger.New(client,
+		ledger.SupportedAssets(cfg.Assets),
+		ledger.SupportedStatuses(statuses),
+		ledger.StatusTransitions(types.Transitions{}),
+	)
+
+	asset := randomAsset(assets)
+	holder := randomName()
+
+	balance := func(status types.Status) *types.Balance {
+		b, err := l.Balance(ctx, holder, asset, types.AllAccounts, status)
+		if !assert.NoError(t, err) || !assert.Contains(t, b, asset) {
+			return types.NewBalance(status)
+		}
+
+		return b[asset]
+	}
+
+	tx1, ok := add(ctx, t, l, holder, asset, three)
+	if !ok {
+		return
+	}
+
+	b := balance(types.AllStatuses)
+	assert.Equal(t, three.String(), b.Sum.String())
+	assert.Equal(t, zero.String(), b.Available.String())
+	assert.Equal(t, three.String(), b.Pending().String())
+
+	_, err = l.Remove(ctx, holder, asset, one)
+	assert.EqualError(t, err, fmt.Sprintf("balance too low to remove %v %v for holder %v", asset, one, holder))
+
+	_, err = l.Status(ctx, tx1, types.Finished)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = remove(ctx, t, l, holder, asset, one)
+	if err != nil {
+		return
+	}
+
+	tx3, ok := add(ctx, t, l, holder, asset, two)
+	if !ok {
+		return
+	}
+
+	_, err = l.Cancel(ctx, holder, asset, tx3.Account, tx3.ID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	b = balance(types.AllStatuses)
+	assert.Equal(t, two.String(), b.Sum.String())
+	assert.Equal(t, two.String(), b.Available.String())
+	assert.Equal(t, one.Neg().String(), b.Pending().String())
+	assert.Equal(t, one.String(), b.Finished().String())
+	assert.Equal(t, two.String(), b.Canceled().String())
+	assert.Equal(t, uint(4), b.Count)
+
+	b = balance(types.Created)
+	assert.Equal(t, one.Neg().String(), b.Sum.String())
+	assert.Equal(t, uint(1), b.Count)
+
+	// only finished credits are available
+	tx4, ok := add(ctx, t, l, holder, asset, one)
+	if !ok {
+		return
+	}
+
+	_, err = l.Status(ctx, tx4, statuses["Approved"])
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	b = balance(types.AllStatuses)
+	assert.Equal(t, three.String(), b.Sum.String())
+	assert.Equal(t, two.String(), b.Available.String())
+
+	err = l.RebuildBalances(ctx, holder, true, func(ctx context.Context, drift *ledger.BalanceDrift) error {
+		assert.Fail(t, "unexpected balance drift", "%v", drift.Computed.Key())
+		return nil
+	})
+	assert.NoError(t, err)
+}
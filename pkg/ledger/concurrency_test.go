@@ -0,0 +1,193 @@
+package ledger_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Concurrent_Remove(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	l := ledger.New(client,
+		ledger.SpendPending(),
+		ledger.SupportedAssets(cfg.Assets),
+		ledger.Overdraw(false),
+		ledger.Retries(50),
+	)
+
+	asset := randomAsset(assets)
+	holder := randomName()
+	workers := 10
+
+	_, ok := add(ctx, t, l, holder, asset, three)
+	if !ok {
+		return
+	}
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	removed := 0
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := l.Remove(ctx, holder, asset, one)
+			if err == nil {
+				mutex.Lock()
+				removed++
+				mutex.Unlock()
+				return
+			}
+
+			e, ok := err.(ledger.Error)
+			if assert.True(t, ok, err.Error()) {
+				assert.True(t, e.IsError(ledger.NotEnoughAssetsError), err.Error())
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 3, removed)
+
+	b, err := l.Balance(ctx, holder, asset, types.AllAccounts, types.AllStatuses)
+	if assert.NoError(t, err) && assert.Contains(t, b, asset) {
+		assert.Equal(t, zero.String(), b[asset].Sum.String())
+		assert.Equal(t, uint(4), b[asset].Count)
+	}
+}
+
+func Test_Concurrent_Hold(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	l := ledger.New(client,
+		ledger.SpendPending(),
+		ledger.SupportedAssets(cfg.Assets),
+		ledger.Overdraw(false),
+		ledger.Retries(50),
+	)
+
+	asset := randomAsset(assets)
+	holder := randomName()
+	workers := 10
+
+	_, ok := add(ctx, t, l, holder, asset, three)
+	if !ok {
+		return
+	}
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	reserved := 0
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			// holds race with each other and with removes
+			var err error
+			if i%2 == 0 {
+				_, err = l.Hold(ctx, holder, asset, one, time.Time{})
+			} else {
+				_, err = l.Remove(ctx, holder, asset, one)
+			}
+
+			if err == nil {
+				mutex.Lock()
+				reserved++
+				mutex.Unlock()
+				return
+			}
+
+			e, ok := err.(ledger.Error)
+			if assert.True(t, ok, err.Error()) {
+				assert.True(t, e.IsError(ledger.NotEnoughAssetsError), err.Error())
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 3, reserved)
+
+	b, err := l.Balance(ctx, holder, asset, types.AllAccounts, types.AllStatuses)
+	if assert.NoError(t, err) && assert.Contains(t, b, asset) {
+		assert.Equal(t, zero.String(), b[asset].Sum.Sub(b[asset].Held).String())
+	}
+}
+
+func Test_Concurrent_Assets(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	// concurrent writes of different accounts update the asset snapshot
+	// one after the other
+	l := ledger.New(client,
+		ledger.SupportedAssets(cfg.Assets),
+		ledger.Retries(50),
+	)
+
+	asset := randomAsset(assets)
+	holder := randomName()
+	workers := 10
+
+	for i := 0; i < workers; i++ {
+		_, ok := add(ctx, t, l, fmt.Sprintf("%v_%v", holder, i), asset, one)
+		if !ok {
+			return
+		}
+	}
+
+	before, err := l.AssetSnapshot(ctx, asset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			_, err := l.Add(ctx, fmt.Sprintf("%v_%v", holder, i), asset, one)
+			assert.NoError(t, err)
+		}(i)
+	}
+
+	wg.Wait()
+
+	after, err := l.AssetSnapshot(ctx, asset)
+	if assert.NoError(t, err) {
+		assert.Equal(t, before.Sum.Add(decimal.NewFromInt(int64(workers))).String(), after.Sum.String())
+		assert.Equal(t, before.Count+uint(workers), after.Count)
+	}
+}
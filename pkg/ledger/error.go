@@ -32,3 +32,7 @@ func (e Error) HttpStatusCode() int {
 func (e Error) IsError(code int) bool {
 	return e.code == code
 }
+
+func (e Error) Code() int {
+	return e.code
+}
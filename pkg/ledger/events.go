@@ -0,0 +1,165 @@
+package ledger
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger/index"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+)
+
+// Filter selects the transactions of a subscription, an empty filter
+// matches all transactions
+type Filter struct {
+	Holder string
+	Asset  types.Asset
+	Status types.Status
+}
+
+func NewFilter() *Filter {
+	return &Filter{
+		Status: types.AllStatuses,
+	}
+}
+
+func (f *Filter) Match(tx *Transaction) bool {
+	if f.Holder != "" && f.Holder != tx.Holder {
+		return false
+	}
+
+	if f.Asset != types.AllAssets && f.Asset != tx.Asset {
+		return false
+	}
+
+	if f.Status != types.AllStatuses && f.Status != tx.Status {
+		return false
+	}
+
+	return true
+}
+
+// Event is a transaction written by the immudb transaction Tx, Last marks the
+// last matching transaction of Tx
+type Event struct {
+	Tx          uint64
+	Last        bool
+	Transaction *Transaction
+}
+
+// LastTX returns the id of the last committed immudb transaction, it is the
+// position of a subscription that only receives new transactions
+func (l *Ledger) LastTX(ctx context.Context) (uint64, error) {
+	last, err := l.client.LastTX(ctx)
+	if err != nil {
+		return 0, NewError(InternalError, "failed to read the last tx: %v", err)
+	}
+
+	return last, nil
+}
+
+// Subscribe tails the ledger and calls f for each transaction written after
+// the immudb transaction fromTx, new and updated transactions are delivered
+// in commit order until the context is done or f returns false
+func (l *Ledger) Subscribe(ctx context.Context, fromTx uint64, filter *Filter, f func(context.Context, *Event) (bool, error)) error {
+	if filter == nil {
+		filter = NewFilter()
+	}
+
+	ticker := time.NewTicker(l.poll)
+	defer ticker.Stop()
+
+	for {
+		last, err := l.LastTX(ctx)
+		if err != nil {
+			return err
+		}
+
+		if last > fromTx {
+			stop := false
+
+			err = l.Events(ctx, fromTx, last, filter, func(ctx context.Context, e *Event) (bool, error) {
+				ok, err := f(ctx, e)
+				stop = err != nil || !ok
+				return ok, err
+			})
+
+			if err != nil || stop {
+				return err
+			}
+
+			fromTx = last
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Events calls f for each transaction written by the immudb transactions after
+// fromTx up to toTx
+func (l *Ledger) Events(ctx context.Context, fromTx uint64, toTx uint64, filter *Filter, f func(context.Context, *Event) (bool, error)) error {
+	if filter == nil {
+		filter = NewFilter()
+	}
+
+	for id := fromTx + 1; id <= toTx; id++ {
+		events, err := l.events(ctx, id, filter)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range events {
+			ok, err := f(ctx, e)
+			if err != nil || !ok {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (l *Ledger) events(ctx context.Context, id uint64, filter *Filter) ([]*Event, error) {
+	tx, err := l.client.GetTx(ctx, id)
+	if err != nil {
+		return nil, NewError(InternalError, "failed to read tx %v: %v", id, err)
+	}
+
+	prefix := index.Key.Prefix()
+	events := []*Event{}
+
+	for _, e := range tx.Entries {
+		key := string(e.Key)
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		entry, err := l.client.GetAt(ctx, key, id)
+		if err != nil {
+			return nil, NewError(InternalError, "failed to read %v at tx %v: %v", key, id, err)
+		}
+
+		t := &Transaction{}
+		err = t.Parse(entry)
+		if err != nil {
+			return nil, NewError(InternalError, "failed to parse the transaction (%v): %v", err, string(entry.Value))
+		}
+
+		if filter.Match(t) {
+			events = append(events, &Event{
+				Tx:          id,
+				Transaction: t,
+			})
+		}
+	}
+
+	if len(events) > 0 {
+		events[len(events)-1].Last = true
+	}
+
+	return events, nil
+}
@@ -0,0 +1,104 @@
+package ledger_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Subscribe(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	l := ledger.New(client,
+		ledger.SupportedAssets(cfg.Assets),
+		ledger.PollInterval(10*time.Millisecond),
+	)
+
+	asset := randomAsset(assets)
+	holder := randomName()
+
+	start, err := client.LastTX(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	tx1, ok := add(ctx, t, l, holder, asset, two)
+	if !ok {
+		return
+	}
+
+	_, err = l.Status(ctx, tx1, types.Finished)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	tx2, ok := add(ctx, t, l, holder, asset, one)
+	if !ok {
+		return
+	}
+
+	cancel, err := l.Cancel(ctx, holder, asset, tx2.Account, tx2.ID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	subscribe := func(from uint64, filter *ledger.Filter, count int) []*ledger.Event {
+		timeout, done := context.WithTimeout(ctx, 5*time.Second)
+		defer done()
+
+		events := []*ledger.Event{}
+		err := l.Subscribe(timeout, from, filter, func(ctx context.Context, e *ledger.Event) (bool, error) {
+			events = append(events, e)
+			return len(events) < count, nil
+		})
+		assert.NoError(t, err)
+
+		return events
+	}
+
+	filter := ledger.NewFilter()
+	filter.Holder = holder
+
+	events := subscribe(start, filter, 5)
+	if !assert.Len(t, events, 5) {
+		return
+	}
+
+	assert.Equal(t, tx1.ID, events[0].Transaction.ID)
+	assert.Equal(t, types.Created, events[0].Transaction.Status)
+	assert.Equal(t, tx1.ID, events[1].Transaction.ID)
+	assert.Equal(t, types.Finished, events[1].Transaction.Status)
+	assert.Equal(t, tx2.ID, events[2].Transaction.ID)
+
+	// the cancel and the canceled transaction are written by the same tx
+	assert.Equal(t, events[3].Tx, events[4].Tx)
+	assert.False(t, events[3].Last)
+	assert.True(t, events[4].Last)
+	assert.ElementsMatch(t, []types.ID{cancel.ID, tx2.ID}, []types.ID{events[3].Transaction.ID, events[4].Transaction.ID})
+
+	for i := 1; i < len(events); i++ {
+		assert.LessOrEqual(t, events[i-1].Tx, events[i].Tx)
+	}
+
+	// resume after the status change and only select canceled transactions
+	filter.Status = types.Canceled
+
+	events = subscribe(events[1].Tx, filter, 1)
+	if !assert.Len(t, events, 1) {
+		return
+	}
+
+	assert.Equal(t, tx2.ID, events[0].Transaction.ID)
+	assert.Equal(t, types.Canceled, events[0].Transaction.Status)
+	assert.True(t, events[0].Last)
+}
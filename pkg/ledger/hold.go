@@ -0,0 +1,539 @@
+package ledger
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/ec-systems/core.ledger.server/pkg/ledger/index"
+	"github.com/ec-systems/core.ledger.server/pkg/logger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/shopspring/decimal"
+)
+
+type HoldStatus string
+
+const (
+	Held     HoldStatus = "Held"
+	Captured HoldStatus = "Captured"
+	Voided   HoldStatus = "Voided"
+	Expired  HoldStatus = "Expired"
+)
+
+// sweepGrace is rescanned by every sweep, a hold which expires before the
+// end of a sweep can be committed after its scan
+const sweepGrace = time.Minute
+
+// Hold reserves an amount of an account, the amount is no longer available
+// but stays part of the balance until the hold is captured
+type Hold struct {
+	tx    uint64
+	key   string
+	since uint64
+
+	ID      types.ID        `json:"ID" swaggertype:"primitive,string"`
+	Holder  string          `json:"Holder"`
+	Account types.Account   `json:"Account" swaggertype:"primitive,string"`
+	Asset   types.Asset     `json:"Asset"`
+	Amount  decimal.Decimal `json:"Amount"`
+
+	Status      HoldStatus `json:"Status"`
+	Transaction types.ID   `json:"Transaction" swaggertype:"primitive,string"`
+	Order       string     `json:"Order,omitempty"`
+	Reference   string     `json:"Reference,omitempty"`
+
+	Expires  *time.Time `json:"Expires,omitempty"`
+	Created  *time.Time `json:"Created"`
+	Modified *time.Time `json:"Modified,omitempty"`
+}
+
+func (h *Hold) SetTX(tx uint64) {
+	h.tx = tx
+}
+
+func (h *Hold) TX() uint64 {
+	return h.tx
+}
+
+func (h *Hold) SetKey(key string) {
+	h.key = key
+}
+
+func (h *Hold) Key() string {
+	return h.key
+}
+
+func (h *Hold) Expired(now time.Time) bool {
+	return h.Expires != nil && !h.Expires.After(now)
+}
+
+// Hold reserves the amount on an account of the holder with enough available
+// balance, a zero expiry keeps the hold until it is captured or voided
+func (l *Ledger) Hold(ctx context.Context, holder string, asset types.Asset, amount decimal.Decimal, expires time.Time, options ...TransactionOption) (*Hold, error) {
+	if l.readOnly {
+		return nil, NewError(NotFoundError, "read-only instance")
+	}
+
+	if amount.IsZero() || amount.IsNegative() {
+		return nil, NewError(BadRequestError, "can't hold %v %v", asset, amount)
+	}
+
+	if !expires.IsZero() && !expires.After(time.Now()) {
+		return nil, NewError(BadRequestError, "hold expiry %v is in the past", expires)
+	}
+
+	var hold *Hold
+
+	err := l.retry(ctx, func() error {
+		// resolves the account and checks the available balance
+		// like a debit of the amount
+		tx, err := l.newTx(ctx, holder, asset, amount.Neg(), options...)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+
+		hold = &Hold{
+			since:     tx.since,
+			ID:        tx.ID,
+			Holder:    tx.Holder,
+			Account:   tx.Account,
+			Asset:     tx.Asset,
+			Amount:    amount,
+			Status:    Held,
+			Order:     tx.Order,
+			Reference: tx.Reference,
+			Created:   &now,
+		}
+
+		if !expires.IsZero() {
+			hold.Expires = &expires
+		}
+
+		ops, err := l.HoldOperations(hold)
+		if err != nil {
+			return err
+		}
+
+		balances, err := l.ReserveOperations(ctx, []*Hold{hold})
+		if err != nil {
+			return err
+		}
+
+		hold.tx, err = l.client.Exec(ctx, append(ops, balances...)...)
+		return err
+	})
+
+	if err != nil {
+		if _, ok := err.(Error); ok {
+			return nil, err
+		}
+
+		return nil, NewError(InternalError, "hold %v %v for holder %v failed: %v", amount, asset, holder, err)
+	}
+
+	return hold, nil
+}
+
+// Capture turns a hold into a debit of the held amount, the debit and the
+// release of the hold are written in the same transaction
+func (l *Ledger) Capture(ctx context.Context, id types.ID) (*Hold, *Transaction, error) {
+	if l.readOnly {
+		return nil, nil, NewError(NotFoundError, "read-only instance")
+	}
+
+	var hold *Hold
+	var debit *Transaction
+
+	err := l.retry(ctx, func() error {
+		var err error
+
+		hold, err = l.active(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		txID, err := l.NewID()
+		if err != nil {
+			return err
+		}
+
+		debit = &Transaction{
+			ID:        txID,
+			Holder:    hold.Holder,
+			Account:   hold.Account,
+			Asset:     hold.Asset,
+			Amount:    hold.Amount.Neg(),
+			Status:    types.Created,
+			Order:     hold.Order,
+			Reference: hold.Reference,
+			User:      UserFrom(ctx),
+		}
+
+		ops, key, err := l.CreateOperations(debit)
+		if err != nil {
+			return err
+		}
+
+		debit.key = key
+
+		hold.Status = Captured
+		hold.Transaction = debit.ID
+
+		update, err := l.HoldOperations(hold)
+		if err != nil {
+			return err
+		}
+
+		balances, err := l.ReserveOperations(ctx, []*Hold{hold}, debit)
+		if err != nil {
+			return err
+		}
+
+		ops = append(ops, update...)
+		ops = append(ops, l.CaptureOperations(hold, debit)...)
+
+		debit.tx, err = l.client.Exec(ctx, append(ops, balances...)...)
+		hold.tx = debit.tx
+
+		return err
+	})
+
+	if err != nil {
+		if _, ok := err.(Error); ok {
+			return nil, nil, err
+		}
+
+		return nil, nil, NewError(InternalError, "capture of hold %v failed: %v", id, err)
+	}
+
+	for _, c := range l.collectors {
+		c.Add(debit.Asset, debit.Amount)
+	}
+
+	return hold, debit, nil
+}
+
+// Void releases a hold without a debit
+func (l *Ledger) Void(ctx context.Context, id types.ID) (*Hold, error) {
+	return l.release(ctx, id, Voided)
+}
+
+// ExpireHolds releases all holds which expired before the given time. The
+// expiry set keeps released holds, a sweep starts at the end of the last
+// complete sweep because new holds always expire in the future.
+func (l *Ledger) ExpireHolds(ctx context.Context, now time.Time) (int, error) {
+	if l.readOnly {
+		return 0, NewError(NotFoundError, "read-only instance")
+	}
+
+	l.sweepLock.Lock()
+	defer l.sweepLock.Unlock()
+
+	bound := time.Now()
+	if now.Before(bound) {
+		bound = now
+	}
+
+	var min *schema.Score
+	if !l.swept.IsZero() {
+		min = &schema.Score{Score: float64(l.swept.UnixMilli())}
+	}
+
+	max := &schema.Score{Score: float64(now.UnixMilli())}
+	expired := []types.ID{}
+
+	err := l.client.ScanSetRange(ctx, string(index.HoldExpiry.Key()), false, min, max, nil, func(ctx context.Context, e *schema.ZEntry) (bool, error) {
+		hold := &Hold{}
+		err := Unmarshal(e.Entry, hold)
+		if err != nil {
+			return false, NewError(InternalError, "failed to parse the hold (%v): %v", err, string(e.Entry.Value))
+		}
+
+		if hold.Status == Held && hold.Expired(now) {
+			expired = append(expired, hold.ID)
+		}
+
+		return true, nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	cnt := 0
+
+	for _, id := range expired {
+		_, err := l.release(ctx, id, Expired)
+		if err != nil {
+			if lerr, ok := err.(Error); ok && lerr.IsError(InvalidStatusError) {
+				// captured or voided in the meantime
+				continue
+			}
+
+			return cnt, err
+		}
+
+		cnt++
+	}
+
+	l.swept = bound.Add(-sweepGrace)
+
+	return cnt, nil
+}
+
+// SweepHolds expires holds in the given interval until the context is done
+func (l *Ledger) SweepHolds(ctx context.Context, interval time.Duration) {
+	if l.readOnly || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			cnt, err := l.ExpireHolds(ctx, now)
+			if err != nil {
+				logger.Errorf("expire holds failed: %v", err)
+			} else if cnt > 0 {
+				logger.Infof("%v holds expired", cnt)
+			}
+		}
+	}
+}
+
+func (l *Ledger) GetHold(ctx context.Context, id types.ID) (*Hold, error) {
+	entry, err := l.client.Get(ctx, string(index.Hold.Key(id)))
+	if err != nil {
+		if strings.Contains(err.Error(), store.ErrKeyNotFound.Error()) {
+			return nil, NewError(NotFoundError, "hold %v not found", id)
+		}
+
+		return nil, NewError(InternalError, "failed to read hold %v: %v", id, err)
+	}
+
+	hold := &Hold{}
+	err = Unmarshal(entry, hold)
+	if err != nil {
+		return nil, NewError(InternalError, "failed to parse the hold %v: %v", id, err)
+	}
+
+	return hold, nil
+}
+
+// Holds lists the holds of a holder, all accounts are used if no account is given
+func (l *Ledger) Holds(ctx context.Context, holder string, asset types.Asset, account types.Account, f func(context.Context, *Hold) (bool, error)) error {
+	if holder == "" {
+		return NewError(BadRequestError, "holder is mandatory")
+	}
+
+	var accounts []types.Account
+	if account.Empty() {
+		acc, err := l.Accounts(ctx, holder, asset)
+		if err != nil {
+			return err
+		}
+
+		accounts = acc
+	} else {
+		accounts = []types.Account{account}
+	}
+
+	for _, a := range accounts {
+		err := l.ForEachHold(ctx, a, func(ctx context.Context, h *Hold) (bool, error) {
+			if holder != h.Holder {
+				return false, NewError(BadRequestError, "invalid holder %v in hold %v (%v)", h.Holder, h.ID, holder)
+			}
+
+			return f(ctx, h)
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l *Ledger) ForEachHold(ctx context.Context, account types.Account, f func(context.Context, *Hold) (bool, error)) error {
+	return l.client.ScanSet(ctx, index.AccountHolds.Scan(account), false, func(ctx context.Context, e *schema.ZEntry) (bool, error) {
+		hold := &Hold{}
+		err := Unmarshal(e.Entry, hold)
+		if err != nil {
+			return true, NewError(InternalError, "failed to parse the hold (%v): %v", err, string(e.Entry.Value))
+		}
+
+		return f(ctx, hold)
+	})
+}
+
+func (l *Ledger) HoldOperations(hold *Hold) ([]interface{}, error) {
+	now := time.Now()
+
+	if hold.Created == nil {
+		hold.Created = &now
+	} else {
+		hold.Modified = &now
+	}
+
+	if !hold.Account.Check() {
+		return nil, NewError(BadRequestError, "checksum check failed for '%v'", hold.Account)
+	}
+
+	if hold.ID.IsEmpty() {
+		return nil, NewError(BadRequestError, "holder '%v' hold id is empty", hold.Holder)
+	}
+
+	data, err := Marshal(hold, types.JSON, Version)
+	if err != nil {
+		return nil, NewError(InternalError, "marshal hold failed: %v", err)
+	}
+
+	key := index.Hold.Key(hold.ID)
+
+	ops := []interface{}{
+		&schema.Op_Kv{
+			Kv: &schema.KeyValue{
+				Key:   key,
+				Value: data,
+			},
+		},
+	}
+
+	if hold.tx == 0 {
+		ops = append(ops,
+			&schema.Op_ZAdd{
+				ZAdd: &schema.ZAddRequest{
+					Key:      key,
+					Set:      index.AccountHolds.Key(hold.Account),
+					Score:    float64(hold.Created.Local().UnixMilli()),
+					BoundRef: false,
+				},
+			},
+			&schema.Precondition_KeyMustNotExist{
+				KeyMustNotExist: &schema.Precondition_KeyMustNotExistPrecondition{
+					Key: key,
+				},
+			},
+		)
+
+		if hold.Expires != nil {
+			ops = append(ops, &schema.Op_ZAdd{
+				ZAdd: &schema.ZAddRequest{
+					Key:      key,
+					Set:      index.HoldExpiry.Key(),
+					Score:    float64(hold.Expires.Local().UnixMilli()),
+					BoundRef: false,
+				},
+			})
+		}
+	} else {
+		ops = append(ops, &schema.Precondition_KeyNotModifiedAfterTX{
+			KeyNotModifiedAfterTX: &schema.Precondition_KeyNotModifiedAfterTXPrecondition{
+				Key:  key,
+				TxID: hold.tx,
+			},
+		})
+	}
+
+	hold.key = string(key)
+
+	return ops, nil
+}
+
+// CaptureOperations links the hold and the debit of its capture in both
+// directions
+func (l *Ledger) CaptureOperations(hold *Hold, debit *Transaction) []interface{} {
+	return []interface{}{
+		&schema.Op_Ref{
+			Ref: &schema.ReferenceRequest{
+				ReferencedKey: []byte(debit.key),
+				Key:           index.Reference.Key(hold.ID, debit.ID),
+				BoundRef:      false,
+			},
+		},
+		&schema.Op_Ref{
+			Ref: &schema.ReferenceRequest{
+				ReferencedKey: index.Hold.Key(hold.ID),
+				Key:           index.Reference.Key(debit.ID, hold.ID),
+				BoundRef:      false,
+			},
+		},
+	}
+}
+
+// active reads a hold which can still be captured or voided
+func (l *Ledger) active(ctx context.Context, id types.ID) (*Hold, error) {
+	hold, err := l.GetHold(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if hold.Status != Held {
+		return nil, NewError(InvalidStatusError, "hold %v is already %v", id, hold.Status)
+	}
+
+	if hold.Expired(time.Now()) {
+		return nil, NewError(InvalidStatusError, "hold %v expired at %v", id, hold.Expires)
+	}
+
+	return hold, nil
+}
+
+func (l *Ledger) release(ctx context.Context, id types.ID, status HoldStatus) (*Hold, error) {
+	if l.readOnly {
+		return nil, NewError(NotFoundError, "read-only instance")
+	}
+
+	var hold *Hold
+
+	err := l.retry(ctx, func() error {
+		var err error
+
+		if status == Expired {
+			hold, err = l.GetHold(ctx, id)
+			if err == nil && hold.Status != Held {
+				err = NewError(InvalidStatusError, "hold %v is already %v", id, hold.Status)
+			}
+		} else {
+			hold, err = l.active(ctx, id)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		hold.Status = status
+
+		ops, err := l.HoldOperations(hold)
+		if err != nil {
+			return err
+		}
+
+		balances, err := l.ReserveOperations(ctx, []*Hold{hold})
+		if err != nil {
+			return err
+		}
+
+		hold.tx, err = l.client.Exec(ctx, append(ops, balances...)...)
+		return err
+	})
+
+	if err != nil {
+		if _, ok := err.(Error); ok {
+			return nil, err
+		}
+
+		return nil, NewError(InternalError, "release of hold %v failed: %v", id, err)
+	}
+
+	return hold, nil
+}
@@ -0,0 +1,137 @@
+package ledger_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Hold(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	l := ledger.New(client,
+		ledger.SpendPending(),
+		ledger.SupportedAssets(cfg.Assets),
+	)
+
+	asset := randomAsset(assets)
+	holder := randomName()
+
+	_, ok := add(ctx, t, l, holder, asset, three)
+	if !ok {
+		return
+	}
+
+	balance := func() *types.Balance {
+		b, err := l.Balance(ctx, holder, asset, types.AllAccounts, types.AllStatuses)
+		if !assert.NoError(t, err) || !assert.Contains(t, b, asset) {
+			return types.NewBalance(types.AllStatuses)
+		}
+		return b[asset]
+	}
+
+	h1, err := l.Hold(ctx, holder, asset, two, time.Time{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, ledger.Held, h1.Status)
+	assert.Equal(t, three.String(), balance().Sum.String())
+	assert.Equal(t, two.String(), balance().Held.String())
+
+	_, err = l.Hold(ctx, holder, asset, two, time.Time{})
+	assert.Error(t, err)
+
+	_, err = l.Remove(ctx, holder, asset, two)
+	assert.Error(t, err)
+
+	h1, tx, err := l.Capture(ctx, h1.ID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, ledger.Captured, h1.Status)
+	assert.Equal(t, tx.ID, h1.Transaction)
+	assert.Equal(t, two.Neg().String(), tx.Amount.String())
+	assert.Equal(t, one.String(), balance().Sum.String())
+	assert.True(t, balance().Held.IsZero())
+
+	_, _, err = l.Capture(ctx, h1.ID)
+	if assert.Error(t, err) {
+		assert.True(t, err.(ledger.Error).IsError(ledger.InvalidStatusError))
+	}
+
+	h2, err := l.Hold(ctx, holder, asset, one, time.Time{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	h2, err = l.Void(ctx, h2.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, ledger.Voided, h2.Status)
+		assert.Equal(t, one.String(), balance().Sum.String())
+		assert.True(t, balance().Held.IsZero())
+	}
+
+	h3, err := l.Hold(ctx, holder, asset, one, time.Now().Add(100*time.Millisecond))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, one.String(), balance().Held.String())
+
+	time.Sleep(200 * time.Millisecond)
+
+	_, err = l.Void(ctx, h3.ID)
+	assert.Error(t, err)
+
+	_, err = l.ExpireHolds(ctx, time.Now())
+	assert.NoError(t, err)
+
+	h3, err = l.GetHold(ctx, h3.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, ledger.Expired, h3.Status)
+		assert.True(t, balance().Held.IsZero())
+	}
+
+	// the next sweep starts after the released holds
+	h4, err := l.Hold(ctx, holder, asset, one, time.Now().Add(100*time.Millisecond))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	_, err = l.ExpireHolds(ctx, time.Now())
+	assert.NoError(t, err)
+
+	h4, err = l.GetHold(ctx, h4.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, ledger.Expired, h4.Status)
+	}
+
+	holds := []*ledger.Hold{}
+	err = l.Holds(ctx, holder, asset, types.AllAccounts, func(ctx context.Context, h *ledger.Hold) (bool, error) {
+		holds = append(holds, h)
+		return true, nil
+	})
+	if assert.NoError(t, err) {
+		assert.Len(t, holds, 4)
+	}
+
+	err = l.RebuildBalances(ctx, holder, true, func(ctx context.Context, d *ledger.BalanceDrift) error {
+		t.Errorf("unexpected drift of %v", d.Computed.Key())
+		return nil
+	})
+	assert.NoError(t, err)
+}
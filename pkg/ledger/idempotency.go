@@ -0,0 +1,81 @@
+package ledger
+
+import (
+	"context"
+	"strings"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/ec-systems/core.ledger.server/pkg/ledger/index"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/shopspring/decimal"
+)
+
+// IdempotencyOperations references the transaction by its idempotency key,
+// the write fails if the key was already used
+func (l *Ledger) IdempotencyOperations(tx *Transaction) []interface{} {
+	if tx.idempotency == "" {
+		return nil
+	}
+
+	key := index.Idempotency.Key(tx.idempotency)
+
+	return []interface{}{
+		&schema.Op_Ref{
+			Ref: &schema.ReferenceRequest{
+				ReferencedKey: index.Key.Key(tx.ID),
+				Key:           key,
+				BoundRef:      false,
+			},
+		},
+		&schema.Precondition_KeyMustNotExist{
+			KeyMustNotExist: &schema.Precondition_KeyMustNotExistPrecondition{
+				Key: key,
+			},
+		},
+	}
+}
+
+// idempotent returns the transaction booked with the idempotency key of the
+// options, or nil if the key is unused. A key used for another request is a
+// conflict.
+func (l *Ledger) idempotent(ctx context.Context, holder string, asset types.Asset, amount decimal.Decimal, options ...TransactionOption) (*Transaction, error) {
+	req := &Transaction{
+		Holder: holder,
+		Asset:  asset,
+		Amount: amount,
+	}
+
+	for _, option := range options {
+		if option != nil {
+			option.Set(req)
+		}
+	}
+
+	if req.idempotency == "" {
+		return nil, nil
+	}
+
+	entry, err := l.client.Get(ctx, string(index.Idempotency.Key(req.idempotency)))
+	if err != nil {
+		if strings.Contains(err.Error(), store.ErrKeyNotFound.Error()) {
+			return nil, nil
+		}
+
+		return nil, NewError(InternalError, "failed to read idempotency key %v: %v", req.idempotency, err)
+	}
+
+	tx := &Transaction{}
+	err = tx.Parse(entry)
+	if err != nil {
+		return nil, NewError(InternalError, "failed to parse the transaction of idempotency key %v: %v", req.idempotency, err)
+	}
+
+	if tx.Holder != req.Holder || tx.Asset != req.Asset || !tx.Amount.Equal(req.Amount) ||
+		tx.Order != req.Order || tx.Item != req.Item || tx.Reference != req.Reference ||
+		(!req.Account.Empty() && tx.Account != req.Account) {
+		return nil, NewError(ConflictError, "idempotency key %v was already used for another request (%v)", req.idempotency, tx.ID)
+	}
+
+	return tx, nil
+}
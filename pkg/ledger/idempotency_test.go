@@ -0,0 +1,82 @@
+package ledger_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Idempotency(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	l := ledger.New(client,
+		ledger.SpendPending(),
+		ledger.SupportedAssets(cfg.Assets),
+	)
+
+	asset := randomAsset(assets)
+	holder := randomName()
+	key := randomName()
+
+	txs := make([]*ledger.Transaction, 5)
+	wg := sync.WaitGroup{}
+
+	for i := range txs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			tx, err := l.Add(ctx, holder, asset, two, ledger.IdempotencyKey(key))
+			if assert.NoError(t, err) {
+				txs[i] = tx
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	for _, tx := range txs {
+		if assert.NotNil(t, tx) {
+			assert.Equal(t, txs[0].ID, tx.ID)
+		}
+	}
+
+	b, err := l.Balance(ctx, holder, asset, types.AllAccounts, types.AllStatuses)
+	if assert.NoError(t, err) && assert.Contains(t, b, asset) {
+		assert.Equal(t, two.String(), b[asset].Sum.String())
+		assert.Equal(t, uint(1), b[asset].Count)
+	}
+
+	_, err = l.Add(ctx, holder, asset, three, ledger.IdempotencyKey(key))
+	if assert.Error(t, err) {
+		assert.True(t, err.(ledger.Error).IsError(ledger.ConflictError))
+	}
+
+	_, err = l.Remove(ctx, holder, asset, two, ledger.IdempotencyKey(key))
+	assert.Error(t, err)
+
+	tx1, err := l.Remove(ctx, holder, asset, one, ledger.IdempotencyKey(key+"_remove"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	tx2, err := l.Remove(ctx, holder, asset, one, ledger.IdempotencyKey(key+"_remove"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, tx1.ID, tx2.ID)
+	}
+
+	b, err = l.Balance(ctx, holder, asset, types.AllAccounts, types.AllStatuses)
+	if assert.NoError(t, err) && assert.Contains(t, b, asset) {
+		assert.Equal(t, one.String(), b[asset].Sum.String())
+	}
+}
@@ -0,0 +1,31 @@
+package index
+
+var APIKey = HashIndex{
+	index{
+		prefix: "AK",
+		max:    1,
+	},
+}
+
+var APIKeys = APIKeysIndex{
+	index{
+		prefix: "AL",
+		max:    1,
+	},
+}
+
+type HashIndex struct {
+	index
+}
+
+func (h *HashIndex) Key(hash string) []byte {
+	return []byte(h.scan(hash))
+}
+
+type APIKeysIndex struct {
+	index
+}
+
+func (a *APIKeysIndex) Key() []byte {
+	return []byte(a.scan())
+}
@@ -27,3 +27,17 @@ func (a *AssetIndex) Key(asset types.Asset) []byte {
 func (a *AssetIndex) Assets() string {
 	return a.scan()
 }
+
+var AssetDefinition = AssetIndex{
+	index{
+		prefix: "AD",
+		max:    1,
+	},
+}
+
+var AssetRegistry = AssetIndex{
+	index{
+		prefix: "AR",
+		max:    1,
+	},
+}
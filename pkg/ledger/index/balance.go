@@ -0,0 +1,15 @@
+package index
+
+var AccountBalance = AccountIndex{
+	index{
+		prefix: "BA",
+		max:    1,
+	},
+}
+
+var AssetBalance = AssetIndex{
+	index{
+		prefix: "BT",
+		max:    1,
+	},
+}
@@ -0,0 +1,30 @@
+package index
+
+var Hold = KeyIndex{
+	index{
+		prefix: "HO",
+		max:    1,
+	},
+}
+
+var AccountHolds = TransactionIndex{
+	index{
+		prefix: "HA",
+		max:    1,
+	},
+}
+
+var HoldExpiry = HoldExpiryIndex{
+	index{
+		prefix: "HX",
+		max:    1,
+	},
+}
+
+type HoldExpiryIndex struct {
+	index
+}
+
+func (h *HoldExpiryIndex) Key() []byte {
+	return []byte(h.scan())
+}
@@ -0,0 +1,16 @@
+package index
+
+var Idempotency = IdempotencyIndex{
+	index{
+		prefix: "IK",
+		max:    1,
+	},
+}
+
+type IdempotencyIndex struct {
+	index
+}
+
+func (i *IdempotencyIndex) Key(key string) []byte {
+	return []byte(i.scan(key))
+}
@@ -0,0 +1,18 @@
+package index
+
+import "github.com/ec-systems/core.ledger.server/pkg/types"
+
+var Journal = JournalIndex{
+	index{
+		prefix: "JN",
+		max:    1,
+	},
+}
+
+type JournalIndex struct {
+	index
+}
+
+func (j *JournalIndex) Key(id types.ID) []byte {
+	return []byte(j.scan(id.HexString()))
+}
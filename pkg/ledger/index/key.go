@@ -20,3 +20,7 @@ func (t *KeyIndex) Key(id types.ID) []byte {
 func (t *KeyIndex) ID(id types.ID) string {
 	return t.scan(id.HexString())
 }
+
+func (t *KeyIndex) Prefix() string {
+	return t.scan()
+}
@@ -1,6 +1,12 @@
 package index
 
-import "github.com/ec-systems/core.ledger.server/pkg/types"
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+)
 
 var Reference = ReferenceIndex{
 	index{
@@ -20,3 +26,43 @@ func (i *ReferenceIndex) Key(src types.ID, dest types.ID) []byte {
 func (i *ReferenceIndex) Source(src types.ID) string {
 	return i.scan(src.HexString())
 }
+
+// Target is the referenced id of a reference key
+func (i *ReferenceIndex) Target(key []byte) (types.ID, error) {
+	parts := strings.Split(string(key), ":")
+	if len(parts) != i.max+1 || parts[0] != i.prefix {
+		return types.ZeroID, fmt.Errorf("invalid reference key '%v'", string(key))
+	}
+
+	data, err := hex.DecodeString(parts[2])
+	if err != nil || len(data) != 16 {
+		return types.ZeroID, fmt.Errorf("invalid reference key '%v'", string(key))
+	}
+
+	return types.NewID(data), nil
+}
+
+// ExternalReference is the set of the transactions with the same normalized
+// reference
+var ExternalReference = ExternalReferenceIndex{
+	index{
+		prefix: "XR",
+		max:    1,
+	},
+}
+
+// UniqueReference refers to the only credit with a normalized reference
+var UniqueReference = ExternalReferenceIndex{
+	index{
+		prefix: "XU",
+		max:    1,
+	},
+}
+
+type ExternalReferenceIndex struct {
+	index
+}
+
+func (i *ExternalReferenceIndex) Key(ref string) []byte {
+	return []byte(i.scan(ref))
+}
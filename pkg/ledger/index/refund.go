@@ -0,0 +1,8 @@
+package index
+
+var Refund = KeyIndex{
+	index{
+		prefix: "RD",
+		max:    1,
+	},
+}
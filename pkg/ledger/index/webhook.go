@@ -0,0 +1,47 @@
+package index
+
+import "github.com/ec-systems/core.ledger.server/pkg/types"
+
+var Webhook = KeyIndex{
+	index{
+		prefix: "WH",
+		max:    1,
+	},
+}
+
+var Webhooks = WebhooksIndex{
+	index{
+		prefix: "WS",
+		max:    1,
+	},
+}
+
+var Delivery = KeyIndex{
+	index{
+		prefix: "WD",
+		max:    1,
+	},
+}
+
+var Deliveries = WebhookIndex{
+	index{
+		prefix: "WA",
+		max:    1,
+	},
+}
+
+type WebhookIndex struct {
+	index
+}
+
+func (w *WebhookIndex) Key(id types.ID) []byte {
+	return []byte(w.scan(id.HexString()))
+}
+
+type WebhooksIndex struct {
+	index
+}
+
+func (w *WebhooksIndex) Key() []byte {
+	return []byte(w.scan())
+}
@@ -0,0 +1,159 @@
+package ledger
+
+import (
+	"context"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger/index"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/shopspring/decimal"
+)
+
+type JournalLeg struct {
+	Holder  string
+	Account types.Account
+	Asset   types.Asset
+	Amount  decimal.Decimal
+}
+
+type Journal struct {
+	ID   types.ID
+	Legs []*Transaction
+}
+
+func (l *Ledger) Journal(ctx context.Context, legs []JournalLeg, options ...TransactionOption) (*Journal, error) {
+	if l.readOnly {
+		return nil, NewError(NotFoundError, "read-only instance")
+	}
+
+	if len(legs) < 2 {
+		return nil, NewError(BadRequestError, "a journal needs at least two legs")
+	}
+
+	sums := map[types.Asset]decimal.Decimal{}
+
+	for i, leg := range legs {
+		if leg.Amount.IsZero() {
+			return nil, NewError(BadRequestError, "journal leg %v of holder %v with 0 %v", i, leg.Holder, leg.Asset)
+		}
+
+		sum, ok := sums[leg.Asset]
+		if !ok {
+			sum = decimal.Zero
+		}
+
+		sums[leg.Asset] = sum.Add(leg.Amount)
+	}
+
+	for asset, sum := range sums {
+		if !sum.IsZero() {
+			return nil, NewError(BadRequestError, "journal legs of %v don't sum up to zero: %v", asset, sum)
+		}
+	}
+
+	var journal *Journal
+	var txID uint64
+
+	err := l.retry(ctx, func() error {
+		id, err := l.NewID()
+		if err != nil {
+			return err
+		}
+
+		journal = &Journal{
+			ID: id,
+		}
+
+		for _, leg := range legs {
+			opts := options
+			if !leg.Account.Empty() {
+				opts = append([]TransactionOption{Account(leg.Account)}, options...)
+			}
+
+			tx, err := l.newTx(ctx, leg.Holder, leg.Asset, leg.Amount, opts...)
+			if err != nil {
+				return err
+			}
+
+			tx.Journal = journal.ID.String()
+			journal.Legs = append(journal.Legs, tx)
+		}
+
+		err = l.debits(ctx, journal.Legs)
+		if err != nil {
+			return err
+		}
+
+		ops, err := l.JournalOperations(journal)
+		if err != nil {
+			return err
+		}
+
+		balances, err := l.BalanceOperations(ctx, journal.Legs...)
+		if err != nil {
+			return err
+		}
+
+		txID, err = l.client.Exec(ctx, append(ops, balances...)...)
+		return err
+	})
+
+	if err != nil {
+		if _, ok := err.(Error); ok {
+			return nil, err
+		}
+
+		return nil, NewError(InternalError, "journal %v failed: %v", journal.ID, err)
+	}
+
+	for _, tx := range journal.Legs {
+		tx.tx = txID
+
+		for _, c := range l.collectors {
+			c.Add(tx.Asset, tx.Amount)
+		}
+	}
+
+	return journal, nil
+}
+
+// debits checks the sum of the debits of each account, newTx only checked
+// each leg on its own. The snapshots are read after the since of the
+// debits, their write fails if an account was modified in the meantime.
+func (l *Ledger) debits(ctx context.Context, legs []*Transaction) error {
+	if l.overdraw {
+		return nil
+	}
+
+	accounts := []types.Account{}
+	sums := map[types.Account]decimal.Decimal{}
+
+	for _, leg := range legs {
+		if !leg.Amount.IsNegative() {
+			continue
+		}
+
+		sum, ok := sums[leg.Account]
+		if !ok {
+			accounts = append(accounts, leg.Account)
+		}
+
+		sums[leg.Account] = sum.Sub(leg.Amount)
+	}
+
+	for _, account := range accounts {
+		_, err := l.covered(ctx, account, sums[account])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l *Ledger) JournalLegs(ctx context.Context, id types.ID, f func(context.Context, *Transaction) (bool, error)) error {
+	if id.IsEmpty() {
+		return NewError(BadRequestError, "journal id is mandatory")
+	}
+
+	return l.ForEachInSet(ctx, string(index.Journal.Key(id)), false, f)
+}
@@ -0,0 +1,110 @@
+package ledger_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Journal(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	for _, f := range formats {
+		t.Run(t.Name()+"_"+f.String(), func(t *testing.T) {
+			l := ledger.New(client,
+				ledger.SpendPending(),
+				ledger.SupportedAssets(cfg.Assets),
+				ledger.Overdraw(false),
+				ledger.Format(f),
+			)
+
+			asset := randomAsset(assets)
+			from := randomName()
+			to1 := from + "_to1"
+			to2 := from + "_to2"
+			reference := "journal"
+
+			_, ok := add(ctx, t, l, from, asset, three)
+			if !ok {
+				return
+			}
+
+			_, err := l.Journal(ctx, []ledger.JournalLeg{
+				{Holder: from, Asset: asset, Amount: three.Neg()},
+				{Holder: to1, Asset: asset, Amount: two},
+			})
+			assert.EqualError(t, err, fmt.Sprintf("journal legs of %v don't sum up to zero: %v", asset, one.Neg()))
+
+			_, err = l.Journal(ctx, []ledger.JournalLeg{
+				{Holder: from, Asset: asset, Amount: three.Neg()},
+			})
+			assert.EqualError(t, err, "a journal needs at least two legs")
+
+			// each debit alone is covered, both together aren't
+			_, err = l.Journal(ctx, []ledger.JournalLeg{
+				{Holder: from, Asset: asset, Amount: two.Neg()},
+				{Holder: from, Asset: asset, Amount: two.Neg()},
+				{Holder: to1, Asset: asset, Amount: two.Add(two)},
+			})
+			if assert.Error(t, err) {
+				assert.True(t, err.(ledger.Error).IsError(ledger.NotEnoughAssetsError), err.Error())
+			}
+
+			journal, err := l.Journal(ctx, []ledger.JournalLeg{
+				{Holder: from, Asset: asset, Amount: three.Neg()},
+				{Holder: to1, Asset: asset, Amount: two},
+				{Holder: to2, Asset: asset, Amount: one},
+			}, ledger.Reference(reference))
+			if !assert.NoError(t, err) || !assert.Len(t, journal.Legs, 3) {
+				return
+			}
+
+			for _, leg := range journal.Legs {
+				assert.NotZero(t, leg.TX())
+				assert.Equal(t, journal.Legs[0].TX(), leg.TX())
+				assert.Equal(t, reference, leg.Reference)
+			}
+
+			legs := map[types.ID]*ledger.Transaction{}
+			err = l.JournalLegs(ctx, journal.ID, func(ctx context.Context, tx *ledger.Transaction) (bool, error) {
+				legs[tx.ID] = tx
+				return true, nil
+			})
+			if assert.NoError(t, err) && assert.Len(t, legs, 3) {
+				for _, leg := range journal.Legs {
+					if assert.Contains(t, legs, leg.ID) {
+						check(t, leg, legs[leg.ID])
+						assert.Equal(t, journal.ID.String(), legs[leg.ID].Journal)
+					}
+				}
+			}
+
+			for holder, sum := range map[string]string{
+				from: "0",
+				to1:  two.String(),
+				to2:  one.String(),
+			} {
+				b, err := l.Balance(ctx, holder, asset, types.AllAccounts, types.AllStatuses)
+				if assert.NoError(t, err) && assert.Contains(t, b, asset) {
+					assert.Equal(t, sum, b[asset].Sum.String())
+				}
+			}
+
+			_, err = l.Journal(ctx, []ledger.JournalLeg{
+				{Holder: from, Asset: asset, Amount: one.Neg()},
+				{Holder: to1, Asset: asset, Amount: one},
+			})
+			assert.EqualError(t, err, fmt.Sprintf("balance too low to remove %v %v for holder %v", asset, one, from))
+		})
+	}
+}
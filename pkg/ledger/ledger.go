@@ -6,10 +6,13 @@ import (
 	"encoding/hex"
 	"fmt"
 	"hash/crc64"
+	"math"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/codenotary/immudb/embedded/store"
 	"github.com/codenotary/immudb/pkg/api/schema"
 	"github.com/shopspring/decimal"
 
@@ -26,9 +29,15 @@ const (
 	AccountNotFoundError = 1
 	TooManyAccountsError = 2
 	NotEnoughAssetsError = 3
+	InvalidStatusError   = 4
+	VerificationError    = 5
+	InvalidAmountError   = 6
 	BadRequestError      = http.StatusBadRequest
+	UnauthorizedError    = http.StatusUnauthorized
+	ForbiddenError       = http.StatusForbidden
 	NotFoundError        = http.StatusNotFound
 	NotAcceptable        = http.StatusNotAcceptable
+	ConflictError        = http.StatusConflict
 	InternalError        = http.StatusInternalServerError
 )
 
@@ -37,19 +46,33 @@ type Ledger struct {
 	readOnly bool
 	overdraw bool
 	multi    bool
+	pending  bool
+	unique   bool
+	retries  int
+	poll     time.Duration
 
-	assets   types.Assets
-	statuses types.Statuses
+	seed        types.Assets
+	assets      types.Assets
+	assetsLock  sync.RWMutex
+	statuses    types.Statuses
+	transitions types.Transitions
 
 	format types.Format
 
+	swept     time.Time
+	sweepLock sync.Mutex
+
 	collectors []types.MetricsCollector
 }
 
 func New(client *client.Client, options ...LedgerOption) *Ledger {
 	ledger := &Ledger{
-		client: client,
-		format: types.JSON,
+		client:      client,
+		format:      types.JSON,
+		retries:     10,
+		poll:        500 * time.Millisecond,
+		statuses:    types.DefaultStatusMap,
+		transitions: types.DefaultTransitions,
 	}
 
 	for _, option := range options {
@@ -65,7 +88,12 @@ func New(client *client.Client, options ...LedgerOption) *Ledger {
 	return ledger
 }
 
+// SupportedAssets returns the configured assets merged with the asset
+// registry, the map is replaced and never modified on changes
 func (l *Ledger) SupportedAssets() types.Assets {
+	l.assetsLock.RLock()
+	defer l.assetsLock.RUnlock()
+
 	return l.assets
 }
 
@@ -73,6 +101,10 @@ func (l *Ledger) SupportedStatus() types.Statuses {
 	return l.statuses
 }
 
+func (l *Ledger) StatusTransitions() types.Transitions {
+	return l.transitions
+}
+
 func (l *Ledger) Assets(ctx context.Context) ([]types.Asset, error) {
 	assets := []types.Asset{}
 
@@ -159,6 +191,13 @@ func (l *Ledger) Balance(ctx context.Context, holder string, asset types.Asset,
 		return nil, NewError(BadRequestError, "balance: holder is mandatory")
 	}
 
+	// the snapshots only hold the totals
+	if status != types.AllStatuses {
+		return l.balanceAt(ctx, holder, asset, account, status, math.MaxFloat64, func(ctx context.Context, tx *Transaction, revision uint64) (*Transaction, error) {
+			return tx, nil
+		})
+	}
+
 	if account.Empty() {
 		acc, err := l.Accounts(ctx, holder, asset)
 		if err != nil {
@@ -171,26 +210,26 @@ func (l *Ledger) Balance(ctx context.Context, holder string, asset types.Asset,
 	}
 
 	for _, a := range accounts {
-		err := l.ForEachInSet(ctx, index.Transaction.Scan(a), false, func(ctx context.Context, tx *Transaction) (bool, error) {
-
-			if holder == tx.Holder {
-				balance, ok := assets[tx.Asset]
-				if !ok {
-					balance = types.NewBalance(status)
-					assets[tx.Asset] = balance
-				}
+		snapshot, err := l.AccountSnapshot(ctx, a)
+		if err != nil {
+			return nil, err
+		}
 
-				balance.Add(tx.Account, tx.Amount, tx.Status)
-			} else {
-				return false, fmt.Errorf("balance: unexpected holder %v!=%v in %v", holder, tx.Holder, tx.tx)
-			}
+		if snapshot.Count == 0 {
+			continue
+		}
 
-			return true, nil
-		})
+		if holder != snapshot.Holder {
+			return nil, fmt.Errorf("balance: unexpected holder %v!=%v in %v", holder, snapshot.Holder, snapshot.tx)
+		}
 
-		if err != nil {
-			return nil, err
+		balance, ok := assets[snapshot.Asset]
+		if !ok {
+			balance = types.NewBalance(status)
+			assets[snapshot.Asset] = balance
 		}
+
+		balance.AddAccount(a, &snapshot.AccountBalance)
 	}
 
 	return assets, nil
@@ -216,19 +255,12 @@ func (l *Ledger) AssetBalance(ctx context.Context, asset types.Asset) (map[types
 	balances := map[types.Asset]decimal.Decimal{}
 
 	for _, a := range assets {
-		err := l.ForEachInSet(ctx, string(index.AssetTx.Key(a)), false, func(ctx context.Context, tx *Transaction) (bool, error) {
-			balance, ok := balances[tx.Asset]
-			if !ok {
-				balance = decimal.Zero
-			}
-
-			balances[tx.Asset] = balance.Add(tx.Amount)
-			return true, nil
-		})
-
+		snapshot, err := l.AssetSnapshot(ctx, a)
 		if err != nil {
-			return nil, NewError(InternalError, "failed to load list of assets: %v", err)
+			return nil, err
 		}
+
+		balances[a] = snapshot.Sum
 	}
 
 	return balances, nil
@@ -292,20 +324,17 @@ func (l *Ledger) Orders(ctx context.Context, holder string, f func(context.Conte
 
 func (l *Ledger) OrderItems(ctx context.Context, holder string, order string, item string, f func(context.Context, *Transaction) (bool, error)) error {
 	if order == "" {
-		return NewError(BadRequestError, "holder is mandatory")
+		return NewError(BadRequestError, "order is mandatory")
 	}
 
+	// the set of an order is shared by all holders and items
 	return l.ForEachInSet(ctx, index.OrderItem.Scan(order), false, func(ctx context.Context, tx *Transaction) (bool, error) {
-		if holder != "" && holder != tx.Holder {
-			return false, NewError(BadRequestError, "invalid holder %v in tx %v (%v)", tx.Holder, tx.ID, holder)
-		}
-
-		if order != "" && order != tx.Order {
+		if order != tx.Order {
 			return false, NewError(BadRequestError, "invalid order %v in tx %v (%v)", tx.Order, tx.ID, order)
 		}
 
-		if item != "" && item != tx.Item {
-			return false, NewError(BadRequestError, "invalid item %v in tx %v (%v)", tx.Item, tx.ID, item)
+		if (holder != "" && holder != tx.Holder) || (item != "" && item != tx.Item) {
+			return true, nil
 		}
 
 		return f(ctx, tx)
@@ -327,50 +356,254 @@ func (l *Ledger) Get(ctx context.Context, transaction types.ID) (*Transaction, e
 	return tx, nil
 }
 
+// Proof reads the transaction verified against the trusted state of the client
+func (l *Ledger) Proof(ctx context.Context, transaction types.ID) (*Transaction, *client.Proof, error) {
+	entry, proof, err := l.client.VerifiedGet(ctx, string(index.Key.Key(transaction)), 0)
+	if err != nil {
+		return nil, nil, proofError(transaction, err)
+	}
+
+	tx := &Transaction{}
+	err = tx.Parse(entry)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tx, proof, nil
+}
+
+// Bundle exports a self-contained proof of the transaction
+func (l *Ledger) Bundle(ctx context.Context, transaction types.ID) (*client.Bundle, error) {
+	tx, err := l.Get(ctx, transaction)
+	if err != nil {
+		return nil, proofError(transaction, err)
+	}
+
+	b, err := l.client.Bundle(ctx, tx.Key())
+	if err != nil {
+		return nil, proofError(transaction, err)
+	}
+
+	return b, nil
+}
+
+func proofError(transaction types.ID, err error) error {
+	if strings.Contains(err.Error(), store.ErrKeyNotFound.Error()) {
+		return NewError(NotFoundError, "transaction %v not found", transaction)
+	}
+
+	if strings.Contains(err.Error(), store.ErrCorruptedData.Error()) {
+		return NewError(VerificationError, "verification of transaction %v failed: %v", transaction, err)
+	}
+
+	return NewError(InternalError, "failed to verify transaction %v: %v", transaction, err)
+}
+
 func (l *Ledger) Status(ctx context.Context, in *Transaction, status types.Status) (*Transaction, error) {
 	if l.readOnly {
 		return nil, NewError(NotFoundError, "read-only instance")
 	}
 
-	tx, err := l.Get(ctx, in.ID)
+	var tx *Transaction
+
+	err := l.retry(ctx, func() error {
+		var err error
+
+		tx, err = l.Get(ctx, in.ID)
+		if err != nil {
+			return err
+		}
+
+		if tx.Holder != in.Holder || tx.Asset != in.Asset || tx.Account != in.Account || tx.ID != in.ID {
+			return fmt.Errorf("invalid holder/asset/account/id combination")
+		}
+
+		if tx.Status == status {
+			return nil
+		}
+
+		// only Cancel writes the reversal, a transaction moved to a
+		// canceled status without it could never be reversed
+		if !canceled(tx) && (status == types.Canceled || status == types.CancellationFinished) {
+			return NewError(InvalidStatusError, "transaction %v can only be canceled with a reversal", tx.ID)
+		}
+
+		if !l.transitions.Allowed(l.statuses, tx.Status, status) {
+			return NewError(InvalidStatusError, "status change of transaction %v from %v to %v is not allowed",
+				tx.ID, tx.Status.String(l.statuses), status.String(l.statuses))
+		}
+
+		from := tx.Status
+		tx.Status = status
+		tx.User = UserFrom(ctx)
+
+		ops, _, err := l.UpdateOperations(tx)
+		if err != nil {
+			return err
+		}
+
+		balances, err := l.StatusOperations(ctx, tx, from)
+		if err != nil {
+			return err
+		}
+
+		tx.tx, err = l.client.Exec(ctx, append(ops, balances...)...)
+		return err
+	})
+
 	if err != nil {
 		return nil, err
 	}
 
-	if tx.Holder != in.Holder || tx.Asset != in.Asset || tx.Account != in.Account || tx.ID != in.ID {
-		return nil, fmt.Errorf("invalid holder/asset/account/id combination")
+	return tx, nil
+}
+
+func (l *Ledger) CreateTx(ctx context.Context, holder string, asset types.Asset, amount decimal.Decimal, options ...TransactionOption) (*Transaction, error) {
+	if l.readOnly {
+		return nil, NewError(NotFoundError, "read-only instance")
 	}
 
-	if tx.Status != status {
-		now := time.Now()
-		tx.Modified = &now
-		tx.Status = status
+	var tx *Transaction
+	var replay bool
+
+	err := l.retry(ctx, func() error {
+		var err error
+
+		// a concurrent request with the same key fails the precondition
+		// and finds the transaction of the other request on retry
+		tx, err = l.idempotent(ctx, holder, asset, amount, options...)
+		if err != nil || tx != nil {
+			replay = tx != nil
+			return err
+		}
 
-		data, err := tx.Bytes(l.format)
+		tx, err = l.newTx(ctx, holder, asset, amount, options...)
 		if err != nil {
-			return nil, NewError(InternalError, "marshal transaction failed: %v", err)
+			return err
 		}
 
-		txID, err := l.client.Set(ctx, index.Key.Key(tx.ID), data)
+		ops, key, err := l.CreateOperations(tx)
 		if err != nil {
-			return nil, err
+			return err
+		}
+
+		balances, err := l.BalanceOperations(ctx, tx)
+		if err != nil {
+			return err
 		}
 
+		ops = append(ops, l.IdempotencyOperations(tx)...)
+
+		txID, err := l.client.Exec(ctx, append(ops, balances...)...)
+
 		tx.tx = txID
+		tx.key = key
+
+		return err
+	})
+
+	if err != nil || replay {
+		return tx, err
+	}
+
+	for _, c := range l.collectors {
+		c.Add(tx.Asset, tx.Amount)
 	}
 
 	return tx, nil
 }
 
-func (l *Ledger) CreateTx(ctx context.Context, holder string, asset types.Asset, amount decimal.Decimal, options ...TransactionOption) (*Transaction, error) {
+// Transfer moves an amount from one holder to another in a single immudb
+// transaction, the accounts of the legs are set with FromAccount and ToAccount
+func (l *Ledger) Transfer(ctx context.Context, from string, to string, asset types.Asset, amount decimal.Decimal, options ...TransactionOption) (*Transaction, *Transaction, error) {
 	if l.readOnly {
-		return nil, NewError(NotFoundError, "read-only instance")
+		return nil, nil, NewError(NotFoundError, "read-only instance")
+	}
+
+	if amount.IsZero() || amount.IsNegative() {
+		return nil, nil, NewError(BadRequestError, "can't transfer %v %v", asset, amount)
+	}
+
+	if from == to {
+		return nil, nil, NewError(BadRequestError, "can't transfer %v %v from holder %v to itself", asset, amount, from)
+	}
+
+	// the options apply to both legs
+	req := &Transaction{}
+	for _, option := range options {
+		if option != nil {
+			option.Set(req)
+		}
+	}
+
+	if !req.Account.Empty() {
+		return nil, nil, NewError(BadRequestError, "the accounts of a transfer are set with FromAccount and ToAccount")
+	}
+
+	if req.idempotency != "" {
+		return nil, nil, NewError(BadRequestError, "idempotency keys aren't supported for transfers")
 	}
 
+	var debit, credit *Transaction
+
+	err := l.retry(ctx, func() error {
+		var err error
+
+		debit, err = l.newTx(ctx, from, asset, amount.Neg(), options...)
+		if err != nil {
+			return err
+		}
+
+		credit, err = l.newTx(ctx, to, asset, amount, options...)
+		if err != nil {
+			return err
+		}
+
+		ops, err := l.TransferOperations(debit, credit)
+		if err != nil {
+			return err
+		}
+
+		balances, err := l.BalanceOperations(ctx, debit, credit)
+		if err != nil {
+			return err
+		}
+
+		debit.tx, err = l.client.Exec(ctx, append(ops, balances...)...)
+		return err
+	})
+
+	if err != nil {
+		if _, ok := err.(Error); ok {
+			return nil, nil, err
+		}
+
+		return nil, nil, NewError(InternalError, "transfer %v %v from holder %v to %v failed: %v", asset, amount, from, to, err)
+	}
+
+	credit.tx = debit.tx
+
+	for _, c := range l.collectors {
+		c.Add(debit.Asset, debit.Amount)
+		c.Add(credit.Asset, credit.Amount)
+	}
+
+	return debit, credit, nil
+}
+
+// newTx creates a new transaction, resolves the account of the holder
+// and checks the balance for debits
+func (l *Ledger) newTx(ctx context.Context, holder string, asset types.Asset, amount decimal.Decimal, options ...TransactionOption) (*Transaction, error) {
 	if amount.IsZero() {
 		return nil, NewError(BadRequestError, "transaction for holder %v with 0 %v", holder, asset)
 	}
 
+	if definition, ok := l.SupportedAssets()[asset]; ok {
+		if err := definition.Validate(amount); err != nil {
+			return nil, NewError(InvalidAmountError, "invalid %v transaction for holder %v: %v", asset, holder, err)
+		}
+	}
+
 	id, err := l.NewID()
 	if err != nil {
 		return nil, err
@@ -383,6 +616,7 @@ func (l *Ledger) CreateTx(ctx context.Context, holder string, asset types.Asset,
 		Status: types.Created,
 		Asset:  asset,
 		Amount: amount,
+		User:   UserFrom(ctx),
 	}
 
 	for _, option := range options {
@@ -434,7 +668,16 @@ func (l *Ledger) CreateTx(ctx context.Context, holder string, asset types.Asset,
 	if tx.Amount.IsNegative() && !l.overdraw {
 		negAmount := amount.Neg()
 
-		balances, err := l.Balance(ctx, holder, asset, tx.Account, types.Created)
+		// the balance is based on all writes up to this tx, the write
+		// of the debit fails if the account was modified afterwards
+		since, err := l.client.LastTX(ctx)
+		if err != nil {
+			return nil, NewError(InternalError, "failed to get last tx: %v", err)
+		}
+
+		tx.since = since
+
+		balances, err := l.Balance(ctx, holder, asset, tx.Account, types.AllStatuses)
 		if err != nil {
 			return nil, NewError(InternalError, "failed to get holder %v balance: %v", holder, err)
 		}
@@ -444,12 +687,12 @@ func (l *Ledger) CreateTx(ctx context.Context, holder string, asset types.Asset,
 			return nil, NewError(NotFoundError, "no %v account found for holder %v", asset, holder)
 		}
 
-		if balance.Sum.LessThan(negAmount) {
+		if l.spendable(&balance.AccountBalance).LessThan(negAmount) {
 			return nil, NewError(NotEnoughAssetsError, "balance too low to remove %v %v for holder %v", asset, negAmount, holder)
 		}
 
 		for k, v := range balance.Accounts {
-			if !v.Sum.LessThan(negAmount) {
+			if !l.spendable(v).LessThan(negAmount) {
 				tx.Account = k
 				break
 			}
@@ -464,21 +707,44 @@ func (l *Ledger) CreateTx(ctx context.Context, holder string, asset types.Asset,
 		return nil, NewError(BadRequestError, "invalid checksum for account %v", tx.Account)
 	}
 
-	ops, key, err := l.CreateOperations(tx)
-	if err != nil {
-		return nil, err
+	if l.unique && tx.Amount.IsPositive() {
+		err := l.booked(ctx, tx.Reference)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	txID, err := l.client.Exec(ctx, ops...)
+	return tx, nil
+}
 
-	tx.tx = txID
-	tx.key = key
+// spendable returns the amount which can be removed from a balance,
+// pending credits only count if the ledger allows to spend them
+func (l *Ledger) spendable(balance *types.AccountBalance) decimal.Decimal {
+	if l.pending {
+		return balance.Sum.Sub(balance.Held)
+	}
 
-	for _, c := range l.collectors {
-		c.Add(tx.Asset, tx.Amount)
+	return balance.Available
+}
+
+// covered checks the spendable balance of an account for a debit, the
+// balance is based on all writes up to the returned tx
+func (l *Ledger) covered(ctx context.Context, account types.Account, amount decimal.Decimal) (uint64, error) {
+	since, err := l.client.LastTX(ctx)
+	if err != nil {
+		return 0, NewError(InternalError, "failed to get last tx: %v", err)
+	}
+
+	s, err := l.AccountSnapshot(ctx, account)
+	if err != nil {
+		return 0, err
+	}
+
+	if l.spendable(&s.AccountBalance).LessThan(amount) {
+		return 0, NewError(NotEnoughAssetsError, "balance too low to remove %v %v from account %v", s.Asset, amount, account)
 	}
 
-	return tx, err
+	return since, nil
 }
 
 func (l *Ledger) Add(ctx context.Context, holder string, asset types.Asset, amount decimal.Decimal, options ...TransactionOption) (*Transaction, error) {
@@ -502,63 +768,132 @@ func (l *Ledger) Cancel(ctx context.Context, holder string, asset types.Asset, a
 		return nil, NewError(NotFoundError, "read-only instance")
 	}
 
-	tx, err := l.Get(ctx, transaction)
-	if err != nil {
-		return nil, NewError(InternalError, "cant read transaxtion %v: %v", transaction, err)
-	}
+	var tx *Transaction
+	var cancel *Transaction
 
-	if tx.Holder != holder && tx.Asset != asset && tx.Account != account {
-		return nil, NewError(BadRequestError, "inconsistent holder/account/transaction combination (%v/%v/%v)", holder, account, transaction)
-	}
+	err := l.retry(ctx, func() error {
+		var err error
+
+		tx, err = l.Get(ctx, transaction)
+		if err != nil {
+			return NewError(InternalError, "cant read transaxtion %v: %v", transaction, err)
+		}
 
-	now := time.Now()
-	tx.Modified = &now
+		if tx.Holder != holder || tx.Asset != asset || tx.Account != account {
+			return NewError(BadRequestError, "inconsistent holder/account/transaction combination (%v/%v/%v)", holder, account, transaction)
+		}
+
+		// a concurrent cancel fails with the precondition of the
+		// original key and is detected here by the retry
+		if canceled(tx) {
+			return NewError(ConflictError, "transaction %v is already canceled", tx.ID)
+		}
+
+		refunds, err := l.Refunded(ctx, tx.ID)
+		if err != nil {
+			return err
+		}
+
+		if refunds.Amount.GreaterThanOrEqual(tx.Amount.Abs()) {
+			return NewError(ConflictError, "transaction %v is already refunded", tx.ID)
+		}
+
+		// the cancel of a spendable credit is a debit of the holder
+		var since uint64
+		if tx.Amount.IsPositive() && !l.overdraw && (l.pending || types.Spendable(tx.Amount, tx.Status)) {
+			since, err = l.covered(ctx, tx.Account, tx.Amount.Sub(refunds.Amount))
+			if err != nil {
+				return err
+			}
+		}
+
+		from := tx.Status
+		now := time.Now()
+		tx.Modified = &now
+		tx.User = UserFrom(ctx)
+
+		ops, c, err := l.CancelOperations(tx, refunds)
+		if err != nil {
+			return err
+		}
+
+		c.since = since
+
+		balances, err := l.balanceOperations(ctx, []*Transaction{c}, tx, from, nil)
+		if err != nil {
+			return err
+		}
+
+		c.tx, err = l.client.Exec(ctx, append(ops, balances...)...)
+		if err != nil {
+			return err
+		}
+
+		cancel = c
+		return nil
+	})
 
-	ops, cancel, err := l.CancelOperations(tx)
 	if err != nil {
 		return nil, err
 	}
 
-	txID, err := l.client.Exec(ctx, ops...)
-
-	cancel.tx = txID
-
 	for _, c := range l.collectors {
 		c.Add(tx.Asset, tx.Amount)
 	}
 
-	return cancel, err
+	return cancel, nil
 }
 
-func (l *Ledger) History(ctx context.Context, id types.ID, f func(ctx context.Context, tx *Transaction) (bool, error)) error {
-	return l.client.History(ctx, index.Key.ID(id), func(ctx context.Context, e *schema.Entry) (bool, error) {
-		if e.Value[0] == 0 {
-			e, err := l.client.GetAt(ctx, string(e.Key), e.Tx)
-			if err != nil {
-				return true, NewError(InternalError, "failed to read the transaction (%v): %v", e.Tx, string(e.Value))
-			}
+// retry runs f again as long as it fails because an account was
+// modified between the balance check and the write
+func (l *Ledger) retry(ctx context.Context, f func() error) error {
+	for i := 0; ; i++ {
+		err := f()
+		if err == nil || !strings.Contains(err.Error(), store.ErrPreconditionFailed.Error()) {
+			return err
+		}
 
-			tx := &Transaction{}
-			err = tx.Parse(e)
-			if err != nil {
-				return true, NewError(InternalError, "failed to parse the transaction (%v): %v", string(e.Value), err)
-			}
+		if i >= l.retries || ctx.Err() != nil {
+			return NewError(ConflictError, "concurrent modification, giving up after %v retries: %v", i, err)
+		}
 
-			return f(ctx, tx)
-		} else {
-			tx := &Transaction{}
-			err := tx.Parse(e)
-			if err != nil {
-				return true, NewError(InternalError, "failed to parse the transaction (%v): %v", string(e.Value), err)
-			}
+		logger.Debugf("concurrent modification, retry %v: %v", i+1, err)
+	}
+}
 
-			return f(ctx, tx)
+func (l *Ledger) History(ctx context.Context, id types.ID, f func(ctx context.Context, tx *Transaction) (bool, error)) error {
+	return l.client.History(ctx, index.Key.ID(id), func(ctx context.Context, e *schema.Entry) (bool, error) {
+		tx, err := l.version(ctx, e)
+		if err != nil {
+			return true, err
 		}
+
+		return f(ctx, tx)
 	})
 }
 
+// version parses a version of a transaction read from its history
+func (l *Ledger) version(ctx context.Context, e *schema.Entry) (*Transaction, error) {
+	if e.Value[0] == 0 {
+		entry, err := l.client.GetAt(ctx, string(e.Key), e.Tx)
+		if err != nil {
+			return nil, NewError(InternalError, "failed to read the transaction (%v): %v", e.Tx, err)
+		}
+
+		e = entry
+	}
+
+	tx := &Transaction{}
+	err := tx.Parse(e)
+	if err != nil {
+		return nil, NewError(InternalError, "failed to parse the transaction (%v): %v", string(e.Value), err)
+	}
+
+	return tx, nil
+}
+
 func (l *Ledger) ForEach(ctx context.Context, prefix string, desc bool, f func(context.Context, *Transaction) (bool, error)) error {
-	return l.client.ScanAll(ctx, prefix, false, func(ctx context.Context, i int, e *schema.Entry) (bool, error) {
+	return l.client.ScanAll(ctx, prefix, desc, func(ctx context.Context, i int, e *schema.Entry) (bool, error) {
 		tx := &Transaction{}
 		err := tx.Parse(e)
 		if err != nil {
@@ -570,7 +905,7 @@ func (l *Ledger) ForEach(ctx context.Context, prefix string, desc bool, f func(c
 }
 
 func (l *Ledger) ForEachInSet(ctx context.Context, prefix string, desc bool, f func(context.Context, *Transaction) (bool, error)) error {
-	return l.client.ScanSet(ctx, prefix, false, func(ctx context.Context, e *schema.ZEntry) (bool, error) {
+	return l.client.ScanSet(ctx, prefix, desc, func(ctx context.Context, e *schema.ZEntry) (bool, error) {
 		tx := &Transaction{}
 		err := tx.Parse(e.Entry)
 		if err != nil {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"hash/crc64"
 	"math/rand"
+	"net/http"
 	"testing"
 	"time"
 
@@ -398,6 +399,15 @@ func Test_Status(t *testing.T) {
 
 			assert.Len(t, txs, 1)
 			assert.Equal(t, types.Finished, txs[0].Status)
+
+			_, err = l.Status(ctx, tx2, types.Created)
+			if assert.Error(t, err) {
+				lerr, ok := err.(ledger.Error)
+				if assert.True(t, ok) {
+					assert.True(t, lerr.IsError(ledger.InvalidStatusError))
+					assert.Equal(t, http.StatusBadRequest, lerr.HttpStatusCode())
+				}
+			}
 		})
 	}
 
@@ -419,6 +429,10 @@ func Test_History(t *testing.T) {
 				ledger.SupportedAssets(cfg.Assets),
 				ledger.MultiAccounts(false),
 				ledger.Format(f),
+				ledger.StatusTransitions(types.Transitions{
+					"Created":  {"Finished"},
+					"Finished": {"Created"},
+				}),
 			)
 
 			amounts := randFloats(1)
@@ -504,6 +518,7 @@ func Test_Remove_MultipleAccounts(t *testing.T) {
 		t.Run(t.Name()+"_"+f.String(), func(t *testing.T) {
 
 			l := ledger.New(client,
+				ledger.SpendPending(),
 				ledger.SupportedAssets(cfg.Assets),
 				ledger.Overdraw(false),
 				ledger.MultiAccounts(true),
@@ -566,6 +581,7 @@ func Test_Overdraw(t *testing.T) {
 		t.Run(t.Name()+"_"+f.String(), func(t *testing.T) {
 
 			l := ledger.New(client,
+				ledger.SpendPending(),
 				ledger.SupportedAssets(cfg.Assets),
 				ledger.Overdraw(false),
 				ledger.Format(f),
@@ -611,6 +627,7 @@ func Test_Overdraw_MultipleAccounts(t *testing.T) {
 		t.Run(t.Name()+"_"+f.String(), func(t *testing.T) {
 
 			l := ledger.New(client,
+				ledger.SpendPending(),
 				ledger.SupportedAssets(cfg.Assets),
 				ledger.Overdraw(false),
 				ledger.MultiAccounts(),
@@ -918,7 +935,18 @@ func Test_Asset_Balances(t *testing.T) {
 				return
 			}
 
-			b, err := l.AssetBalance(ctx, types.AllAssets)
+			_, err := l.AssetBalance(ctx, types.AllAssets)
+			if assert.Error(t, err) {
+				assert.True(t, err.(ledger.Error).IsError(ledger.NotAcceptable))
+			}
+
+			ro := ledger.New(client,
+				ledger.SupportedAssets(cfg.Assets),
+				ledger.Format(f),
+				ledger.ReadOnly(),
+			)
+
+			b, err := ro.AssetBalance(ctx, types.AllAssets)
 
 			if !assert.NoError(t, err) {
 				return
@@ -950,6 +978,7 @@ func Test_Transactions(t *testing.T) {
 		t.Run(t.Name()+"_"+f.String(), func(t *testing.T) {
 
 			l := ledger.New(client,
+				ledger.SpendPending(),
 				ledger.SupportedAssets(cfg.Assets),
 				ledger.MultiAccounts(),
 				ledger.Format(f),
@@ -1068,9 +1097,9 @@ func Test_Balance(t *testing.T) {
 			asset1 := types.Asset("BL1" + "_" + f.String())
 			asset2 := types.Asset("BL2" + "_" + f.String())
 
-			testAssets := map[types.Asset]string{
-				asset1: asset1.String(),
-				asset2: asset2.String(),
+			testAssets := types.Assets{
+				asset1: types.NewAssetDefinition(asset1.String()),
+				asset2: types.NewAssetDefinition(asset2.String()),
 			}
 
 			l := ledger.New(client,
@@ -1288,15 +1317,7 @@ func randFloats(n int, digits ...int) []decimal.Decimal {
 }
 
 func newClient(ctx context.Context) (*client.Client, error) {
-	client, err := client.New(ctx, cfg.ClientOptions.Username, cfg.ClientOptions.Password, cfg.ClientOptions.Database,
-		client.ClientOptions(cfg.ClientOptions),
-		client.Limit(5),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("immudb client error: %v", err)
-	}
-
-	return client, nil
+	return client.FromStorage(store, client.Limit(5)), nil
 }
 
 func add(ctx context.Context, t *testing.T, l *ledger.Ledger, holder string, asset types.Asset, amount decimal.Decimal, options ...ledger.TransactionOption) (*ledger.Transaction, bool) {
@@ -0,0 +1,173 @@
+package ledger
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/ec-systems/core.ledger.server/pkg/ledger/index"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+)
+
+type Relation string
+
+const (
+	CancelRelation   Relation = "cancel"
+	RefundRelation   Relation = "refund"
+	TransferRelation Relation = "transfer"
+	CaptureRelation  Relation = "capture"
+)
+
+// Link is a relation between two transactions, the source of a capture
+// is the hold
+type Link struct {
+	Relation    Relation     `json:"Relation"`
+	Source      types.ID     `json:"Source" swaggertype:"primitive,string"`
+	Target      types.ID     `json:"Target" swaggertype:"primitive,string"`
+	Transaction *Transaction `json:"Transaction,omitempty"`
+	Hold        *Hold        `json:"Hold,omitempty"`
+}
+
+// Links are the relations of a transaction, the transaction is the target
+// of the incoming and the source of the outgoing links
+type Links struct {
+	ID       types.ID `json:"ID" swaggertype:"primitive,string"`
+	Incoming []*Link  `json:"Incoming"`
+	Outgoing []*Link  `json:"Outgoing"`
+}
+
+// Links reads the references of a transaction or hold, the relation is
+// derived from the reference of the reversing transaction and the
+// reference in the opposite direction
+func (l *Ledger) Links(ctx context.Context, id types.ID) (*Links, error) {
+	links := &Links{
+		ID:       id,
+		Incoming: []*Link{},
+		Outgoing: []*Link{},
+	}
+
+	err := l.client.ScanAll(ctx, index.Reference.Source(id), false, func(ctx context.Context, i int, e *schema.Entry) (bool, error) {
+		if e.ReferencedBy == nil {
+			return true, nil
+		}
+
+		target, err := index.Reference.Target(e.ReferencedBy.Key)
+		if err != nil {
+			return false, NewError(InternalError, "%v", err)
+		}
+
+		// a captured hold is referenced by its debit
+		if strings.HasPrefix(string(e.Key), index.Hold.Prefix()) {
+			hold := &Hold{}
+			err := Unmarshal(e, hold)
+			if err != nil {
+				return false, NewError(InternalError, "failed to parse the hold (%v): %v", err, string(e.Value))
+			}
+
+			links.Incoming = append(links.Incoming, &Link{Relation: CaptureRelation, Source: target, Target: id, Hold: hold})
+			return true, nil
+		}
+
+		tx := &Transaction{}
+		err = tx.Parse(e)
+		if err != nil {
+			return false, NewError(InternalError, "failed to parse the transaction (%v): %v", err, string(e.Value))
+		}
+
+		if relation, source, ok := reversal(tx); ok && source == id {
+			links.Outgoing = append(links.Outgoing, &Link{Relation: relation, Source: id, Target: target, Transaction: tx})
+			return true, nil
+		}
+
+		reverse, err := l.client.Get(ctx, string(index.Reference.Key(target, id)))
+		if err != nil {
+			if strings.Contains(err.Error(), store.ErrKeyNotFound.Error()) {
+				return true, nil
+			}
+
+			return false, NewError(InternalError, "failed to read the reference of %v: %v", target, err)
+		}
+
+		if strings.HasPrefix(string(reverse.Key), index.Hold.Prefix()) {
+			links.Outgoing = append(links.Outgoing, &Link{Relation: CaptureRelation, Source: id, Target: target, Transaction: tx})
+			return true, nil
+		}
+
+		links.Outgoing = append(links.Outgoing, &Link{Relation: TransferRelation, Source: id, Target: target, Transaction: tx})
+		links.Incoming = append(links.Incoming, &Link{Relation: TransferRelation, Source: target, Target: id, Transaction: tx})
+
+		return true, nil
+	})
+
+	if err != nil {
+		if _, ok := err.(Error); ok {
+			return nil, err
+		}
+
+		return nil, NewError(InternalError, "failed to read the links of %v: %v", id, err)
+	}
+
+	tx, err := l.Get(ctx, id)
+	if err != nil {
+		if !strings.Contains(err.Error(), store.ErrKeyNotFound.Error()) {
+			return nil, NewError(InternalError, "cant read transaxtion %v: %v", id, err)
+		}
+
+		if len(links.Incoming) == 0 && len(links.Outgoing) == 0 {
+			return nil, NewError(NotFoundError, "transaction %v not found", id)
+		}
+
+		return links, nil
+	}
+
+	// cancels and refunds are only referenced by the original
+	if relation, source, ok := reversal(tx); ok {
+		_, err := l.client.Get(ctx, string(index.Reference.Key(source, id)))
+		if err != nil {
+			if strings.Contains(err.Error(), store.ErrKeyNotFound.Error()) {
+				return links, nil
+			}
+
+			return nil, NewError(InternalError, "failed to read the reference of %v: %v", source, err)
+		}
+
+		original, err := l.Get(ctx, source)
+		if err != nil {
+			return nil, NewError(InternalError, "cant read transaxtion %v: %v", source, err)
+		}
+
+		links.Incoming = append(links.Incoming, &Link{Relation: relation, Source: source, Target: id, Transaction: original})
+	}
+
+	return links, nil
+}
+
+// reversal reads the original transaction from the reference of a cancel
+// or refund
+func reversal(tx *Transaction) (Relation, types.ID, bool) {
+	if !strings.HasPrefix(tx.Reference, "{") {
+		return "", types.ZeroID, false
+	}
+
+	ref := struct {
+		ID     types.ID
+		Status *types.Status
+		Refund string
+	}{}
+
+	err := json.Unmarshal([]byte(tx.Reference), &ref)
+	if err != nil || ref.ID.IsEmpty() {
+		return "", types.ZeroID, false
+	}
+
+	switch {
+	case ref.Refund != "":
+		return RefundRelation, ref.ID, true
+	case ref.Status != nil && *ref.Status == types.Canceled:
+		return CancelRelation, ref.ID, true
+	}
+
+	return "", types.ZeroID, false
+}
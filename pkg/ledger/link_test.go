@@ -0,0 +1,129 @@
+package ledger_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Links(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	l := ledger.New(client,
+		ledger.SpendPending(),
+		ledger.SupportedAssets(cfg.Assets),
+	)
+
+	asset := randomAsset(assets)
+	holder := randomName()
+	other := holder + "_other"
+
+	links := func(id types.ID) *ledger.Links {
+		links, err := l.Links(ctx, id)
+		if !assert.NoError(t, err) {
+			return &ledger.Links{}
+		}
+		return links
+	}
+
+	tx, ok := add(ctx, t, l, holder, asset, three)
+	if !ok {
+		return
+	}
+
+	assert.Empty(t, links(tx.ID).Incoming)
+	assert.Empty(t, links(tx.ID).Outgoing)
+
+	// refund and cancel
+	refund, err := l.Refund(ctx, tx.ID, one)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	cancel, err := l.Cancel(ctx, holder, asset, tx.Account, tx.ID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	outgoing := links(tx.ID).Outgoing
+	if assert.Len(t, outgoing, 2) {
+		relations := map[types.ID]ledger.Relation{}
+		for _, link := range outgoing {
+			assert.Equal(t, tx.ID, link.Source)
+			relations[link.Target] = link.Relation
+		}
+
+		assert.Equal(t, ledger.RefundRelation, relations[refund.ID])
+		assert.Equal(t, ledger.CancelRelation, relations[cancel.ID])
+	}
+
+	incoming := links(cancel.ID).Incoming
+	if assert.Len(t, incoming, 1) {
+		assert.Equal(t, ledger.CancelRelation, incoming[0].Relation)
+		assert.Equal(t, tx.ID, incoming[0].Source)
+		assert.Equal(t, tx.ID, incoming[0].Transaction.ID)
+	}
+
+	incoming = links(refund.ID).Incoming
+	if assert.Len(t, incoming, 1) {
+		assert.Equal(t, ledger.RefundRelation, incoming[0].Relation)
+	}
+
+	// transfer legs
+	_, ok = add(ctx, t, l, holder, asset, three)
+	if !ok {
+		return
+	}
+
+	debit, credit, err := l.Transfer(ctx, holder, other, asset, one)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	transfer := links(debit.ID)
+	if assert.Len(t, transfer.Outgoing, 1) && assert.Len(t, transfer.Incoming, 1) {
+		assert.Equal(t, ledger.TransferRelation, transfer.Outgoing[0].Relation)
+		assert.Equal(t, credit.ID, transfer.Outgoing[0].Target)
+		assert.Equal(t, credit.ID, transfer.Incoming[0].Source)
+	}
+
+	// capture of a hold
+	hold, err := l.Hold(ctx, holder, asset, one, time.Time{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, captured, err := l.Capture(ctx, hold.ID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	incoming = links(captured.ID).Incoming
+	if assert.Len(t, incoming, 1) && assert.NotNil(t, incoming[0].Hold) {
+		assert.Equal(t, ledger.CaptureRelation, incoming[0].Relation)
+		assert.Equal(t, hold.ID, incoming[0].Source)
+		assert.Equal(t, ledger.Captured, incoming[0].Hold.Status)
+	}
+
+	outgoing = links(hold.ID).Outgoing
+	if assert.Len(t, outgoing, 1) {
+		assert.Equal(t, ledger.CaptureRelation, outgoing[0].Relation)
+		assert.Equal(t, captured.ID, outgoing[0].Target)
+	}
+
+	id, _ := types.NewRandomID()
+	_, err = l.Links(ctx, id)
+	if assert.Error(t, err) {
+		assert.True(t, err.(ledger.Error).IsError(ledger.NotFoundError))
+	}
+}
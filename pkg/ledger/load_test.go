@@ -21,6 +21,7 @@ func Test_Add_Remove_100(t *testing.T) {
 	defer client.Close(ctx)
 
 	l := ledger.New(client,
+		ledger.SpendPending(),
 		ledger.SupportedAssets(cfg.Assets),
 		ledger.MultiAccounts(true),
 		ledger.Format(types.Protobuf),
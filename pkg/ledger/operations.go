@@ -8,7 +8,10 @@ import (
 	"github.com/ec-systems/core.ledger.server/pkg/types"
 )
 
-func (l *Ledger) CancelOperations(tx *Transaction) ([]interface{}, *Transaction, error) {
+// CancelOperations reverses the amount of the transaction which isn't
+// refunded yet, the operations fail if the transaction or its refunds
+// were modified after they were read
+func (l *Ledger) CancelOperations(tx *Transaction, refunds *Refunds) ([]interface{}, *Transaction, error) {
 	id, err := l.NewID()
 	if err != nil {
 		return nil, nil, err
@@ -31,7 +34,15 @@ func (l *Ledger) CancelOperations(tx *Transaction) ([]interface{}, *Transaction,
 	cancel.ID = id
 	cancel.Status = types.Finished
 	cancel.Amount = tx.Amount.Neg()
+
+	if refunds.Amount.IsPositive() {
+		cancel.Amount = tx.Amount.Abs().Sub(refunds.Amount)
+		if tx.Amount.IsPositive() {
+			cancel.Amount = cancel.Amount.Neg()
+		}
+	}
 	cancel.Reference = ref.String()
+	cancel.Journal = ""
 
 	ops := []interface{}{}
 
@@ -52,11 +63,95 @@ func (l *Ledger) CancelOperations(tx *Transaction) ([]interface{}, *Transaction,
 
 	ops = append(ops, op2...)
 
-	ops = append(ops, l.RefOperation(tx, cancel))
+	ops = append(ops,
+		l.RefOperation(tx, cancel),
+		unmodified(tx),
+		refunds.unmodified(),
+	)
 
 	return ops, cancel, nil
 }
 
+func (l *Ledger) TransferOperations(debit *Transaction, credit *Transaction) ([]interface{}, error) {
+	if debit.Asset != credit.Asset {
+		return nil, NewError(BadRequestError, "asset mismatch in transfer: %v != %v", debit.Asset, credit.Asset)
+	}
+
+	if !debit.Amount.Neg().Equal(credit.Amount) {
+		return nil, NewError(BadRequestError, "amount mismatch in transfer: %v != %v", debit.Amount.Neg(), credit.Amount)
+	}
+
+	ops := []interface{}{}
+
+	op1, key, err := l.CreateOperations(debit)
+	if err != nil {
+		return nil, err
+	}
+
+	ops = append(ops, op1...)
+	debit.key = key
+
+	op2, key, err := l.CreateOperations(credit)
+	if err != nil {
+		return nil, err
+	}
+
+	ops = append(ops, op2...)
+	credit.key = key
+
+	ops = unique(ops)
+
+	ops = append(ops,
+		l.RefOperation(debit, credit),
+		l.RefOperation(credit, debit),
+		&schema.Precondition_KeyMustNotExist{
+			KeyMustNotExist: &schema.Precondition_KeyMustNotExistPrecondition{
+				Key: []byte(debit.key),
+			},
+		},
+		&schema.Precondition_KeyMustNotExist{
+			KeyMustNotExist: &schema.Precondition_KeyMustNotExistPrecondition{
+				Key: []byte(credit.key),
+			},
+		},
+	)
+
+	return ops, nil
+}
+
+func (l *Ledger) JournalOperations(journal *Journal) ([]interface{}, error) {
+	ops := []interface{}{}
+	legs := []interface{}{}
+
+	for i, leg := range journal.Legs {
+		op, key, err := l.CreateOperations(leg)
+		if err != nil {
+			return nil, err
+		}
+
+		ops = append(ops, op...)
+		leg.key = key
+
+		legs = append(legs,
+			&schema.Op_ZAdd{
+				ZAdd: &schema.ZAddRequest{
+					Key:      []byte(leg.key),
+					Set:      index.Journal.Key(journal.ID),
+					Score:    float64(i),
+					BoundRef: false,
+				},
+			},
+			&schema.Precondition_KeyMustNotExist{
+				KeyMustNotExist: &schema.Precondition_KeyMustNotExistPrecondition{
+					Key: []byte(leg.key),
+				},
+			},
+		)
+	}
+
+	return append(unique(ops), legs...), nil
+}
+
 func (l *Ledger) RefOperation(src *Transaction, dest *Transaction) *schema.Op_Ref {
 	return &schema.Op_Ref{
 		Ref: &schema.ReferenceRequest{
@@ -79,7 +174,7 @@ func (l *Ledger) UpdateOperations(tx *Transaction) ([]interface{}, string, error
 		return nil, "", NewError(BadRequestError, "checksum check failed for '%v'", tx.Account)
 	}
 
-	if !tx.Asset.Check(l.assets) {
+	if !tx.Asset.Check(l.SupportedAssets()) {
 		return nil, "", NewError(BadRequestError, "invalid asset '%v'", tx.Asset)
 	}
 
@@ -120,7 +215,7 @@ func (l *Ledger) CreateOperations(tx *Transaction) ([]interface{}, string, error
 		return nil, "", NewError(BadRequestError, "checksum check failed for '%v'", tx.Account)
 	}
 
-	if !tx.Asset.Check(l.assets) {
+	if !tx.Asset.Check(l.SupportedAssets()) {
 		return nil, "", NewError(BadRequestError, "invalid asset '%v'", tx.Asset)
 	}
 
@@ -211,7 +306,7 @@ func (l *Ledger) CreateOperations(tx *Transaction) ([]interface{}, string, error
 		},
 	}
 
-	return []interface{}{
+	ops := []interface{}{
 		kv,
 		order,
 		holder,
@@ -220,5 +315,96 @@ func (l *Ledger) CreateOperations(tx *Transaction) ([]interface{}, string, error
 		transaction,
 		assetTx,
 		item,
-	}, string(kv.Kv.Key), nil
+	}
+
+	ops = append(ops, l.ReferenceOperations(tx, kv.Kv.Key)...)
+
+	if tx.since > 0 {
+		ops = append(ops, &schema.Precondition_KeyNotModifiedAfterTX{
+			KeyNotModifiedAfterTX: &schema.Precondition_KeyNotModifiedAfterTXPrecondition{
+				Key:  account.Ref.Key,
+				TxID: tx.since,
+			},
+		})
+	}
+
+	return ops, string(kv.Kv.Key), nil
+}
+
+// ReferenceOperations add the transaction to the set of its normalized
+// reference, credits claim the reference in the unique mode. Cancels and
+// refunds reference the original transaction, they aren't indexed.
+func (l *Ledger) ReferenceOperations(tx *Transaction, key []byte) []interface{} {
+	ref := types.Reference(tx.Reference).Normalized()
+	if ref == "" {
+		return nil
+	}
+
+	if _, _, ok := reversal(tx); ok {
+		return nil
+	}
+
+	ops := []interface{}{
+		&schema.Op_ZAdd{
+			ZAdd: &schema.ZAddRequest{
+				Key:      key,
+				Set:      index.ExternalReference.Key(ref),
+				Score:    float64(tx.Created.Local().UnixMilli()),
+				BoundRef: false,
+			},
+		},
+	}
+
+	if !l.unique || !tx.Amount.IsPositive() {
+		return ops
+	}
+
+	return append(ops,
+		&schema.Op_Ref{
+			Ref: &schema.ReferenceRequest{
+				ReferencedKey: key,
+				Key:           index.UniqueReference.Key(ref),
+				BoundRef:      false,
+			},
+		},
+		&schema.Precondition_KeyMustNotExist{
+			KeyMustNotExist: &schema.Precondition_KeyMustNotExistPrecondition{
+				Key: index.UniqueReference.Key(ref),
+			},
+		},
+	)
+}
+
+// unique drops operations writing a key which is already written by
+// a previous operation, immudb rejects duplicated keys in a batch
+func unique(ops []interface{}) []interface{} {
+	keys := map[string]bool{}
+	result := []interface{}{}
+
+	for _, op := range ops {
+		var key []byte
+
+		switch o := op.(type) {
+		case *schema.Op_Kv:
+			if o != nil {
+				key = o.Kv.Key
+			}
+		case *schema.Op_Ref:
+			if o != nil {
+				key = o.Ref.Key
+			}
+		}
+
+		if key != nil {
+			if keys[string(key)] {
+				continue
+			}
+
+			keys[string(key)] = true
+		}
+
+		result = append(result, op)
+	}
+
+	return result
 }
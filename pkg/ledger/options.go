@@ -1,6 +1,8 @@
 package ledger
 
 import (
+	"time"
+
 	"github.com/ec-systems/core.ledger.server/pkg/types"
 )
 
@@ -50,9 +52,48 @@ func MultiAccounts(value ...bool) LedgerOption {
 	})
 }
 
+func SpendPending(value ...bool) LedgerOption {
+	return LedgerOptionFunc(func(l *Ledger) {
+		if len(value) == 0 {
+			l.pending = true
+		} else {
+			l.pending = value[0]
+		}
+	})
+}
+
+// UniqueReferences rejects a credit if another credit with the same
+// normalized reference exists
+func UniqueReferences(value ...bool) LedgerOption {
+	return LedgerOptionFunc(func(l *Ledger) {
+		if len(value) == 0 {
+			l.unique = true
+		} else {
+			l.unique = value[0]
+		}
+	})
+}
+
+func Retries(value int) LedgerOption {
+	return LedgerOptionFunc(func(l *Ledger) {
+		if value >= 0 {
+			l.retries = value
+		}
+	})
+}
+
+func PollInterval(value time.Duration) LedgerOption {
+	return LedgerOptionFunc(func(l *Ledger) {
+		if value > 0 {
+			l.poll = value
+		}
+	})
+}
+
 func SupportedAssets(assets types.Assets) LedgerOption {
 	return LedgerOptionFunc(func(l *Ledger) {
 		if len(assets) > 0 {
+			l.seed = assets
 			l.assets = assets
 		}
 	})
@@ -66,6 +107,14 @@ func SupportedStatuses(statuses types.Statuses) LedgerOption {
 	})
 }
 
+func StatusTransitions(transitions types.Transitions) LedgerOption {
+	return LedgerOptionFunc(func(l *Ledger) {
+		if transitions != nil {
+			l.transitions = transitions
+		}
+	})
+}
+
 func Collector(collectors ...types.MetricsCollector) LedgerOption {
 	return LedgerOptionFunc(func(l *Ledger) {
 		l.collectors = append(l.collectors, collectors...)
@@ -88,6 +137,24 @@ func Account(account types.Account) TransactionOption {
 	})
 }
 
+// FromAccount sets the account of the debit of a transfer
+func FromAccount(account types.Account) TransactionOption {
+	return TransactionOptionFunc(func(tx *Transaction) {
+		if tx.Amount.IsNegative() {
+			tx.Account = account
+		}
+	})
+}
+
+// ToAccount sets the account of the credit of a transfer
+func ToAccount(account types.Account) TransactionOption {
+	return TransactionOptionFunc(func(tx *Transaction) {
+		if tx.Amount.IsPositive() {
+			tx.Account = account
+		}
+	})
+}
+
 func Reference(ref string) TransactionOption {
 	return TransactionOptionFunc(func(tx *Transaction) {
 		tx.Reference = ref
@@ -100,6 +167,14 @@ func OrderID(id string) TransactionOption {
 	})
 }
 
+// IdempotencyKey makes a retry of Add or Remove with the same key return the
+// original transaction instead of booking it again
+func IdempotencyKey(key string) TransactionOption {
+	return TransactionOptionFunc(func(tx *Transaction) {
+		tx.idempotency = key
+	})
+}
+
 func OrderItemID(id string) TransactionOption {
 	return TransactionOptionFunc(func(tx *Transaction) {
 		tx.Item = id
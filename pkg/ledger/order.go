@@ -0,0 +1,177 @@
+package ledger
+
+import (
+	"context"
+
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/shopspring/decimal"
+)
+
+type OrderStatus string
+
+const (
+	OpenOrder              OrderStatus = "Open"
+	FinishedOrder          OrderStatus = "Finished"
+	CanceledOrder          OrderStatus = "Canceled"
+	PartiallyCanceledOrder OrderStatus = "PartiallyCanceled"
+)
+
+// OrderItem are the transactions of an item of an order, the transactions
+// without an item are collected in the item ""
+type OrderItem struct {
+	Item         string         `json:"Item"`
+	Status       OrderStatus    `json:"Status"`
+	Transactions []*Transaction `json:"Transactions"`
+}
+
+// Order summarizes the transactions of an order, the net amounts include
+// the cancellations and refunds
+type Order struct {
+	Holder string                          `json:"Holder"`
+	Order  string                          `json:"Order"`
+	Status OrderStatus                     `json:"Status"`
+	Net    map[types.Asset]decimal.Decimal `json:"Net"`
+	Items  []*OrderItem                    `json:"Items"`
+}
+
+func NewOrder(holder string, order string) *Order {
+	return &Order{
+		Holder: holder,
+		Order:  order,
+		Status: OpenOrder,
+		Net:    map[types.Asset]decimal.Decimal{},
+		Items:  []*OrderItem{},
+	}
+}
+
+// Add adds a transaction to its item and updates the net amounts and statuses
+func (o *Order) Add(tx *Transaction) {
+	var item *OrderItem
+	for _, i := range o.Items {
+		if i.Item == tx.Item {
+			item = i
+			break
+		}
+	}
+
+	if item == nil {
+		item = &OrderItem{Item: tx.Item}
+		o.Items = append(o.Items, item)
+	}
+
+	item.Transactions = append(item.Transactions, tx)
+	item.Status = item.status()
+
+	o.Net[tx.Asset] = o.Net[tx.Asset].Add(tx.Amount)
+	o.Status = o.status()
+}
+
+// status of an item is derived from the transactions which aren't reversals,
+// an item is canceled if all of them are canceled and open as long as one
+// of them isn't finished
+func (i *OrderItem) status() OrderStatus {
+	txs := []*Transaction{}
+	for _, tx := range i.Transactions {
+		if _, _, ok := reversal(tx); !ok {
+			txs = append(txs, tx)
+		}
+	}
+
+	if len(txs) == 0 {
+		txs = i.Transactions
+	}
+
+	status := CanceledOrder
+	for _, tx := range txs {
+		switch {
+		case canceled(tx):
+		case tx.Status == types.Finished:
+			status = FinishedOrder
+		default:
+			return OpenOrder
+		}
+	}
+
+	return status
+}
+
+func (o *Order) status() OrderStatus {
+	finished, canceled := 0, 0
+	for _, item := range o.Items {
+		switch item.Status {
+		case FinishedOrder:
+			finished++
+		case CanceledOrder:
+			canceled++
+		default:
+			return OpenOrder
+		}
+	}
+
+	switch {
+	case finished == 0 && canceled > 0:
+		return CanceledOrder
+	case finished > 0 && canceled > 0:
+		return PartiallyCanceledOrder
+	case finished > 0:
+		return FinishedOrder
+	default:
+		return OpenOrder
+	}
+}
+
+// Order reads the transactions of an order of a holder, restricted to an
+// item if set
+func (l *Ledger) Order(ctx context.Context, holder string, order string, item string) (*Order, error) {
+	if holder == "" {
+		return nil, NewError(BadRequestError, "holder is mandatory")
+	}
+
+	result := NewOrder(holder, order)
+	err := l.OrderItems(ctx, holder, order, item, func(ctx context.Context, tx *Transaction) (bool, error) {
+		result.Add(tx)
+		return true, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Items) == 0 {
+		if item != "" {
+			return nil, NewError(NotFoundError, "item %v of order %v of holder %v not found", item, order, holder)
+		}
+
+		return nil, NewError(NotFoundError, "order %v of holder %v not found", order, holder)
+	}
+
+	return result, nil
+}
+
+// HolderOrders reads all orders of a holder
+func (l *Ledger) HolderOrders(ctx context.Context, holder string) ([]*Order, error) {
+	ids := []string{}
+	err := l.Orders(ctx, holder, func(ctx context.Context, tx *Transaction) (bool, error) {
+		if tx.Order != "" {
+			ids = append(ids, tx.Order)
+		}
+
+		return true, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	orders := []*Order{}
+	for _, id := range ids {
+		order, err := l.Order(ctx, holder, id, "")
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, order)
+	}
+
+	return orders, nil
+}
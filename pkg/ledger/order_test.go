@@ -0,0 +1,88 @@
+package ledger_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_OrderSummary(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	l := ledger.New(client,
+		ledger.SupportedAssets(cfg.Assets),
+	)
+
+	asset := randomAsset(assets)
+	holder := randomName()
+	order := randomName()
+
+	tx1, ok := add(ctx, t, l, holder, asset, three, ledger.OrderID(order), ledger.OrderItemID("001"))
+	if !ok {
+		return
+	}
+
+	tx2, ok := add(ctx, t, l, holder, asset, two, ledger.OrderID(order), ledger.OrderItemID("002"))
+	if !ok {
+		return
+	}
+
+	// the same order id of another holder
+	_, ok = add(ctx, t, l, holder+"_other", asset, one, ledger.OrderID(order), ledger.OrderItemID("001"))
+	if !ok {
+		return
+	}
+
+	summary, err := l.Order(ctx, holder, order, "")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, ledger.OpenOrder, summary.Status)
+	assert.Len(t, summary.Items, 2)
+	assert.True(t, three.Add(two).Equal(summary.Net[asset]))
+
+	_, err = l.Status(ctx, tx1, types.Finished)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = l.Cancel(ctx, holder, asset, tx2.Account, tx2.ID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	summary, err = l.Order(ctx, holder, order, "")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, ledger.PartiallyCanceledOrder, summary.Status)
+	assert.True(t, three.Equal(summary.Net[asset]))
+
+	summary, err = l.Order(ctx, holder, order, "002")
+	if assert.NoError(t, err) && assert.Len(t, summary.Items, 1) {
+		assert.Equal(t, ledger.CanceledOrder, summary.Status)
+		assert.Len(t, summary.Items[0].Transactions, 2)
+		assert.True(t, summary.Net[asset].IsZero())
+	}
+
+	_, err = l.Order(ctx, holder, order, "003")
+	if assert.Error(t, err) {
+		assert.True(t, err.(ledger.Error).IsError(ledger.NotFoundError))
+	}
+
+	orders, err := l.HolderOrders(ctx, holder)
+	if assert.NoError(t, err) && assert.Len(t, orders, 1) {
+		assert.Equal(t, order, orders[0].Order)
+	}
+}
@@ -0,0 +1,197 @@
+package ledger
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/ec-systems/core.ledger.server/pkg/ledger/index"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+)
+
+// Page selects and filters the transactions of a listing, an empty page
+// returns all transactions
+type Page struct {
+	Limit  int
+	Cursor string
+	Desc   bool
+
+	Status types.Status
+	From   time.Time
+	To     time.Time
+	Order  string
+}
+
+func NewPage() *Page {
+	return &Page{
+		Status: types.AllStatuses,
+	}
+}
+
+// cursor is the position of the last returned transaction, it is passed to
+// the clients as opaque string
+type cursor struct {
+	Account types.Account `json:"A"`
+	Key     []byte        `json:"K"`
+	Score   float64       `json:"S"`
+	AtTx    uint64        `json:"T"`
+}
+
+func (c *cursor) String() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func parseCursor(text string) (*cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(text)
+	if err != nil {
+		return nil, NewError(BadRequestError, "invalid cursor %v", text)
+	}
+
+	c := &cursor{}
+	err = json.Unmarshal(data, c)
+	if err != nil || len(c.Key) == 0 {
+		return nil, NewError(BadRequestError, "invalid cursor %v", text)
+	}
+
+	return c, nil
+}
+
+func (p *Page) match(tx *Transaction) bool {
+	if p.Status != types.AllStatuses && p.Status != tx.Status {
+		return false
+	}
+
+	if p.Order != "" && p.Order != tx.Order {
+		return false
+	}
+
+	return true
+}
+
+func (p *Page) scores() (*schema.Score, *schema.Score) {
+	var min, max *schema.Score
+
+	if !p.From.IsZero() {
+		min = &schema.Score{Score: float64(p.From.Local().UnixMilli())}
+	}
+
+	if !p.To.IsZero() {
+		max = &schema.Score{Score: float64(p.To.Local().UnixMilli())}
+	}
+
+	return min, max
+}
+
+// TransactionsPage lists the transactions of the page, it returns the cursor
+// of the next page or an empty string if the listing is complete
+func (l *Ledger) TransactionsPage(ctx context.Context, holder string, asset types.Asset, account types.Account, page *Page, f func(context.Context, *Transaction) (bool, error)) (string, error) {
+	if holder == "" {
+		return "", NewError(BadRequestError, "holder is mandatory")
+	}
+
+	if page == nil {
+		page = NewPage()
+	}
+
+	var seek *cursor
+	if page.Cursor != "" {
+		c, err := parseCursor(page.Cursor)
+		if err != nil {
+			return "", err
+		}
+
+		seek = c
+	}
+
+	var accounts []types.Account
+	if account.Empty() {
+		acc, err := l.Accounts(ctx, holder, asset)
+		if err != nil {
+			return "", err
+		}
+
+		accounts = acc
+	} else {
+		accounts = []types.Account{account}
+	}
+
+	if seek != nil {
+		found := false
+		for i, a := range accounts {
+			if a == seek.Account {
+				accounts = accounts[i:]
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return "", NewError(BadRequestError, "cursor doesn't match the account %v", seek.Account)
+		}
+	}
+
+	min, max := page.scores()
+	count := 0
+	next := ""
+
+	for _, a := range accounts {
+		var last *schema.ZEntry
+		if seek != nil && seek.Account == a {
+			last = &schema.ZEntry{
+				Key:   seek.Key,
+				Score: seek.Score,
+				AtTx:  seek.AtTx,
+			}
+		}
+
+		done := false
+
+		err := l.client.ScanSetRange(ctx, index.Transaction.Scan(a), page.Desc, min, max, last, func(ctx context.Context, e *schema.ZEntry) (bool, error) {
+			tx := &Transaction{}
+			err := tx.Parse(e.Entry)
+			if err != nil {
+				return false, NewError(InternalError, "failed to parse the transaction (%v): %v", err, string(e.Entry.Value))
+			}
+
+			if tx.Holder != holder {
+				return false, NewError(BadRequestError, "invalid holder %v in tx %v (%v)", tx.Holder, tx.ID, holder)
+			}
+
+			if asset != types.AllAssets && asset != tx.Asset {
+				return false, NewError(BadRequestError, "invalid asset %v in tx %v (%v)", tx.Asset, tx.ID, asset)
+			}
+
+			if !page.match(tx) {
+				return true, nil
+			}
+
+			ok, err := f(ctx, tx)
+			if err != nil || !ok {
+				done = true
+				return false, err
+			}
+
+			count++
+			if page.Limit > 0 && count >= page.Limit {
+				next = (&cursor{Account: a, Key: e.Key, Score: e.Score, AtTx: e.AtTx}).String()
+				done = true
+				return false, nil
+			}
+
+			return true, nil
+		})
+
+		if err != nil {
+			return "", err
+		}
+
+		if done {
+			break
+		}
+	}
+
+	return next, nil
+}
@@ -0,0 +1,105 @@
+package ledger_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TransactionsPage(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	l := ledger.New(client,
+		ledger.SupportedAssets(cfg.Assets),
+	)
+
+	asset := randomAsset(assets)
+	holder := randomName()
+
+	txs := []*ledger.Transaction{}
+	for i := 0; i < 5; i++ {
+		options := []ledger.TransactionOption{}
+		if i%2 == 0 {
+			options = append(options, ledger.OrderID("even"))
+		}
+
+		tx, ok := add(ctx, t, l, holder, asset, one, options...)
+		if !ok {
+			return
+		}
+
+		txs = append(txs, tx)
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	_, err = l.Status(ctx, txs[1], types.Finished)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	list := func(page *ledger.Page) ([]types.ID, string) {
+		ids := []types.ID{}
+		next, err := l.TransactionsPage(ctx, holder, asset, types.AllAccounts, page, func(ctx context.Context, tx *ledger.Transaction) (bool, error) {
+			ids = append(ids, tx.ID)
+			return true, nil
+		})
+		assert.NoError(t, err)
+		return ids, next
+	}
+
+	page := ledger.NewPage()
+	page.Limit = 2
+
+	ids, next := list(page)
+	assert.Equal(t, []types.ID{txs[0].ID, txs[1].ID}, ids)
+	assert.NotEmpty(t, next)
+
+	page.Cursor = next
+	ids, next = list(page)
+	assert.Equal(t, []types.ID{txs[2].ID, txs[3].ID}, ids)
+
+	page.Cursor = next
+	ids, _ = list(page)
+	assert.Equal(t, []types.ID{txs[4].ID}, ids)
+
+	page = ledger.NewPage()
+	page.Desc = true
+	page.Limit = 3
+	ids, _ = list(page)
+	assert.Equal(t, []types.ID{txs[4].ID, txs[3].ID, txs[2].ID}, ids)
+
+	page = ledger.NewPage()
+	page.Status = types.Finished
+	ids, _ = list(page)
+	assert.Equal(t, []types.ID{txs[1].ID}, ids)
+
+	page = ledger.NewPage()
+	page.Order = "even"
+	ids, _ = list(page)
+	assert.Equal(t, []types.ID{txs[0].ID, txs[2].ID, txs[4].ID}, ids)
+
+	page = ledger.NewPage()
+	page.From = *txs[1].Created
+	page.To = *txs[3].Created
+	ids, _ = list(page)
+	assert.Equal(t, []types.ID{txs[1].ID, txs[2].ID, txs[3].ID}, ids)
+
+	page = ledger.NewPage()
+	page.Cursor = "invalid"
+	_, err = l.TransactionsPage(ctx, holder, asset, types.AllAccounts, page, func(ctx context.Context, tx *ledger.Transaction) (bool, error) {
+		return true, nil
+	})
+	if assert.Error(t, err) {
+		assert.True(t, err.(ledger.Error).IsError(ledger.BadRequestError))
+	}
+}
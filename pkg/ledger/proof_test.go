@@ -0,0 +1,138 @@
+package ledger_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/signer"
+	"github.com/ec-systems/core.ledger.server/pkg/client"
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Proof(t *testing.T) {
+	ctx := context.Background()
+	cl := client.FromStorage(store, client.Limit(5), client.Verified())
+
+	l := ledger.New(cl,
+		ledger.SupportedAssets(cfg.Assets),
+	)
+
+	asset := randomAsset(assets)
+	holder := randomName()
+
+	tx1, ok := add(ctx, t, l, holder, asset, one)
+	if !ok {
+		return
+	}
+
+	tx2, ok := add(ctx, t, l, holder, asset, two)
+	if !ok {
+		return
+	}
+
+	tx, proof, err := l.Proof(ctx, tx2.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, tx2.ID, tx.ID)
+		assert.True(t, proof.Verified)
+		assert.Equal(t, proof.Tx, proof.TargetTx)
+	}
+
+	tx, proof, err = l.Proof(ctx, tx1.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, tx1.ID, tx.ID)
+		assert.True(t, proof.Verified)
+		assert.Equal(t, proof.Tx, proof.SourceTx)
+		assert.Less(t, proof.SourceTx, proof.TargetTx)
+	}
+
+	state, err := cl.TrustedState()
+	if assert.NoError(t, err) {
+		assert.Equal(t, proof.TargetTx, state.TxId)
+	}
+
+	txs := []*ledger.Transaction{}
+	err = l.Transactions(ctx, holder, asset, types.AllAccounts, func(ctx context.Context, tx *ledger.Transaction) (bool, error) {
+		txs = append(txs, tx)
+		return true, nil
+	})
+	if assert.NoError(t, err) {
+		assert.Len(t, txs, 2)
+	}
+
+	id, _ := types.NewRandomID()
+	_, _, err = l.Proof(ctx, id)
+	if assert.Error(t, err) {
+		assert.True(t, err.(ledger.Error).IsError(ledger.NotFoundError))
+	}
+}
+
+func Test_Bundle(t *testing.T) {
+	ctx := context.Background()
+	cl := client.FromStorage(store, client.Limit(5))
+
+	l := ledger.New(cl,
+		ledger.SupportedAssets(cfg.Assets),
+	)
+
+	asset := randomAsset(assets)
+	holder := randomName()
+
+	tx1, ok := add(ctx, t, l, holder, asset, one)
+	if !ok {
+		return
+	}
+
+	_, ok = add(ctx, t, l, holder, asset, two)
+	if !ok {
+		return
+	}
+
+	b, err := l.Bundle(ctx, tx1.ID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	sig, pub, err := signer.NewSignerFromPKey(rand.Reader, pk).Sign(b.State.ToBytes())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	b.State.Signature = &schema.Signature{Signature: sig, PublicKey: pub}
+
+	buf := &bytes.Buffer{}
+	err = b.Write(buf)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	b, err = client.ReadBundle(buf)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NoError(t, b.Verify(&pk.PublicKey))
+
+	tx := &ledger.Transaction{}
+	if assert.NoError(t, tx.Parse(b.Entry)) {
+		assert.Equal(t, tx1.ID, tx.ID)
+	}
+
+	other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Error(t, b.Verify(&other.PublicKey))
+
+	b.Entry.Value = append([]byte{}, b.Entry.Value...)
+	b.Entry.Value[len(b.Entry.Value)-1] ^= 0xff
+	assert.Error(t, b.Verify(nil))
+}
@@ -26,6 +26,7 @@ func (ProtobufSerializer) Marshal(v interface{}, version uint16) ([]byte, error)
 			Holder:    o.Holder,
 			Order:     o.Order,
 			Item:      o.Item,
+			Journal:   o.Journal,
 			Asset:     o.Asset.String(),
 			Amount:    o.Amount.String(),
 			Status:    int64(o.Status),
@@ -93,6 +94,7 @@ func (ProtobufSerializer) Unmarshal(data []byte, v interface{}, version uint16)
 		o.Holder = tx.Holder
 		o.Order = tx.Order
 		o.Item = tx.Item
+		o.Journal = tx.Journal
 		o.Asset = types.Asset(tx.Asset)
 		o.Amount, _ = decimal.NewFromString(tx.Amount)
 
@@ -0,0 +1,46 @@
+package ledger
+
+import (
+	"context"
+	"strings"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/ec-systems/core.ledger.server/pkg/ledger/index"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+)
+
+// FindReference lists all transactions of all holders with the same
+// normalized reference
+func (l *Ledger) FindReference(ctx context.Context, ref string, f func(context.Context, *Transaction) (bool, error)) error {
+	normalized := types.Reference(ref).Normalized()
+	if normalized == "" {
+		return NewError(BadRequestError, "reference is empty")
+	}
+
+	return l.ForEachInSet(ctx, string(index.ExternalReference.Key(normalized)), false, f)
+}
+
+// booked fails if a credit with the reference exists
+func (l *Ledger) booked(ctx context.Context, ref string) error {
+	normalized := types.Reference(ref).Normalized()
+	if normalized == "" {
+		return nil
+	}
+
+	entry, err := l.client.Get(ctx, string(index.UniqueReference.Key(normalized)))
+	if err != nil {
+		if strings.Contains(err.Error(), store.ErrKeyNotFound.Error()) {
+			return nil
+		}
+
+		return NewError(InternalError, "failed to read reference %v: %v", ref, err)
+	}
+
+	tx := &Transaction{}
+	err = tx.Parse(entry)
+	if err != nil {
+		return NewError(InternalError, "failed to parse the transaction of reference %v: %v", ref, err)
+	}
+
+	return NewError(ConflictError, "reference %v is already booked by transaction %v", ref, tx.ID)
+}
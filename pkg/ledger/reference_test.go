@@ -0,0 +1,150 @@
+package ledger_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FindReference(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	l := ledger.New(client,
+		ledger.SupportedAssets(cfg.Assets),
+	)
+
+	asset := randomAsset(assets)
+	holder := randomName()
+	hash := "0x" + randomName() + "ABC"
+
+	tx1, ok := add(ctx, t, l, holder, asset, three, ledger.Reference(hash))
+	if !ok {
+		return
+	}
+
+	tx2, ok := add(ctx, t, l, holder+"_other", asset, one, ledger.Reference(" "+hash+" "))
+	if !ok {
+		return
+	}
+
+	_, ok = add(ctx, t, l, holder, asset, one, ledger.Reference(hash+"_other"))
+	if !ok {
+		return
+	}
+
+	ids := []types.ID{}
+	err = l.FindReference(ctx, types.Reference(hash).Normalized(), func(ctx context.Context, tx *ledger.Transaction) (bool, error) {
+		ids = append(ids, tx.ID)
+		return true, nil
+	})
+
+	if assert.NoError(t, err) {
+		assert.ElementsMatch(t, []types.ID{tx1.ID, tx2.ID}, ids)
+	}
+
+	// the internal references of cancels aren't indexed
+	cancel, err := l.Cancel(ctx, tx2.Holder, asset, tx2.Account, tx2.ID)
+	if assert.NoError(t, err) {
+		found := false
+		err = l.FindReference(ctx, types.Reference(cancel.Reference).Normalized(), func(ctx context.Context, tx *ledger.Transaction) (bool, error) {
+			found = true
+			return true, nil
+		})
+
+		assert.NoError(t, err)
+		assert.False(t, found)
+	}
+
+	err = l.FindReference(ctx, "  ", func(ctx context.Context, tx *ledger.Transaction) (bool, error) {
+		return true, nil
+	})
+	assert.Error(t, err)
+}
+
+func Test_UniqueReferences(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	l := ledger.New(client,
+		ledger.SpendPending(),
+		ledger.SupportedAssets(cfg.Assets),
+		ledger.UniqueReferences(),
+		ledger.Retries(50),
+	)
+
+	asset := randomAsset(assets)
+	holder := randomName()
+	hash := "0x" + randomName() + "unique"
+
+	conflict := func(err error) {
+		if assert.Error(t, err) {
+			assert.True(t, err.(ledger.Error).IsError(ledger.ConflictError), err.Error())
+		}
+	}
+
+	_, ok := add(ctx, t, l, holder, asset, three, ledger.Reference(hash))
+	if !ok {
+		return
+	}
+
+	_, err = l.Add(ctx, holder, asset, one, ledger.Reference(hash))
+	conflict(err)
+
+	_, err = l.Add(ctx, holder+"_other", asset, one, ledger.Reference(types.Reference(hash).Normalized()))
+	conflict(err)
+
+	_, _, err = l.Transfer(ctx, holder, holder+"_other", asset, one, ledger.Reference(hash))
+	conflict(err)
+
+	// debits and their reversals are not unique
+	debit, err := l.Remove(ctx, holder, asset, one, ledger.Reference(hash))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = l.Cancel(ctx, holder, asset, debit.Account, debit.ID)
+	assert.NoError(t, err)
+
+	// only one of concurrent credits is booked
+	other := hash + "_concurrent"
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	booked := 0
+
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := l.Add(ctx, holder, asset, one, ledger.Reference(other))
+			if err == nil {
+				mutex.Lock()
+				booked++
+				mutex.Unlock()
+				return
+			}
+
+			conflict(err)
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 1, booked)
+}
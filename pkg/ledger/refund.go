@@ -0,0 +1,236 @@
+package ledger
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/ec-systems/core.ledger.server/pkg/ledger/index"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/shopspring/decimal"
+)
+
+// Refunds is the refunded total of a transaction
+type Refunds struct {
+	tx uint64
+
+	ID       types.ID        `json:"ID" swaggertype:"primitive,string"`
+	Amount   decimal.Decimal `json:"Amount"`
+	Modified *time.Time      `json:"Modified,omitempty"`
+}
+
+// Refund reverses a part of a transaction, the refunded total of a
+// transaction can't exceed its amount
+func (l *Ledger) Refund(ctx context.Context, transaction types.ID, amount decimal.Decimal) (*Transaction, error) {
+	if l.readOnly {
+		return nil, NewError(NotFoundError, "read-only instance")
+	}
+
+	if !amount.IsPositive() {
+		return nil, NewError(InvalidAmountError, "refund amount %v of transaction %v is not positive", amount, transaction)
+	}
+
+	var refund *Transaction
+
+	err := l.retry(ctx, func() error {
+		tx, err := l.Get(ctx, transaction)
+		if err != nil {
+			if strings.Contains(err.Error(), store.ErrKeyNotFound.Error()) {
+				return NewError(NotFoundError, "transaction %v not found", transaction)
+			}
+
+			return NewError(InternalError, "cant read transaxtion %v: %v", transaction, err)
+		}
+
+		if relation, _, ok := reversal(tx); ok {
+			return NewError(BadRequestError, "transaction %v is a %v and can't be refunded", tx.ID, relation)
+		}
+
+		if definition, ok := l.SupportedAssets()[tx.Asset]; ok {
+			if err := definition.Validate(amount); err != nil {
+				return NewError(InvalidAmountError, "invalid refund of transaction %v: %v", tx.ID, err)
+			}
+		}
+
+		if canceled(tx) {
+			return NewError(ConflictError, "transaction %v is canceled", tx.ID)
+		}
+
+		refunds, err := l.Refunded(ctx, tx.ID)
+		if err != nil {
+			return err
+		}
+
+		remaining := tx.Amount.Abs().Sub(refunds.Amount)
+		if amount.GreaterThan(remaining) {
+			return NewError(InvalidAmountError, "refund of %v exceeds the remaining amount %v of transaction %v", amount, remaining, tx.ID)
+		}
+
+		// the refund of a credit is a debit of the holder
+		var since uint64
+		if tx.Amount.IsPositive() && !l.overdraw {
+			since, err = l.covered(ctx, tx.Account, amount)
+			if err != nil {
+				return err
+			}
+		}
+
+		tx.User = UserFrom(ctx)
+
+		ops, r, err := l.RefundOperations(tx, refunds, amount)
+		if err != nil {
+			return err
+		}
+
+		r.since = since
+
+		balances, err := l.balanceOperations(ctx, []*Transaction{r}, nil, types.Unknown, nil)
+		if err != nil {
+			return err
+		}
+
+		r.tx, err = l.client.Exec(ctx, append(ops, balances...)...)
+		if err != nil {
+			return err
+		}
+
+		refund = r
+		return nil
+	})
+
+	if err != nil {
+		if _, ok := err.(Error); ok {
+			return nil, err
+		}
+
+		return nil, NewError(InternalError, "refund of transaction %v failed: %v", transaction, err)
+	}
+
+	for _, c := range l.collectors {
+		c.Add(refund.Asset, refund.Amount)
+	}
+
+	return refund, nil
+}
+
+// Refunded reads the refunded total of a transaction, it is zero if the
+// transaction was never refunded
+func (l *Ledger) Refunded(ctx context.Context, transaction types.ID) (*Refunds, error) {
+	entry, err := l.client.Get(ctx, string(index.Refund.Key(transaction)))
+	if err != nil {
+		if strings.Contains(err.Error(), store.ErrKeyNotFound.Error()) {
+			return &Refunds{ID: transaction}, nil
+		}
+
+		return nil, NewError(InternalError, "failed to read the refunds of transaction %v: %v", transaction, err)
+	}
+
+	refunds := &Refunds{}
+	err = Unmarshal(entry, refunds)
+	if err != nil {
+		return nil, NewError(InternalError, "failed to parse the refunds of transaction %v: %v", transaction, err)
+	}
+
+	refunds.tx = entry.Tx
+
+	return refunds, nil
+}
+
+func (l *Ledger) RefundOperations(tx *Transaction, refunds *Refunds, amount decimal.Decimal) ([]interface{}, *Transaction, error) {
+	id, err := l.NewID()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ref, err := types.NewReference(
+		struct {
+			ID     string
+			Refund string
+		}{
+			tx.ID.String(),
+			amount.String(),
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	refund := tx.Copy()
+	refund.ID = id
+	refund.Status = types.Finished
+	refund.Amount = amount
+	refund.Reference = ref.String()
+	refund.Journal = ""
+
+	if tx.Amount.IsPositive() {
+		refund.Amount = amount.Neg()
+	}
+
+	ops, key, err := l.CreateOperations(refund)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	refund.key = key
+
+	now := time.Now()
+	refunds.Amount = refunds.Amount.Add(amount)
+	refunds.Modified = &now
+
+	data, err := Marshal(refunds, types.JSON, Version)
+	if err != nil {
+		return nil, nil, NewError(InternalError, "marshal refunds failed: %v", err)
+	}
+
+	ops = append(ops,
+		&schema.Op_Kv{
+			Kv: &schema.KeyValue{
+				Key:   index.Refund.Key(tx.ID),
+				Value: data,
+			},
+		},
+		l.RefOperation(tx, refund),
+		unmodified(tx),
+		refunds.unmodified(),
+	)
+
+	return ops, refund, nil
+}
+
+// unmodified is the precondition of the refunded total, it fails if the
+// transaction was refunded concurrently
+func (r *Refunds) unmodified() interface{} {
+	key := index.Refund.Key(r.ID)
+
+	if r.tx == 0 {
+		return &schema.Precondition_KeyMustNotExist{
+			KeyMustNotExist: &schema.Precondition_KeyMustNotExistPrecondition{
+				Key: key,
+			},
+		}
+	}
+
+	return &schema.Precondition_KeyNotModifiedAfterTX{
+		KeyNotModifiedAfterTX: &schema.Precondition_KeyNotModifiedAfterTXPrecondition{
+			Key:  key,
+			TxID: r.tx,
+		},
+	}
+}
+
+// unmodified is the precondition of a transaction, it fails if the
+// transaction was changed after it was read
+func unmodified(tx *Transaction) interface{} {
+	return &schema.Precondition_KeyNotModifiedAfterTX{
+		KeyNotModifiedAfterTX: &schema.Precondition_KeyNotModifiedAfterTXPrecondition{
+			Key:  index.Key.Key(tx.ID),
+			TxID: tx.tx,
+		},
+	}
+}
+
+func canceled(tx *Transaction) bool {
+	return tx.Status == types.Canceled || tx.Status == types.CancellationFinished
+}
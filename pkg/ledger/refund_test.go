@@ -0,0 +1,272 @@
+package ledger_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DoubleCancel(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	l := ledger.New(client,
+		ledger.SupportedAssets(cfg.Assets),
+		ledger.Retries(50),
+	)
+
+	asset := randomAsset(assets)
+	holder := randomName()
+
+	tx, ok := add(ctx, t, l, holder, asset, three)
+	if !ok {
+		return
+	}
+
+	// each part of the combination is checked
+	_, err = l.Cancel(ctx, holder+"_other", asset, tx.Account, tx.ID)
+	assert.Error(t, err)
+
+	_, err = l.Cancel(ctx, holder, types.Asset("OTHER"), tx.Account, tx.ID)
+	assert.Error(t, err)
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	canceled := 0
+
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := l.Cancel(ctx, holder, asset, tx.Account, tx.ID)
+			if err == nil {
+				mutex.Lock()
+				canceled++
+				mutex.Unlock()
+				return
+			}
+
+			e, ok := err.(ledger.Error)
+			if assert.True(t, ok, err.Error()) {
+				assert.True(t, e.IsError(ledger.ConflictError), err.Error())
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 1, canceled)
+
+	_, err = l.Cancel(ctx, holder, asset, tx.Account, tx.ID)
+	if assert.Error(t, err) {
+		assert.True(t, err.(ledger.Error).IsError(ledger.ConflictError))
+	}
+
+	b, err := l.Balance(ctx, holder, asset, types.AllAccounts, types.AllStatuses)
+	if assert.NoError(t, err) && assert.Contains(t, b, asset) {
+		assert.True(t, b[asset].Sum.IsZero(), b[asset].Sum.String())
+	}
+}
+
+func Test_Refund(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	l := ledger.New(client,
+		ledger.SpendPending(),
+		ledger.SupportedAssets(cfg.Assets),
+	)
+
+	asset := randomAsset(assets)
+	holder := randomName()
+
+	balance := func() string {
+		b, err := l.Balance(ctx, holder, asset, types.AllAccounts, types.AllStatuses)
+		if !assert.NoError(t, err) || !assert.Contains(t, b, asset) {
+			return ""
+		}
+		return b[asset].Sum.String()
+	}
+
+	tx, ok := add(ctx, t, l, holder, asset, three)
+	if !ok {
+		return
+	}
+
+	_, err = l.Refund(ctx, tx.ID, decimal.Zero)
+	assert.Error(t, err)
+
+	refund, err := l.Refund(ctx, tx.ID, one)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, one.Neg().String(), refund.Amount.String())
+	assert.Equal(t, types.Finished, refund.Status)
+	assert.Equal(t, two.String(), balance())
+
+	_, err = l.Refund(ctx, refund.ID, one)
+	if assert.Error(t, err) {
+		assert.True(t, err.(ledger.Error).IsError(ledger.BadRequestError), err.Error())
+	}
+
+	_, err = l.Refund(ctx, tx.ID, three)
+	if assert.Error(t, err) {
+		assert.True(t, err.(ledger.Error).IsError(ledger.InvalidAmountError))
+	}
+
+	refunds, err := l.Refunded(ctx, tx.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, one.String(), refunds.Amount.String())
+	}
+
+	// the cancel reverses the rest
+	cancel, err := l.Cancel(ctx, holder, asset, tx.Account, tx.ID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, two.Neg().String(), cancel.Amount.String())
+	assert.Equal(t, "0", balance())
+
+	_, err = l.Refund(ctx, tx.ID, one)
+	if assert.Error(t, err) {
+		assert.True(t, err.(ledger.Error).IsError(ledger.ConflictError))
+	}
+
+	// a debit is refunded with credits
+	_, ok = add(ctx, t, l, holder, asset, three)
+	if !ok {
+		return
+	}
+
+	debit, err := l.Remove(ctx, holder, asset, two)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	refund, err = l.Refund(ctx, debit.ID, two)
+	if assert.NoError(t, err) {
+		assert.Equal(t, two.String(), refund.Amount.String())
+	}
+
+	_, err = l.Cancel(ctx, debit.Holder, asset, debit.Account, debit.ID)
+	if assert.Error(t, err) {
+		assert.True(t, err.(ledger.Error).IsError(ledger.ConflictError))
+	}
+}
+
+func Test_RefundSpent(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	l := ledger.New(client,
+		ledger.SpendPending(),
+		ledger.SupportedAssets(cfg.Assets),
+		ledger.Overdraw(false),
+	)
+
+	asset := randomAsset(assets)
+	holder := randomName()
+
+	tx, ok := add(ctx, t, l, holder, asset, three)
+	if !ok {
+		return
+	}
+
+	_, err = l.Remove(ctx, holder, asset, two)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// the refund of a credit is a debit of the remaining balance
+	_, err = l.Refund(ctx, tx.ID, two)
+	if assert.Error(t, err) {
+		assert.True(t, err.(ledger.Error).IsError(ledger.NotEnoughAssetsError), err.Error())
+	}
+
+	_, err = l.Refund(ctx, tx.ID, one)
+	assert.NoError(t, err)
+
+	b, err := l.Balance(ctx, holder, asset, types.AllAccounts, types.AllStatuses)
+	if assert.NoError(t, err) && assert.Contains(t, b, asset) {
+		assert.Equal(t, "0", b[asset].Sum.String())
+	}
+}
+
+func Test_CancelSpent(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	l := ledger.New(client,
+		ledger.SupportedAssets(cfg.Assets),
+		ledger.Overdraw(false),
+	)
+
+	asset := randomAsset(assets)
+	holder := randomName()
+
+	tx1, ok := add(ctx, t, l, holder, asset, three)
+	if !ok {
+		return
+	}
+
+	tx1, err = l.Status(ctx, tx1, types.Finished)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// a pending credit isn't spendable, its cancel doesn't need a balance
+	tx2, ok := add(ctx, t, l, holder, asset, two)
+	if !ok {
+		return
+	}
+
+	_, err = l.Remove(ctx, holder, asset, two)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// the cancel of a credit is a debit of the remaining balance
+	_, err = l.Cancel(ctx, holder, asset, tx1.Account, tx1.ID)
+	if assert.Error(t, err) {
+		assert.True(t, err.(ledger.Error).IsError(ledger.NotEnoughAssetsError), err.Error())
+	}
+
+	_, err = l.Cancel(ctx, holder, asset, tx2.Account, tx2.ID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	b, err := l.Balance(ctx, holder, asset, types.AllAccounts, types.AllStatuses)
+	if assert.NoError(t, err) && assert.Contains(t, b, asset) {
+		assert.Equal(t, one.String(), b[asset].Sum.String())
+		assert.Equal(t, one.String(), b[asset].Available.String())
+	}
+}
@@ -35,6 +35,7 @@ const (
 
 var (
 	holder = randomName()
+	store  client.Storage
 
 	zero  = decimal.Zero
 	one   = decimal.NewFromInt(1)
@@ -85,47 +86,21 @@ func TestMain(m *testing.M) {
 	rand.Seed(time.Now().UTC().UnixNano())
 
 	ctx := context.Background()
-	var cl *client.Client
-	var err error
 
-	cl, err = client.New(ctx, cfg.ClientOptions.Username, cfg.ClientOptions.Password, "defaultdb",
-		client.ClientOptions(cfg.ClientOptions),
-		client.Limit(5),
-	)
-
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	exists, err := cl.DatabaseExist(ctx, CLIENT_OPTIONS_DATABASE)
+	dir, err := os.MkdirTemp("", "ledger")
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	if exists {
-		log.Printf("Delete test database: %v", CLIENT_OPTIONS_DATABASE)
-
-		err := cl.UnloadDatabase(ctx, CLIENT_OPTIONS_DATABASE)
-		if err != nil {
-			log.Fatal(err)
-		}
-
-		err = cl.DeleteDatabase(ctx, CLIENT_OPTIONS_DATABASE)
-		if err != nil {
-			log.Fatal(err)
-		}
-	}
-
-	log.Printf("Create test database: %v", CLIENT_OPTIONS_DATABASE)
-
-	err = cl.CreateDatabase(ctx, CLIENT_OPTIONS_DATABASE)
+	store, err = client.OpenEmbedded(dir, CLIENT_OPTIONS_DATABASE)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	code := m.Run()
 
-	cl.Close(ctx)
+	store.Close(ctx)
+	os.RemoveAll(dir)
 
 	os.Exit(code)
 }
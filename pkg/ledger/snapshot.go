@@ -0,0 +1,441 @@
+package ledger
+
+import (
+	"context"
+	"strings"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/ec-systems/core.ledger.server/pkg/ledger/index"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+)
+
+// Snapshot is the running balance of an account or an asset, it is
+// written in the same immudb transaction as the ledger transactions
+type Snapshot struct {
+	tx  uint64
+	key string
+
+	Holder  string        `json:"Holder,omitempty"`
+	Account types.Account `json:"Account,omitempty"`
+	Asset   types.Asset   `json:"Asset"`
+
+	types.AccountBalance
+}
+
+func NewSnapshot(key string) *Snapshot {
+	return &Snapshot{
+		key:            key,
+		AccountBalance: *types.NewAccountBalance(),
+	}
+}
+
+type BalanceDrift struct {
+	Stored   *Snapshot
+	Computed *Snapshot
+}
+
+func (s *Snapshot) SetTX(tx uint64) {
+	s.tx = tx
+}
+
+func (s *Snapshot) TX() uint64 {
+	return s.tx
+}
+
+func (s *Snapshot) SetKey(key string) {
+	s.key = key
+}
+
+func (s *Snapshot) Key() string {
+	return s.key
+}
+
+func (s *Snapshot) Equal(o *Snapshot) bool {
+	return o != nil && s.AccountBalance.Equal(&o.AccountBalance)
+}
+
+func (s *Snapshot) add(tx *Transaction) {
+	s.AccountBalance.Add(tx.Amount, tx.Status)
+}
+
+func (s *Snapshot) hold(h *Hold) {
+	if h.Status == Held {
+		s.AccountBalance.Hold(h.Amount)
+	} else {
+		s.AccountBalance.Release(h.Amount)
+	}
+}
+
+// held adds the active holds of an account
+func (l *Ledger) held(ctx context.Context, s *Snapshot, account types.Account) error {
+	return l.ForEachHold(ctx, account, func(ctx context.Context, h *Hold) (bool, error) {
+		if h.Status == Held {
+			s.hold(h)
+		}
+		return true, nil
+	})
+}
+
+func (l *Ledger) AccountSnapshot(ctx context.Context, account types.Account) (*Snapshot, error) {
+	key := string(index.AccountBalance.Key(account))
+
+	s, err := l.snapshot(ctx, key)
+	if err != nil || s != nil {
+		return s, err
+	}
+
+	s = NewSnapshot(key)
+	s.Account = account
+
+	err = l.ForEachInSet(ctx, index.Transaction.Scan(account), false, func(ctx context.Context, tx *Transaction) (bool, error) {
+		s.Holder = tx.Holder
+		s.Asset = tx.Asset
+		s.add(tx)
+		return true, nil
+	})
+
+	if err == nil {
+		err = l.held(ctx, s, account)
+	}
+
+	if err != nil {
+		return nil, NewError(InternalError, "failed to compute balance of account %v: %v", account, err)
+	}
+
+	return s, nil
+}
+
+// AssetSnapshot reads the stored balance of an asset, it is only computed
+// from the transactions of the asset if no snapshot was written yet
+func (l *Ledger) AssetSnapshot(ctx context.Context, asset types.Asset) (*Snapshot, error) {
+	key := string(index.AssetBalance.Key(asset))
+
+	s, err := l.snapshot(ctx, key)
+	if err != nil || s != nil {
+		return s, err
+	}
+
+	s = NewSnapshot(key)
+	s.Asset = asset
+
+	err = l.ForEachInSet(ctx, string(index.AssetTx.Key(asset)), false, func(ctx context.Context, tx *Transaction) (bool, error) {
+		s.add(tx)
+		return true, nil
+	})
+
+	if err != nil {
+		return nil, NewError(InternalError, "failed to compute balance of asset %v: %v", asset, err)
+	}
+
+	return s, nil
+}
+
+// snapshot reads a stored snapshot, it returns nil if no snapshot was written yet
+func (l *Ledger) snapshot(ctx context.Context, key string) (*Snapshot, error) {
+	entry, err := l.client.Get(ctx, key)
+	if err != nil {
+		if strings.Contains(err.Error(), store.ErrKeyNotFound.Error()) {
+			return nil, nil
+		}
+
+		return nil, NewError(InternalError, "failed to read balance %v: %v", key, err)
+	}
+
+	s := &Snapshot{}
+	err = Unmarshal(entry, s)
+	if err != nil {
+		return nil, NewError(InternalError, "failed to parse balance %v: %v", key, err)
+	}
+
+	return s, nil
+}
+
+// BalanceOperations adds the transactions to the account and asset
+// snapshots, the write fails if one of the snapshots was modified since
+// it was read
+func (l *Ledger) BalanceOperations(ctx context.Context, txs ...*Transaction) ([]interface{}, error) {
+	return l.balanceOperations(ctx, txs, nil, types.Unknown, nil)
+}
+
+// StatusOperations moves the amount of the transaction from the previous
+// to its current status in the account and asset snapshots
+func (l *Ledger) StatusOperations(ctx context.Context, tx *Transaction, from types.Status) ([]interface{}, error) {
+	return l.balanceOperations(ctx, nil, tx, from, nil)
+}
+
+// ReserveOperations reserves or releases the amount of the holds in the
+// account snapshots and adds the transactions
+func (l *Ledger) ReserveOperations(ctx context.Context, holds []*Hold, txs ...*Transaction) ([]interface{}, error) {
+	return l.balanceOperations(ctx, txs, nil, types.Unknown, holds)
+}
+
+func (l *Ledger) balanceOperations(ctx context.Context, txs []*Transaction, moved *Transaction, from types.Status, holds []*Hold) ([]interface{}, error) {
+	snapshots := []*Snapshot{}
+	accounts := map[types.Account]*Snapshot{}
+	assets := map[types.Asset]*Snapshot{}
+	pins := map[*Snapshot]uint64{}
+
+	loadAccount := func(holder string, a types.Account, asset types.Asset, since uint64) (*Snapshot, error) {
+		account, ok := accounts[a]
+		if !ok {
+			s, err := l.AccountSnapshot(ctx, a)
+			if err != nil {
+				return nil, err
+			}
+
+			account = s
+			accounts[a] = s
+			snapshots = append(snapshots, s)
+		}
+
+		account.Holder = holder
+		account.Asset = asset
+
+		// the balance check of a debit or hold read the account before
+		// since, the snapshot must not be modified afterwards
+		if since > 0 && (pins[account] == 0 || since < pins[account]) {
+			pins[account] = since
+		}
+
+		return account, nil
+	}
+
+	load := func(tx *Transaction) (*Snapshot, *Snapshot, error) {
+		account, err := loadAccount(tx.Holder, tx.Account, tx.Asset, tx.since)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		asset, ok := assets[tx.Asset]
+		if !ok {
+			s, err := l.AssetSnapshot(ctx, tx.Asset)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			asset = s
+			assets[tx.Asset] = s
+			snapshots = append(snapshots, s)
+		}
+
+		return account, asset, nil
+	}
+
+	if moved != nil && moved.Status != from {
+		account, asset, err := load(moved)
+		if err != nil {
+			return nil, err
+		}
+
+		account.Move(moved.Amount, from, moved.Status)
+		asset.Move(moved.Amount, from, moved.Status)
+	}
+
+	for _, tx := range txs {
+		if tx == nil || tx.Amount.IsZero() {
+			continue
+		}
+
+		account, asset, err := load(tx)
+		if err != nil {
+			return nil, err
+		}
+
+		account.add(tx)
+		asset.add(tx)
+	}
+
+	for _, h := range holds {
+		account, err := loadAccount(h.Holder, h.Account, h.Asset, h.since)
+		if err != nil {
+			return nil, err
+		}
+
+		account.hold(h)
+	}
+
+	ops := []interface{}{}
+
+	for _, s := range snapshots {
+		since := s.tx
+		if pin, ok := pins[s]; ok && pin < since {
+			since = pin
+		}
+
+		op, err := l.SnapshotOperations(s, since)
+		if err != nil {
+			return nil, err
+		}
+
+		ops = append(ops, op...)
+	}
+
+	return ops, nil
+}
+
+func (l *Ledger) SnapshotOperations(s *Snapshot, since uint64) ([]interface{}, error) {
+	data, err := Marshal(s, types.JSON, Version)
+	if err != nil {
+		return nil, NewError(InternalError, "marshal balance failed: %v", err)
+	}
+
+	ops := []interface{}{
+		&schema.Op_Kv{
+			Kv: &schema.KeyValue{
+				Key:   []byte(s.key),
+				Value: data,
+			},
+		},
+	}
+
+	if since == 0 {
+		ops = append(ops, &schema.Precondition_KeyMustNotExist{
+			KeyMustNotExist: &schema.Precondition_KeyMustNotExistPrecondition{
+				Key: []byte(s.key),
+			},
+		})
+	} else {
+		ops = append(ops, &schema.Precondition_KeyNotModifiedAfterTX{
+			KeyNotModifiedAfterTX: &schema.Precondition_KeyNotModifiedAfterTXPrecondition{
+				Key:  []byte(s.key),
+				TxID: since,
+			},
+		})
+	}
+
+	return ops, nil
+}
+
+// RebuildBalances recomputes the balance snapshots from the transaction
+// history, reports every snapshot which differs and rewrites it unless
+// dryRun is set. Asset snapshots are only rebuilt if no holder is given.
+func (l *Ledger) RebuildBalances(ctx context.Context, holder string, dryRun bool, f func(context.Context, *BalanceDrift) error) error {
+	if l.readOnly && !dryRun {
+		return NewError(NotFoundError, "read-only instance")
+	}
+
+	accounts := []types.Account{}
+
+	prefix := index.Holder.All()
+	if holder != "" {
+		prefix = index.Holder.Accounts(holder, types.AllAssets)
+	}
+
+	err := l.ForEach(ctx, prefix, false, func(ctx context.Context, tx *Transaction) (bool, error) {
+		accounts = append(accounts, tx.Account)
+		return true, nil
+	})
+
+	if err != nil {
+		return NewError(InternalError, "list accounts failed: %v", err)
+	}
+
+	for _, account := range accounts {
+		account := account
+
+		err := l.rebuild(ctx, string(index.AccountBalance.Key(account)), dryRun, f, func(s *Snapshot) error {
+			s.Account = account
+
+			err := l.ForEachInSet(ctx, index.Transaction.Scan(account), false, func(ctx context.Context, tx *Transaction) (bool, error) {
+				s.Holder = tx.Holder
+				s.Asset = tx.Asset
+				s.add(tx)
+				return true, nil
+			})
+			if err != nil {
+				return err
+			}
+
+			return l.held(ctx, s, account)
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if holder != "" {
+		return nil
+	}
+
+	assets, err := l.Assets(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, asset := range assets {
+		asset := asset
+
+		err := l.rebuild(ctx, string(index.AssetBalance.Key(asset)), dryRun, f, func(s *Snapshot) error {
+			s.Asset = asset
+
+			return l.ForEachInSet(ctx, string(index.AssetTx.Key(asset)), false, func(ctx context.Context, tx *Transaction) (bool, error) {
+				s.add(tx)
+				return true, nil
+			})
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l *Ledger) rebuild(ctx context.Context, key string, dryRun bool, f func(context.Context, *BalanceDrift) error, compute func(*Snapshot) error) error {
+	var drift *BalanceDrift
+
+	err := l.retry(ctx, func() error {
+		drift = nil
+
+		since, err := l.client.LastTX(ctx)
+		if err != nil {
+			return NewError(InternalError, "failed to get last tx: %v", err)
+		}
+
+		stored, err := l.snapshot(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		computed := NewSnapshot(key)
+
+		err = compute(computed)
+		if err != nil {
+			return NewError(InternalError, "failed to compute balance %v: %v", key, err)
+		}
+
+		if computed.Equal(stored) {
+			return nil
+		}
+
+		drift = &BalanceDrift{
+			Stored:   stored,
+			Computed: computed,
+		}
+
+		if dryRun {
+			return nil
+		}
+
+		ops, err := l.SnapshotOperations(computed, since)
+		if err != nil {
+			return err
+		}
+
+		_, err = l.client.Exec(ctx, ops...)
+		return err
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if drift != nil {
+		return f(ctx, drift)
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package ledger_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/ledger/index"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Balance_Snapshots(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	// the cancel of the spent credit overdraws the account
+	l := ledger.New(client,
+		ledger.SpendPending(),
+		ledger.SupportedAssets(cfg.Assets),
+		ledger.Overdraw(),
+	)
+
+	asset := randomAsset(assets)
+	holder := randomName()
+
+	before, err := l.AssetSnapshot(ctx, asset)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	tx1, ok := add(ctx, t, l, holder, asset, three)
+	if !ok {
+		return
+	}
+
+	_, err = remove(ctx, t, l, holder, asset, one)
+	if err != nil {
+		return
+	}
+
+	_, err = l.Cancel(ctx, holder, asset, tx1.Account, tx1.ID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	s, err := l.AccountSnapshot(ctx, tx1.Account)
+	if assert.NoError(t, err) {
+		assert.NotZero(t, s.TX())
+		assert.Equal(t, holder, s.Holder)
+		assert.Equal(t, asset, s.Asset)
+		assert.Equal(t, one.Neg().String(), s.Sum.String())
+		assert.Equal(t, uint(3), s.Count)
+	}
+
+	after, err := l.AssetSnapshot(ctx, asset)
+	if assert.NoError(t, err) {
+		assert.Equal(t, before.Sum.Sub(one).String(), after.Sum.String())
+	}
+
+	b, err := l.Balance(ctx, holder, asset, types.AllAccounts, types.AllStatuses)
+	if assert.NoError(t, err) && assert.Contains(t, b, asset) {
+		assert.Equal(t, one.Neg().String(), b[asset].Sum.String())
+		assert.Equal(t, uint(3), b[asset].Count)
+	}
+
+	drifts := []*ledger.BalanceDrift{}
+	report := func(ctx context.Context, drift *ledger.BalanceDrift) error {
+		drifts = append(drifts, drift)
+		return nil
+	}
+
+	err = l.RebuildBalances(ctx, holder, true, report)
+	if assert.NoError(t, err) {
+		assert.Empty(t, drifts)
+	}
+
+	data, err := ledger.Marshal(&ledger.Snapshot{
+		Holder:  holder,
+		Account: tx1.Account,
+		Asset:   asset,
+		AccountBalance: types.AccountBalance{
+			Sum:   two,
+			Count: 1,
+		},
+	}, types.JSON, ledger.Version)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = client.Set(ctx, index.AccountBalance.Key(tx1.Account), data)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	err = l.RebuildBalances(ctx, holder, true, report)
+	if assert.NoError(t, err) && assert.Len(t, drifts, 1) {
+		assert.Equal(t, two.String(), drifts[0].Stored.Sum.String())
+		assert.Equal(t, one.Neg().String(), drifts[0].Computed.Sum.String())
+		assert.Equal(t, uint(3), drifts[0].Computed.Count)
+	}
+
+	drifts = []*ledger.BalanceDrift{}
+	err = l.RebuildBalances(ctx, holder, false, report)
+	if assert.NoError(t, err) {
+		assert.Len(t, drifts, 1)
+	}
+
+	drifts = []*ledger.BalanceDrift{}
+	err = l.RebuildBalances(ctx, holder, true, report)
+	if assert.NoError(t, err) {
+		assert.Empty(t, drifts)
+	}
+
+	s, err = l.AccountSnapshot(ctx, tx1.Account)
+	if assert.NoError(t, err) {
+		assert.Equal(t, one.Neg().String(), s.Sum.String())
+	}
+}
@@ -13,13 +13,16 @@ const (
 )
 
 type Transaction struct {
-	tx      uint64
-	key     string
-	ID      types.ID      `json:"ID" swaggertype:"primitive,string"`
-	Account types.Account `json:"Account" swaggertype:"primitive,string"`
-	Holder  string        `json:"Holder"`
-	Order   string        `json:"Order,omitempty"`
-	Item    string        `json:"Item,omitempty"`
+	tx          uint64
+	key         string
+	since       uint64
+	idempotency string
+	ID          types.ID      `json:"ID" swaggertype:"primitive,string"`
+	Account     types.Account `json:"Account" swaggertype:"primitive,string"`
+	Holder      string        `json:"Holder"`
+	Order       string        `json:"Order,omitempty"`
+	Item        string        `json:"Item,omitempty"`
+	Journal     string        `json:"Journal,omitempty"`
 
 	Asset  types.Asset     `json:"Asset"`
 	Amount decimal.Decimal `json:"Amount"`
@@ -41,6 +44,7 @@ func (tx *Transaction) Copy() *Transaction {
 		Holder:  tx.Holder,
 		Order:   tx.Order,
 		Item:    tx.Item,
+		Journal: tx.Journal,
 
 		Asset:  tx.Asset,
 		Amount: tx.Amount,
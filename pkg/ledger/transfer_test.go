@@ -0,0 +1,122 @@
+package ledger_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Transfer(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	for _, f := range formats {
+		t.Run(t.Name()+"_"+f.String(), func(t *testing.T) {
+			l := ledger.New(client,
+				ledger.SpendPending(),
+				ledger.SupportedAssets(cfg.Assets),
+				ledger.Overdraw(false),
+				ledger.Format(f),
+			)
+
+			asset := randomAsset(assets)
+			from := randomName()
+			to := from + "_to"
+			reference := "transfer"
+
+			tx1, ok := add(ctx, t, l, from, asset, three)
+			if !ok {
+				return
+			}
+
+			debit, credit, err := l.Transfer(ctx, from, to, asset, two,
+				ledger.Reference(reference),
+			)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			if !assert.NotNil(t, debit) || !assert.NotNil(t, credit) {
+				return
+			}
+
+			assert.NotZero(t, debit.TX())
+			assert.Equal(t, debit.TX(), credit.TX())
+
+			assert.Equal(t, from, debit.Holder)
+			assert.Equal(t, tx1.Account, debit.Account)
+			assert.Equal(t, asset, debit.Asset)
+			assert.Equal(t, two.Neg().String(), debit.Amount.String())
+			assert.Equal(t, reference, debit.Reference)
+
+			assert.Equal(t, to, credit.Holder)
+			assert.NotEqual(t, debit.Account, credit.Account)
+			assert.Equal(t, asset, credit.Asset)
+			assert.Equal(t, two.String(), credit.Amount.String())
+			assert.Equal(t, reference, credit.Reference)
+
+			tx2, err := l.Get(ctx, debit.ID)
+			if assert.NoError(t, err) {
+				check(t, debit, tx2)
+			}
+
+			tx3, err := l.Get(ctx, credit.ID)
+			if assert.NoError(t, err) {
+				check(t, credit, tx3)
+			}
+
+			b, err := l.Balance(ctx, from, asset, types.AllAccounts, types.AllStatuses)
+			if assert.NoError(t, err) && assert.Contains(t, b, asset) {
+				assert.Equal(t, one.String(), b[asset].Sum.String())
+			}
+
+			b, err = l.Balance(ctx, to, asset, types.AllAccounts, types.AllStatuses)
+			if assert.NoError(t, err) && assert.Contains(t, b, asset) {
+				assert.Equal(t, two.String(), b[asset].Sum.String())
+			}
+
+			_, _, err = l.Transfer(ctx, from, to, asset, two)
+			assert.EqualError(t, err, fmt.Sprintf("balance too low to remove %v %v for holder %v", asset, two, from))
+
+			b, err = l.Balance(ctx, to, asset, types.AllAccounts, types.AllStatuses)
+			if assert.NoError(t, err) && assert.Contains(t, b, asset) {
+				assert.Equal(t, two.String(), b[asset].Sum.String())
+				assert.Equal(t, uint(1), b[asset].Count)
+			}
+
+			_, _, err = l.Transfer(ctx, from, from, asset, one)
+			assert.EqualError(t, err, fmt.Sprintf("can't transfer %v %v from holder %v to itself", asset, one, from))
+
+			// the accounts are set per leg
+			_, _, err = l.Transfer(ctx, from, to, asset, one, ledger.Account(tx1.Account))
+			if assert.Error(t, err) {
+				assert.True(t, err.(ledger.Error).IsError(ledger.BadRequestError), err.Error())
+			}
+
+			_, _, err = l.Transfer(ctx, from, to, asset, one, ledger.IdempotencyKey(randomName()))
+			if assert.Error(t, err) {
+				assert.True(t, err.(ledger.Error).IsError(ledger.BadRequestError), err.Error())
+			}
+
+			account := credit.Account
+
+			debit, credit, err = l.Transfer(ctx, from, to, asset, one,
+				ledger.FromAccount(tx1.Account),
+				ledger.ToAccount(account),
+			)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tx1.Account, debit.Account)
+				assert.Equal(t, account, credit.Account)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package ledger
+
+import "context"
+
+type userKey struct{}
+
+// WithUser returns a context whose writes record the user in the
+// transactions they create or modify
+func WithUser(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+func UserFrom(ctx context.Context) string {
+	user, _ := ctx.Value(userKey{}).(string)
+	return user
+}
@@ -0,0 +1,345 @@
+package ledger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/ec-systems/core.ledger.server/pkg/ledger/index"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+)
+
+// Webhook is a subscription of an url to the transactions matching the
+// holder, asset and status, Position is the last immudb tx handled
+type Webhook struct {
+	tx  uint64
+	key string
+
+	ID     types.ID     `json:"ID" swaggertype:"primitive,string"`
+	URL    string       `json:"URL"`
+	Holder string       `json:"Holder,omitempty"`
+	Asset  types.Asset  `json:"Asset,omitempty"`
+	Status types.Status `json:"Status"`
+	Secret string       `json:"Secret"`
+
+	Position uint64     `json:"Position"`
+	Created  *time.Time `json:"Created"`
+	Modified *time.Time `json:"Modified,omitempty"`
+}
+
+func NewWebhook(url string) *Webhook {
+	return &Webhook{
+		URL:    url,
+		Status: types.AllStatuses,
+	}
+}
+
+func (w *Webhook) SetTX(tx uint64) {
+	w.tx = tx
+}
+
+func (w *Webhook) TX() uint64 {
+	return w.tx
+}
+
+func (w *Webhook) SetKey(key string) {
+	w.key = key
+}
+
+func (w *Webhook) Key() string {
+	return w.key
+}
+
+func (w *Webhook) Filter() *Filter {
+	return &Filter{
+		Holder: w.Holder,
+		Asset:  w.Asset,
+		Status: w.Status,
+	}
+}
+
+type DeliveryStatus string
+
+const (
+	Delivered DeliveryStatus = "Delivered"
+	Failed    DeliveryStatus = "Failed"
+)
+
+// Delivery is a single attempt to post a transaction to a webhook
+type Delivery struct {
+	ID          types.ID       `json:"ID" swaggertype:"primitive,string"`
+	Webhook     types.ID       `json:"Webhook" swaggertype:"primitive,string"`
+	Tx          uint64         `json:"Tx"`
+	Transaction types.ID       `json:"Transaction" swaggertype:"primitive,string"`
+	Attempt     int            `json:"Attempt"`
+	Status      DeliveryStatus `json:"Status"`
+	Code        int            `json:"Code,omitempty"`
+	Error       string         `json:"Error,omitempty"`
+	Created     *time.Time     `json:"Created"`
+}
+
+// AddWebhook stores a new webhook, it receives the transactions written
+// after the current tx. A random secret is generated if none is given.
+func (l *Ledger) AddWebhook(ctx context.Context, hook *Webhook) (*Webhook, error) {
+	if l.readOnly {
+		return nil, NewError(NotFoundError, "read-only instance")
+	}
+
+	u, err := url.Parse(hook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, NewError(BadRequestError, "invalid webhook url %v", hook.URL)
+	}
+
+	if hook.Asset != types.AllAssets && !hook.Asset.Check(l.SupportedAssets()) {
+		return nil, NewError(BadRequestError, "invalid asset '%v'", hook.Asset)
+	}
+
+	if hook.Secret == "" {
+		secret := make([]byte, 32)
+		_, err := rand.Read(secret)
+		if err != nil {
+			return nil, NewError(InternalError, "can't generate webhook secret: %v", err)
+		}
+
+		hook.Secret = hex.EncodeToString(secret)
+	}
+
+	hook.ID, err = l.NewID()
+	if err != nil {
+		return nil, NewError(InternalError, "can't generate webhook id: %v", err)
+	}
+
+	hook.tx = 0
+	hook.Created = nil
+
+	hook.Position, err = l.LastTX(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ops, err := l.WebhookOperations(hook)
+	if err != nil {
+		return nil, err
+	}
+
+	hook.tx, err = l.client.Exec(ctx, ops...)
+	if err != nil {
+		return nil, NewError(InternalError, "failed to store webhook %v: %v", hook.URL, err)
+	}
+
+	return hook, nil
+}
+
+func (l *Ledger) GetWebhook(ctx context.Context, id types.ID) (*Webhook, error) {
+	entry, err := l.client.Get(ctx, string(index.Webhook.Key(id)))
+	if err != nil {
+		if strings.Contains(err.Error(), store.ErrKeyNotFound.Error()) {
+			return nil, NewError(NotFoundError, "webhook %v not found", id)
+		}
+
+		return nil, NewError(InternalError, "failed to read webhook %v: %v", id, err)
+	}
+
+	hook := &Webhook{}
+	err = Unmarshal(entry, hook)
+	if err != nil {
+		return nil, NewError(InternalError, "failed to parse the webhook %v: %v", id, err)
+	}
+
+	return hook, nil
+}
+
+func (l *Ledger) Webhooks(ctx context.Context, f func(context.Context, *Webhook) (bool, error)) error {
+	return l.client.ScanSet(ctx, string(index.Webhooks.Key()), false, func(ctx context.Context, e *schema.ZEntry) (bool, error) {
+		hook := &Webhook{}
+		err := Unmarshal(e.Entry, hook)
+		if err != nil {
+			return false, NewError(InternalError, "failed to parse the webhook (%v): %v", err, string(e.Entry.Value))
+		}
+
+		return f(ctx, hook)
+	})
+}
+
+// UpdateWebhook writes the webhook, it fails with a ConflictError if the
+// webhook was modified after it was read
+func (l *Ledger) UpdateWebhook(ctx context.Context, hook *Webhook) error {
+	if l.readOnly {
+		return NewError(NotFoundError, "read-only instance")
+	}
+
+	ops, err := l.WebhookOperations(hook)
+	if err != nil {
+		return err
+	}
+
+	tx, err := l.client.Exec(ctx, ops...)
+	if err != nil {
+		if strings.Contains(err.Error(), store.ErrPreconditionFailed.Error()) {
+			return NewError(ConflictError, "webhook %v was modified: %v", hook.ID, err)
+		}
+
+		return NewError(InternalError, "failed to update webhook %v: %v", hook.ID, err)
+	}
+
+	hook.tx = tx
+
+	return nil
+}
+
+// ReplayWebhook moves the position of the webhook back, all matching
+// transactions written after fromTx are delivered again
+func (l *Ledger) ReplayWebhook(ctx context.Context, id types.ID, fromTx uint64) (*Webhook, error) {
+	var hook *Webhook
+
+	err := l.retry(ctx, func() error {
+		var err error
+
+		hook, err = l.GetWebhook(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		hook.Position = fromTx
+
+		err = l.UpdateWebhook(ctx, hook)
+		if lerr, ok := err.(Error); ok && lerr.IsError(ConflictError) {
+			return store.ErrPreconditionFailed
+		}
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return hook, nil
+}
+
+func (l *Ledger) WebhookOperations(hook *Webhook) ([]interface{}, error) {
+	now := time.Now()
+
+	if hook.Created == nil {
+		hook.Created = &now
+	} else {
+		hook.Modified = &now
+	}
+
+	if hook.ID.IsEmpty() {
+		return nil, NewError(BadRequestError, "webhook id is empty")
+	}
+
+	data, err := Marshal(hook, types.JSON, Version)
+	if err != nil {
+		return nil, NewError(InternalError, "marshal webhook failed: %v", err)
+	}
+
+	key := index.Webhook.Key(hook.ID)
+
+	ops := []interface{}{
+		&schema.Op_Kv{
+			Kv: &schema.KeyValue{
+				Key:   key,
+				Value: data,
+			},
+		},
+	}
+
+	if hook.tx == 0 {
+		ops = append(ops,
+			&schema.Op_ZAdd{
+				ZAdd: &schema.ZAddRequest{
+					Key:      key,
+					Set:      index.Webhooks.Key(),
+					Score:    float64(hook.Created.Local().UnixMilli()),
+					BoundRef: false,
+				},
+			},
+			&schema.Precondition_KeyMustNotExist{
+				KeyMustNotExist: &schema.Precondition_KeyMustNotExistPrecondition{
+					Key: key,
+				},
+			},
+		)
+	} else {
+		ops = append(ops, &schema.Precondition_KeyNotModifiedAfterTX{
+			KeyNotModifiedAfterTX: &schema.Precondition_KeyNotModifiedAfterTXPrecondition{
+				Key:  key,
+				TxID: hook.tx,
+			},
+		})
+	}
+
+	hook.key = string(key)
+
+	return ops, nil
+}
+
+// AddDelivery records a delivery attempt of a webhook
+func (l *Ledger) AddDelivery(ctx context.Context, delivery *Delivery) error {
+	if l.readOnly {
+		return NewError(NotFoundError, "read-only instance")
+	}
+
+	id, err := l.NewID()
+	if err != nil {
+		return NewError(InternalError, "can't generate delivery id: %v", err)
+	}
+
+	now := time.Now()
+	delivery.ID = id
+	delivery.Created = &now
+
+	data, err := Marshal(delivery, types.JSON, Version)
+	if err != nil {
+		return NewError(InternalError, "marshal delivery failed: %v", err)
+	}
+
+	key := index.Delivery.Key(delivery.ID)
+
+	_, err = l.client.Exec(ctx,
+		&schema.Op_Kv{
+			Kv: &schema.KeyValue{
+				Key:   key,
+				Value: data,
+			},
+		},
+		&schema.Op_ZAdd{
+			ZAdd: &schema.ZAddRequest{
+				Key:      key,
+				Set:      index.Deliveries.Key(delivery.Webhook),
+				Score:    float64(now.Local().UnixMilli()),
+				BoundRef: false,
+			},
+		},
+	)
+
+	if err != nil {
+		return NewError(InternalError, "failed to record delivery of tx %v to webhook %v: %v", delivery.Tx, delivery.Webhook, err)
+	}
+
+	return nil
+}
+
+// Deliveries lists the delivery attempts of a webhook, the latest first
+func (l *Ledger) Deliveries(ctx context.Context, webhook types.ID, status DeliveryStatus, f func(context.Context, *Delivery) (bool, error)) error {
+	return l.client.ScanSet(ctx, string(index.Deliveries.Key(webhook)), true, func(ctx context.Context, e *schema.ZEntry) (bool, error) {
+		delivery := &Delivery{}
+		err := Unmarshal(e.Entry, delivery)
+		if err != nil {
+			return false, NewError(InternalError, "failed to parse the delivery (%v): %v", err, string(e.Entry.Value))
+		}
+
+		if status != "" && status != delivery.Status {
+			return true, nil
+		}
+
+		return f(ctx, delivery)
+	})
+}
@@ -0,0 +1,85 @@
+package ledger_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Webhook(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	l := ledger.New(client,
+		ledger.SupportedAssets(cfg.Assets),
+	)
+
+	_, err = l.AddWebhook(ctx, ledger.NewWebhook("localhost:1234"))
+	assert.Error(t, err)
+
+	last, err := client.LastTX(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	hook := ledger.NewWebhook("http://localhost:1234/hook")
+	hook.Holder = randomName()
+
+	hook, err = l.AddWebhook(ctx, hook)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NotEmpty(t, hook.Secret)
+	assert.Equal(t, last, hook.Position)
+	assert.Equal(t, types.AllStatuses, hook.Filter().Status)
+
+	found := false
+	err = l.Webhooks(ctx, func(ctx context.Context, h *ledger.Webhook) (bool, error) {
+		found = found || h.ID == hook.ID
+		return true, nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, found)
+
+	replayed, err := l.ReplayWebhook(ctx, hook.ID, 1)
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint64(1), replayed.Position)
+	}
+
+	// the stale copy can't overwrite the replay
+	hook.Position = last + 10
+	err = l.UpdateWebhook(ctx, hook)
+	if assert.Error(t, err) {
+		assert.True(t, err.(ledger.Error).IsError(ledger.ConflictError))
+	}
+
+	for i, status := range []ledger.DeliveryStatus{ledger.Failed, ledger.Delivered} {
+		err = l.AddDelivery(ctx, &ledger.Delivery{Webhook: hook.ID, Tx: last, Attempt: i + 1, Status: status})
+		if !assert.NoError(t, err) {
+			return
+		}
+	}
+
+	failures := []*ledger.Delivery{}
+	err = l.Deliveries(ctx, hook.ID, ledger.Failed, func(ctx context.Context, d *ledger.Delivery) (bool, error) {
+		failures = append(failures, d)
+		return true, nil
+	})
+	if assert.NoError(t, err) && assert.Len(t, failures, 1) {
+		assert.Equal(t, 1, failures[0].Attempt)
+	}
+
+	_, err = l.GetWebhook(ctx, types.ZeroID)
+	if assert.Error(t, err) {
+		assert.True(t, err.(ledger.Error).IsError(ledger.NotFoundError))
+	}
+}
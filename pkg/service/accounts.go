@@ -4,7 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/codenotary/immudb/embedded/store"
 	"github.com/ec-systems/core.ledger.server/pkg/ledger"
 	"github.com/ec-systems/core.ledger.server/pkg/types"
 	"github.com/go-chi/chi/v5"
@@ -40,10 +44,14 @@ func NewAccountsService(ledger *ledger.Ledger) chi.Router {
 	router.Get("/{holder}/{asset}/{account}", svc.transactions)
 	// show tx history
 	router.Get("/{holder}/{asset}/{account}/{id}", svc.history)
+	// verify tx
+	router.Get("/{holder}/{asset}/{account}/{id}/proof", svc.proof)
 	// set tx status
 	router.Patch("/{holder}/{asset}/{account}/{id}/{status}", svc.change)
 	// revert a transaction
 	router.Delete("/{holder}/{asset}/{account}/{id}", svc.cancel)
+	// revert a part of a transaction
+	router.Post("/{holder}/{asset}/{account}/{id}/refund/{amount}", svc.refund)
 
 	return svc
 }
@@ -58,10 +66,12 @@ func NewAccountsService(ledger *ledger.Ledger) chi.Router {
 // @Param        order   	query     	string  false  	"Order ID"
 // @Param        item   	query    	string  false  	"Order Item ID"
 // @Param        ref   		query      	string 	false	"Reference"
+// @Param        Idempotency-Key	header	string	false	"Idempotency Key, a retry with the same key returns the original transaction"
 // @Success      200  {object}  service.Transaction
 // @Failure      400
 // @Failure      404
 // @Failure      406
+// @Failure      409
 // @Failure      500
 // @Router       /accounts/{holder}/{asset}/{amount} [put]
 func (a *AccountsService) add(w http.ResponseWriter, r *http.Request) {
@@ -90,7 +100,11 @@ func (a *AccountsService) add(w http.ResponseWriter, r *http.Request) {
 	item := a.item(w, r)
 	ref := a.reference(w, r)
 
-	tx, err := a.ledger.Add(r.Context(), holder, asset, amount, account, order, item, ref)
+	if isError(w, authorize(r.Context(), AddOperation, holder, asset)) {
+		return
+	}
+
+	tx, err := a.ledger.Add(r.Context(), holder, asset, amount, account, order, item, ref, a.idempotency(w, r))
 	if isError(w, err) {
 		return
 	}
@@ -110,10 +124,12 @@ func (a *AccountsService) add(w http.ResponseWriter, r *http.Request) {
 // @Param        order   	query     	string  false  	"Order ID"
 // @Param        item   	query    	string  false  	"Order Item ID"
 // @Param        ref   		query      	string 	false	"Reference"
+// @Param        Idempotency-Key	header	string	false	"Idempotency Key, a retry with the same key returns the original transaction"
 // @Success      200  {object}  service.Transaction
 // @Failure      400
 // @Failure      404
 // @Failure      406
+// @Failure      409
 // @Failure      500
 // @Router       /accounts/{holder}/{asset}/{amount} [delete]
 func (a *AccountsService) remove(w http.ResponseWriter, r *http.Request) {
@@ -142,7 +158,11 @@ func (a *AccountsService) remove(w http.ResponseWriter, r *http.Request) {
 	item := a.item(w, r)
 	ref := a.reference(w, r)
 
-	tx, err := a.ledger.Remove(r.Context(), holder, asset, amount, account, order, item, ref)
+	if isError(w, authorize(r.Context(), RemoveOperation, holder, asset)) {
+		return
+	}
+
+	tx, err := a.ledger.Remove(r.Context(), holder, asset, amount, account, order, item, ref, a.idempotency(w, r))
 	if isError(w, err) {
 		return
 	}
@@ -164,6 +184,7 @@ func (a *AccountsService) remove(w http.ResponseWriter, r *http.Request) {
 // @Failure      400
 // @Failure      404
 // @Failure      406
+// @Failure      409
 // @Failure      500
 // @Router       /accounts/{holder}/{asset}/{account}/{id} [delete]
 func (a *AccountsService) cancel(w http.ResponseWriter, r *http.Request) {
@@ -214,6 +235,10 @@ func (a *AccountsService) cancel(w http.ResponseWriter, r *http.Request) {
 		account.Set(in)
 	}
 
+	if isError(w, authorize(r.Context(), CancelOperation, holder, asset)) {
+		return
+	}
+
 	tx, err := a.ledger.Cancel(r.Context(), in.Holder, in.Asset, in.Account, in.ID)
 	if isError(w, err) {
 		return
@@ -224,6 +249,84 @@ func (a *AccountsService) cancel(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, output)
 }
 
+// @Summary      Refund a Transaction
+// @Description  Revert a part of a transaction, the refunded total can't exceed the amount of the transaction. Cancels and refunds can't be refunded
+// @Tags         Accounts
+// @Produce      json
+// @Param        holder   	path      	string  true  	"Account Holder"
+// @Param        asset   	path      	string  true  	"Asset Symbol"
+// @Param        account   	path      	string  true  	"Account"
+// @Param        id   		path      	string  true  	"Transaction ID"
+// @Param        amount   	path      	string  true  	"Amount"
+// @Success      200  {object}  service.Transaction
+// @Failure      400
+// @Failure      404
+// @Failure      409
+// @Failure      500
+// @Router       /accounts/{holder}/{asset}/{account}/{id}/refund/{amount} [post]
+func (a *AccountsService) refund(w http.ResponseWriter, r *http.Request) {
+	holder := a.holder(w, r)
+	if holder == "" {
+		http.Error(w, "empty holder", http.StatusBadRequest)
+		return
+	}
+
+	asset, err := a.asset(w, r)
+	if isError(w, err) {
+		return
+	}
+
+	account, err := a.account(w, r)
+	if isError(w, err) {
+		return
+	}
+
+	if account == nil {
+		http.Error(w, "account is mandatory", http.StatusBadRequest)
+		return
+	}
+
+	id, err := a.id(w, r)
+	if isError(w, err) {
+		return
+	}
+
+	amount, err := a.amount(w, r)
+	if isError(w, err) {
+		return
+	}
+
+	if isError(w, authorize(r.Context(), CancelOperation, holder, asset)) {
+		return
+	}
+
+	in := &ledger.Transaction{}
+	account.Set(in)
+
+	tx, err := a.ledger.Get(r.Context(), id)
+	if err != nil && strings.Contains(err.Error(), store.ErrKeyNotFound.Error()) {
+		err = ledger.NewError(ledger.NotFoundError, "transaction %v not found", id)
+	}
+
+	if isError(w, err) {
+		return
+	}
+
+	if tx.Holder != holder || tx.Asset != asset || tx.Account != in.Account {
+		http.Error(w, fmt.Sprintf("inconsistent holder/account/transaction combination (%v/%v/%v)", holder, in.Account, id), http.StatusBadRequest)
+		return
+	}
+
+	refund, err := a.ledger.Refund(r.Context(), id, amount)
+	if isError(w, err) {
+		return
+	}
+
+	output := &Transaction{}
+	output.Set(a.ledger, refund)
+	render.JSON(w, r, output)
+}
+
 // @Summary      List Holders
 // @Description  List all holders in the ledger
 // @Tags         Accounts
@@ -235,6 +338,10 @@ func (a *AccountsService) cancel(w http.ResponseWriter, r *http.Request) {
 func (a *AccountsService) holders(w http.ResponseWriter, r *http.Request) {
 	holders := map[string]*Holder{}
 	err := a.ledger.Holders(r.Context(), func(holder string, account types.Account, asset types.Asset) (bool, error) {
+		if !permitted(r.Context(), ReadOperation, holder, asset) {
+			return true, nil
+		}
+
 		h, ok := holders[holder]
 		if !ok {
 			h = &Holder{
@@ -271,7 +378,10 @@ func (a *AccountsService) holders(w http.ResponseWriter, r *http.Request) {
 // @Tags         Accounts
 // @Produce      json
 // @Param        holder   	path      	string  true  	"Account Holder"
+// @Param        at   		query      	string 	false	"Balance at a point in time (RFC3339)"
+// @Param        tx   		query      	int 	false	"Balance as of an immudb transaction"
 // @Success 	 200 		{array} service.Balance
+// @Failure      400
 // @Failure      404
 // @Failure      500
 // @Router       /accounts/{holder} [get]
@@ -282,11 +392,49 @@ func (a *AccountsService) allAccounts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	balances, err := a.ledger.Balance(r.Context(), holder, types.AllAssets, types.AllAccounts, types.AllStatuses)
-	if isError(w, err) {
+	if isError(w, authorizeHolder(r.Context(), ReadOperation, holder)) {
 		return
 	}
 
+	at := r.URL.Query().Get("at")
+	txID := r.URL.Query().Get("tx")
+
+	var balances map[types.Asset]*types.Balance
+	var err error
+
+	switch {
+	case at != "" && txID != "":
+		http.Error(w, "at and tx can't be combined", http.StatusBadRequest)
+		return
+	case at != "":
+		t, err := time.Parse(time.RFC3339, at)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid point in time %v: %v", at, err), http.StatusBadRequest)
+			return
+		}
+
+		balances, err = a.ledger.BalanceAt(r.Context(), holder, types.AllAssets, types.AllAccounts, t)
+		if isError(w, err) {
+			return
+		}
+	case txID != "":
+		id, err := strconv.ParseUint(txID, 10, 64)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid tx %v: %v", txID, err), http.StatusBadRequest)
+			return
+		}
+
+		balances, err = a.ledger.BalanceAsOfTx(r.Context(), holder, types.AllAssets, types.AllAccounts, id)
+		if isError(w, err) {
+			return
+		}
+	default:
+		balances, err = a.ledger.Balance(r.Context(), holder, types.AllAssets, types.AllAccounts, types.AllStatuses)
+		if isError(w, err) {
+			return
+		}
+	}
+
 	if len(balances) == 0 {
 		http.Error(w, fmt.Sprintf("No accounts for holder %v found\n", holder), http.StatusNotFound)
 		return
@@ -295,22 +443,13 @@ func (a *AccountsService) allAccounts(w http.ResponseWriter, r *http.Request) {
 	list := []*Balance{}
 
 	for k, v := range balances {
-		accounts := []*AccountBalance{}
-
-		for a, b := range v.Accounts {
-			accounts = append(accounts, &AccountBalance{
-				ID:    a.String(),
-				Count: b.Count,
-				Sum:   b.Sum,
-			})
+		if !permitted(r.Context(), ReadOperation, holder, k) {
+			continue
 		}
 
-		list = append(list, &Balance{
-			Asset:    k.String(),
-			Accounts: accounts,
-			Count:    v.Count,
-			Sum:      v.Sum,
-		})
+		balance := &Balance{}
+		balance.Set(k, v)
+		list = append(list, balance)
 	}
 
 	render.JSON(w, r, list)
@@ -338,6 +477,16 @@ func (a *AccountsService) accounts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if asset == types.AllAssets {
+		err = authorizeHolder(r.Context(), ReadOperation, holder)
+	} else {
+		err = authorize(r.Context(), ReadOperation, holder, asset)
+	}
+
+	if isError(w, err) {
+		return
+	}
+
 	balances, err := a.ledger.Balance(r.Context(), holder, asset, types.AllAccounts, types.AllStatuses)
 	if isError(w, err) {
 		return
@@ -351,35 +500,34 @@ func (a *AccountsService) accounts(w http.ResponseWriter, r *http.Request) {
 	list := []*Balance{}
 
 	for k, v := range balances {
-		accounts := []*AccountBalance{}
-
-		for a, b := range v.Accounts {
-			accounts = append(accounts, &AccountBalance{
-				ID:    a.String(),
-				Count: b.Count,
-				Sum:   b.Sum,
-			})
+		if !permitted(r.Context(), ReadOperation, holder, k) {
+			continue
 		}
 
-		list = append(list, &Balance{
-			Asset:    k.String(),
-			Accounts: accounts,
-			Count:    v.Count,
-			Sum:      v.Sum,
-		})
+		balance := &Balance{}
+		balance.Set(k, v)
+		list = append(list, balance)
 	}
 
 	render.JSON(w, r, list)
 }
 
 // @Summary      List Transactions
-// @Description  List all the transactions of an account
+// @Description  List the transactions of an account, the cursor of the next page is returned in the Next-Cursor header
 // @Tags         Accounts
 // @Produce      json
 // @Param        holder   	path      	string  true  	"Account Holder"
 // @Param        asset   	path      	string  true  	"Asset Symbol"
 // @Param        account   	path      	string  true  	"Account"
-// @Success 	 200 		{object} service.Transaction
+// @Param        limit   	query      	int 	false	"Max number of transactions"
+// @Param        cursor   	query      	string 	false	"Cursor of the next page"
+// @Param        order   	query      	string 	false	"Sort order (asc, desc)"
+// @Param        status   	query      	string 	false	"Status"
+// @Param        from   	query      	string 	false	"Created at or after (RFC3339)"
+// @Param        to   		query      	string 	false	"Created at or before (RFC3339)"
+// @Param        order_id  	query      	string 	false	"Order ID"
+// @Success 	 200 		{array} service.Transaction
+// @Header       200        {string}  Next-Cursor  "Cursor of the next page"
 // @Failure      404
 // @Failure      500
 // @Router       /accounts/{holder}/{asset}/{account} [get]
@@ -419,9 +567,18 @@ func (a *AccountsService) transactions(w http.ResponseWriter, r *http.Request) {
 		account.Set(in)
 	}
 
+	if isError(w, authorize(r.Context(), ReadOperation, holder, asset)) {
+		return
+	}
+
+	page, err := a.page(w, r)
+	if isError(w, err) {
+		return
+	}
+
 	txs := []*Transaction{}
 
-	err = a.ledger.Transactions(r.Context(), in.Holder, in.Asset, in.Account, func(ctx context.Context, tx *ledger.Transaction) (bool, error) {
+	next, err := a.ledger.TransactionsPage(r.Context(), in.Holder, in.Asset, in.Account, page, func(ctx context.Context, tx *ledger.Transaction) (bool, error) {
 		if tx.Holder == in.Holder && tx.Asset == in.Asset && tx.Account == in.Account {
 			output := &Transaction{}
 			output.Set(a.ledger, tx)
@@ -436,6 +593,10 @@ func (a *AccountsService) transactions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if next != "" {
+		w.Header().Set("Next-Cursor", next)
+	}
+
 	render.JSON(w, r, txs)
 }
 
@@ -498,6 +659,10 @@ func (a *AccountsService) history(w http.ResponseWriter, r *http.Request) {
 		account.Set(in)
 	}
 
+	if isError(w, authorize(r.Context(), ReadOperation, holder, asset)) {
+		return
+	}
+
 	txs := []*Transaction{}
 
 	err = a.ledger.History(r.Context(), in.ID, func(ctx context.Context, tx *ledger.Transaction) (bool, error) {
@@ -518,6 +683,101 @@ func (a *AccountsService) history(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, txs)
 }
 
+// @Summary      Verify Transaction
+// @Description  Verify a transaction against the trusted database state
+// @Tags         Accounts
+// @Produce      json
+// @Param        holder   	path      	string  true  	"Account Holder"
+// @Param        asset   	path      	string  true  	"Asset Symbol"
+// @Param        account   	path      	string  true  	"Account"
+// @Param        id   		path      	string  true  	"Transaction ID"
+// @Success 	 200 		{object} service.Proof
+// @Failure      404
+// @Failure      500
+// @Router       /accounts/{holder}/{asset}/{account}/{id}/proof [get]
+func (a *AccountsService) proof(w http.ResponseWriter, r *http.Request) {
+	holder := a.holder(w, r)
+	if holder == "" {
+		http.Error(w, "holder is mandatory", http.StatusBadRequest)
+		return
+	}
+
+	asset, err := a.asset(w, r)
+	if isError(w, err) {
+		return
+	}
+
+	if asset == types.AllAssets {
+		http.Error(w, "asset is mandatory", http.StatusBadRequest)
+		return
+	}
+
+	account, err := a.account(w, r)
+	if isError(w, err) {
+		return
+	}
+
+	if account == nil {
+		http.Error(w, "account is mandatory", http.StatusBadRequest)
+		return
+	}
+
+	id, err := a.id(w, r)
+	if isError(w, err) {
+		return
+	}
+
+	if id.IsEmpty() {
+		http.Error(w, "transaction id is mandatory", http.StatusBadRequest)
+		return
+	}
+
+	in := &ledger.Transaction{
+		ID:     id,
+		Holder: holder,
+		Asset:  asset,
+	}
+
+	account.Set(in)
+
+	if isError(w, authorize(r.Context(), ReadOperation, holder, asset)) {
+		return
+	}
+
+	tx, proof, err := a.ledger.Proof(r.Context(), id)
+	if lerr, ok := err.(ledger.Error); ok && lerr.IsError(ledger.VerificationError) {
+		render.JSON(w, r, &Proof{
+			Verified: false,
+			Error:    err.Error(),
+		})
+		return
+	}
+
+	if isError(w, err) {
+		return
+	}
+
+	if tx.Holder != in.Holder || tx.Asset != in.Asset || tx.Account != in.Account {
+		http.Error(w, fmt.Sprintf("transaction %v not found", id), http.StatusNotFound)
+		return
+	}
+
+	output := &Transaction{}
+	output.Set(a.ledger, tx)
+
+	render.JSON(w, r, &Proof{
+		Transaction: output,
+		Verified:    proof.Verified,
+		Signed:      proof.Signed,
+		Tx:          proof.Tx,
+		SourceTx:    proof.SourceTx,
+		TargetTx:    proof.TargetTx,
+		TargetAlh:   proof.TargetAlh,
+		Leaf:        proof.Leaf,
+		Width:       proof.Width,
+	})
+}
+
 // @Summary      Change the Transaction Status
 // @Description  Change the status of a transaction
 // @Tags         Accounts
@@ -586,6 +846,10 @@ func (a *AccountsService) change(w http.ResponseWriter, r *http.Request) {
 		account.Set(in)
 	}
 
+	if isError(w, authorize(r.Context(), StatusOperation, holder, asset)) {
+		return
+	}
+
 	tx, err := a.ledger.Status(r.Context(), in, in.Status)
 	if isError(w, err) {
 		return
@@ -687,6 +951,70 @@ func (l *AccountsService) reference(w http.ResponseWriter, r *http.Request) ledg
 	return nil
 }
 
+func (l *AccountsService) idempotency(w http.ResponseWriter, r *http.Request) ledger.TransactionOption {
+	key := r.Header.Get("Idempotency-Key")
+	if key != "" {
+		return ledger.IdempotencyKey(key)
+	}
+
+	return nil
+}
+
+func (l *AccountsService) page(w http.ResponseWriter, r *http.Request) (*ledger.Page, error) {
+	query := r.URL.Query()
+	page := ledger.NewPage()
+
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value < 0 {
+			return nil, ledger.NewError(http.StatusBadRequest, "invalid limit %v", limit)
+		}
+
+		page.Limit = value
+	}
+
+	page.Cursor = query.Get("cursor")
+
+	switch order := query.Get("order"); order {
+	case "", "asc":
+	case "desc":
+		page.Desc = true
+	default:
+		return nil, ledger.NewError(http.StatusBadRequest, "invalid order %v (asc, desc)", order)
+	}
+
+	if status := query.Get("status"); status != "" {
+		value, err := l.ledger.SupportedStatus().Parse(status)
+		if err != nil {
+			return nil, ledger.NewError(http.StatusBadRequest, err.Error())
+		}
+
+		page.Status = value
+	}
+
+	if from := query.Get("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return nil, ledger.NewError(http.StatusBadRequest, "invalid from %v: %v", from, err)
+		}
+
+		page.From = t
+	}
+
+	if to := query.Get("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return nil, ledger.NewError(http.StatusBadRequest, "invalid to %v: %v", to, err)
+		}
+
+		page.To = t
+	}
+
+	page.Order = query.Get("order_id")
+
+	return page, nil
+}
+
 func (l *AccountsService) account(w http.ResponseWriter, r *http.Request) (ledger.TransactionOption, error) {
 	accountID := chi.URLParam(r, "account")
 	if accountID == "" {
@@ -0,0 +1,320 @@
+package service_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ec-systems/core.ledger.server/pkg/service"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Balance_At(t *testing.T) {
+	holder := randomName()
+	asset := randomAsset()
+
+	amount1, _ := decimal.NewFromString("2.5")
+	amount2, _ := decimal.NewFromString("1.5")
+
+	resp, err := put("/accounts/%v/%v/%v", holder, asset, amount1)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	var tx1 service.Transaction
+	err = json.NewDecoder(resp.Body).Decode(&tx1)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	resp, err = del("/accounts/%v/%v/%v", holder, asset, amount2)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	balance := func(query string, args ...interface{}) []*service.Balance {
+		resp, err := get("/accounts/%v"+query, append([]interface{}{holder}, args...)...)
+		if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+			return nil
+		}
+
+		var balances []*service.Balance
+		err = json.NewDecoder(resp.Body).Decode(&balances)
+		if !assert.NoError(t, err) {
+			return nil
+		}
+
+		return balances
+	}
+
+	balances := balance("?at=%v", tx1.Created.UTC().Format(time.RFC3339Nano))
+	if assert.Len(t, balances, 1) {
+		assert.Equal(t, amount1.String(), balances[0].Sum.String())
+		assert.Equal(t, uint(1), balances[0].Count)
+	}
+
+	balances = balance("")
+	if assert.Len(t, balances, 1) {
+		assert.Equal(t, amount1.Sub(amount2).String(), balances[0].Sum.String())
+		assert.Equal(t, amount2.Neg().String(), balances[0].Available.String())
+		assert.Equal(t, amount1.Sub(amount2).String(), balances[0].Pending.String())
+		assert.Equal(t, "0", balances[0].Finished.String())
+	}
+
+	balances = balance("?tx=%v", uint64(1<<63))
+	if assert.Len(t, balances, 1) {
+		assert.Equal(t, amount1.Sub(amount2).String(), balances[0].Sum.String())
+		assert.Equal(t, uint(2), balances[0].Count)
+	}
+
+	resp, err = get("/accounts/%v?at=yesterday", holder)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+
+	resp, err = get("/accounts/%v?at=%v&tx=1", holder, tx1.Created.UTC().Format(time.RFC3339Nano))
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+}
+
+func Test_Idempotency_Key(t *testing.T) {
+	holder := randomName()
+	asset := randomAsset()
+	key := randomName()
+
+	add := func(amount string) *http.Response {
+		req, err := http.NewRequest("PUT", url+"/accounts/"+holder+"/"+asset.String()+"/"+amount, nil)
+		if !assert.NoError(t, err) {
+			return nil
+		}
+
+		req.Header.Set("Idempotency-Key", key)
+
+		resp, err := http.DefaultClient.Do(req)
+		if !assert.NoError(t, err) {
+			return nil
+		}
+
+		return resp
+	}
+
+	resp := add("1.5")
+	if resp == nil || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	var tx1 service.Transaction
+	err := json.NewDecoder(resp.Body).Decode(&tx1)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	resp = add("1.5")
+	if resp == nil || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	var tx2 service.Transaction
+	err = json.NewDecoder(resp.Body).Decode(&tx2)
+	if assert.NoError(t, err) {
+		assert.Equal(t, tx1.ID, tx2.ID)
+	}
+
+	resp = add("2")
+	if resp != nil {
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	}
+}
+
+func Test_Proof(t *testing.T) {
+	holder := randomName()
+	asset := randomAsset()
+
+	amount, _ := decimal.NewFromString("1.5")
+
+	resp, err := put("/accounts/%v/%v/%v", holder, asset, amount)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	var tx service.Transaction
+	err = json.NewDecoder(resp.Body).Decode(&tx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	resp, err = get("/accounts/%v/%v/%v/%v/proof", holder, asset, tx.Account, tx.ID)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	var proof service.Proof
+	err = json.NewDecoder(resp.Body).Decode(&proof)
+	if assert.NoError(t, err) && assert.NotNil(t, proof.Transaction) {
+		assert.True(t, proof.Verified)
+		assert.Equal(t, tx.ID, proof.Transaction.ID)
+		assert.NotEmpty(t, proof.TargetAlh)
+	}
+
+	resp, err = get("/accounts/%v/%v/%v/%v/proof", randomName(), asset, tx.Account, tx.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+}
+
+func Test_Refund(t *testing.T) {
+	holder := randomName()
+	asset := randomAsset()
+
+	resp, err := put("/accounts/%v/%v/%v", holder, asset, 3)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	var tx service.Transaction
+	err = json.NewDecoder(resp.Body).Decode(&tx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	resp, err = post(nil, "/accounts/%v/%v/%v/%v/refund/%v", holder, asset, tx.Account, tx.ID, 1)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	var refund service.Transaction
+	err = json.NewDecoder(resp.Body).Decode(&refund)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "-1", refund.Amount.String())
+	}
+
+	resp, err = post(nil, "/accounts/%v/%v/%v/%v/refund/%v", holder, asset, refund.Account, refund.ID, 1)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+
+	resp, err = post(nil, "/accounts/%v/%v/%v/%v/refund/%v", holder, asset, tx.Account, uuid.New(), 1)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+
+	resp, err = post(nil, "/accounts/%v/%v/%v/%v/refund/%v", holder, asset, tx.Account, tx.ID, 3)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+
+	resp, err = post(nil, "/accounts/%v/%v/%v/%v/refund/%v", randomName(), asset, tx.Account, tx.ID, 1)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+
+	resp, err = del("/accounts/%v/%v/%v/%v", holder, asset, tx.Account, tx.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	resp, err = del("/accounts/%v/%v/%v/%v", holder, asset, tx.Account, tx.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	}
+}
+
+func Test_Cancel_Status(t *testing.T) {
+	holder := randomName()
+	asset := randomAsset()
+
+	resp, err := put("/accounts/%v/%v/%v", holder, asset, 3)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	var tx service.Transaction
+	err = json.NewDecoder(resp.Body).Decode(&tx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// a status change doesn't reverse the amount, it can't cancel
+	resp, err = patch("/accounts/%v/%v/%v/%v/%v", holder, asset, tx.Account, tx.ID, "Canceled")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusBadRequest, resp.StatusCode) {
+		return
+	}
+
+	resp, err = del("/accounts/%v/%v/%v/%v", holder, asset, tx.Account, tx.ID)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	resp, err = patch("/accounts/%v/%v/%v/%v/%v", holder, asset, tx.Account, tx.ID, "CancellationFinished")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	resp, err = get("/accounts/%v", holder)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	var balances []*service.Balance
+	err = json.NewDecoder(resp.Body).Decode(&balances)
+	if assert.NoError(t, err) && assert.Len(t, balances, 1) {
+		assert.True(t, balances[0].Sum.IsZero(), balances[0].Sum.String())
+	}
+}
+
+func Test_Transactions_Page(t *testing.T) {
+	holder := randomName()
+	asset := randomAsset()
+
+	var account string
+	for i := 0; i < 3; i++ {
+		resp, err := put("/accounts/%v/%v/%v", holder, asset, decimal.NewFromInt(1))
+		if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+			return
+		}
+
+		var tx service.Transaction
+		err = json.NewDecoder(resp.Body).Decode(&tx)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		account = tx.Account
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	list := func(query string, args ...interface{}) ([]*service.Transaction, string) {
+		resp, err := get("/accounts/%v/%v/%v"+query, append([]interface{}{holder, asset, account}, args...)...)
+		if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+			return nil, ""
+		}
+
+		var txs []*service.Transaction
+		err = json.NewDecoder(resp.Body).Decode(&txs)
+		assert.NoError(t, err)
+
+		return txs, resp.Header.Get("Next-Cursor")
+	}
+
+	txs, next := list("?limit=2&order=desc")
+	if assert.Len(t, txs, 2) && assert.NotEmpty(t, next) {
+		assert.True(t, txs[0].Created.After(*txs[1].Created))
+	}
+
+	txs, _ = list("?limit=2&order=desc&cursor=%v", next)
+	assert.Len(t, txs, 1)
+
+	txs, _ = list("?status=Finished")
+	assert.Len(t, txs, 0)
+
+	resp, err := get("/accounts/%v/%v/%v?order=sideways", holder, asset, account)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type APIKeysService struct {
+	chi.Router
+	ledger *ledger.Ledger
+}
+
+func NewAPIKeysService(ledger *ledger.Ledger) chi.Router {
+	router := chi.NewRouter()
+	svc := &APIKeysService{
+		Router: router,
+		ledger: ledger,
+	}
+
+	router.Use(authorizeOperation(AdminOperation))
+
+	// add an api key
+	router.Post("/", svc.add)
+	// list the api keys
+	router.Get("/", svc.apiKeys)
+	// revoke an api key
+	router.Delete("/{id}", svc.revoke)
+
+	return svc
+}
+
+// @Summary      Add API Key
+// @Description  Add an api key for the name, the key is only returned once and only its hash is stored
+// @Tags         API Keys
+// @Accept       json
+// @Produce      json
+// @Param        key  	body      	service.APIKeyRequest  true  	"API Key"
+// @Success      200  {object}  service.APIKey
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      500
+// @Router       /apikeys/ [post]
+func (h *APIKeysService) add(w http.ResponseWriter, r *http.Request) {
+	req := &APIKeyRequest{}
+
+	err := json.NewDecoder(r.Body).Decode(req)
+	if err != nil {
+		http.Error(w, "invalid api key request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	apiKey, key, err := h.ledger.AddAPIKey(r.Context(), req.Name)
+	if isError(w, err) {
+		return
+	}
+
+	output := &APIKey{}
+	output.Set(apiKey)
+	output.Key = key
+	render.JSON(w, r, output)
+}
+
+// @Summary      List API Keys
+// @Description  List all stored api keys
+// @Tags         API Keys
+// @Produce      json
+// @Success      200  {array}  service.APIKey
+// @Failure      401
+// @Failure      403
+// @Failure      500
+// @Router       /apikeys/ [get]
+func (h *APIKeysService) apiKeys(w http.ResponseWriter, r *http.Request) {
+	output := []*APIKey{}
+
+	err := h.ledger.APIKeys(r.Context(), func(ctx context.Context, apiKey *ledger.APIKey) (bool, error) {
+		o := &APIKey{}
+		o.Set(apiKey)
+		output = append(output, o)
+		return true, nil
+	})
+
+	if isError(w, err) {
+		return
+	}
+
+	render.JSON(w, r, output)
+}
+
+// @Summary      Revoke API Key
+// @Description  Revoke a stored api key
+// @Tags         API Keys
+// @Produce      json
+// @Param        id   		path      	string  true  	"API Key ID"
+// @Success      200  {object}  service.APIKey
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /apikeys/{id} [delete]
+func (h *APIKeysService) revoke(w http.ResponseWriter, r *http.Request) {
+	guid, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("api key id is invalid: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	apiKey, err := h.ledger.RevokeAPIKey(r.Context(), types.ID{UUID: guid})
+	if isError(w, err) {
+		return
+	}
+
+	output := &APIKey{}
+	output.Set(apiKey)
+	render.JSON(w, r, output)
+}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -26,6 +28,14 @@ func NewAssetsService(ledger *ledger.Ledger) chi.Router {
 	router.Get("/", svc.assets)
 	// show balance of an asset
 	router.Get("/{asset}", svc.balance)
+	// add or replace the definition of an asset
+	router.With(authorizeOperation(AdminOperation)).Put("/{asset}", svc.define)
+	// enable an asset
+	router.With(authorizeOperation(AdminOperation)).Post("/{asset}/enable", svc.enable)
+	// disable an asset
+	router.With(authorizeOperation(AdminOperation)).Post("/{asset}/disable", svc.disable)
+	// list the changes of an asset definition
+	router.Get("/{asset}/history", svc.history)
 
 	return svc
 }
@@ -65,6 +75,10 @@ func (a *AssetsService) balance(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if isError(w, authorize(r.Context(), ReadOperation, "", asset)) {
+		return
+	}
+
 	balance, err := a.ledger.AssetBalance(r.Context(), asset)
 	if isError(w, err) {
 		return
@@ -84,6 +98,126 @@ func (a *AssetsService) balance(w http.ResponseWriter, r *http.Request) {
 	http.Error(w, fmt.Sprintf("asset '%v' not found", asset), http.StatusNotFound)
 }
 
+// @Summary      Define Asset
+// @Description  Add or replace the definition of an asset in the asset registry, all service instances pick it up on their next reload
+// @Tags         Assets
+// @Accept       json
+// @Produce      json
+// @Param        asset   	path      	string  				true  	"Asset Symbol"
+// @Param        definition	body      	service.AssetRequest  	true  	"Asset Definition"
+// @Success      200  {object}  service.Asset
+// @Failure      400
+// @Failure      403
+// @Failure      500
+// @Router       /assets/{asset} [put]
+func (a *AssetsService) define(w http.ResponseWriter, r *http.Request) {
+	req := &AssetRequest{}
+
+	err := json.NewDecoder(r.Body).Decode(req)
+	if err != nil {
+		http.Error(w, "invalid asset request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	definition := types.NewAssetDefinition(req.Name)
+	definition.Decimals = req.Decimals
+	definition.Min = req.Min
+	definition.Max = req.Max
+
+	if req.Enabled != nil {
+		definition.Enabled = *req.Enabled
+	}
+
+	asset, err := a.ledger.AddAsset(r.Context(), types.Asset(chi.URLParam(r, "asset")), definition)
+	if isError(w, err) {
+		return
+	}
+
+	output := &Asset{}
+	output.SetRegistered(asset)
+	render.JSON(w, r, output)
+}
+
+// @Summary      Enable Asset
+// @Description  Enable an asset of the asset registry
+// @Tags         Assets
+// @Produce      json
+// @Param        asset   	path      	string  true  	"Asset Symbol"
+// @Success      200  {object}  service.Asset
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /assets/{asset}/enable [post]
+func (a *AssetsService) enable(w http.ResponseWriter, r *http.Request) {
+	a.setEnabled(w, r, true)
+}
+
+// @Summary      Disable Asset
+// @Description  Disable an asset, new transactions of the asset are rejected
+// @Tags         Assets
+// @Produce      json
+// @Param        asset   	path      	string  true  	"Asset Symbol"
+// @Success      200  {object}  service.Asset
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /assets/{asset}/disable [post]
+func (a *AssetsService) disable(w http.ResponseWriter, r *http.Request) {
+	a.setEnabled(w, r, false)
+}
+
+func (a *AssetsService) setEnabled(w http.ResponseWriter, r *http.Request, enabled bool) {
+	asset, err := a.ledger.EnableAsset(r.Context(), types.Asset(chi.URLParam(r, "asset")), enabled)
+	if isError(w, err) {
+		return
+	}
+
+	output := &Asset{}
+	output.SetRegistered(asset)
+	render.JSON(w, r, output)
+}
+
+// @Summary      Asset History
+// @Description  List all stored versions of an asset definition
+// @Tags         Assets
+// @Produce      json
+// @Param        asset   	path      	string  true  	"Asset Symbol"
+// @Success      200  {array}  service.Asset
+// @Failure      400
+// @Failure      404
+// @Failure      500
+// @Router       /assets/{asset}/history [get]
+func (a *AssetsService) history(w http.ResponseWriter, r *http.Request) {
+	asset, err := a.asset(w, r)
+	if isError(w, err) {
+		return
+	}
+
+	if isError(w, authorize(r.Context(), ReadOperation, "", asset)) {
+		return
+	}
+
+	output := []*Asset{}
+
+	err = a.ledger.AssetHistory(r.Context(), asset, func(ctx context.Context, registered *ledger.RegisteredAsset) (bool, error) {
+		o := &Asset{}
+		o.SetRegistered(registered)
+		output = append(output, o)
+		return true, nil
+	})
+
+	if isError(w, err) {
+		return
+	}
+
+	if len(output) == 0 {
+		http.Error(w, fmt.Sprintf("asset '%v' isn't registered", asset), http.StatusNotFound)
+		return
+	}
+
+	render.JSON(w, r, output)
+}
+
 func (t *AssetsService) asset(w http.ResponseWriter, r *http.Request) (types.Asset, error) {
 	assetID := chi.URLParam(r, "asset")
 
@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"strings"
+
+	"github.com/ec-systems/core.ledger.server/pkg/config"
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	APIKeyHeader = "X-API-Key"
+
+	APIKeyMethod      = "apikey"
+	JWTMethod         = "jwt"
+	CertificateMethod = "certificate"
+)
+
+// Principal is the authenticated caller of a request, without Grants all
+// operations except the administration are allowed. Admin marks the
+// statically configured api keys, they administrate the ledger without a
+// policy.
+type Principal struct {
+	Name   string
+	Method string
+	Admin  bool
+	Grants Grants
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a context with the principal, the ledger records
+// its name as user of the written transactions
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	ctx = context.WithValue(ctx, principalKey{}, principal)
+	return ledger.WithUser(ctx, principal.Name)
+}
+
+func PrincipalFrom(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey{}).(*Principal)
+	return principal
+}
+
+// Credentials are the parts of a http or grpc request used to
+// authenticate the caller
+type Credentials struct {
+	Header http.Header
+	TLS    *tls.ConnectionState
+}
+
+// Authenticator resolves the principal of the credentials, it returns
+// no principal and no error if the credentials don't contain its secret
+type Authenticator interface {
+	Authenticate(ctx context.Context, creds *Credentials) (*Principal, error)
+}
+
+type AuthenticatorFunc func(ctx context.Context, creds *Credentials) (*Principal, error)
+
+func (f AuthenticatorFunc) Authenticate(ctx context.Context, creds *Credentials) (*Principal, error) {
+	return f(ctx, creds)
+}
+
+// Authentication runs the authenticators in order, the first principal
+// found is used. Without a principal a request is rejected if the
+// authentication is required. With a policy the principal gets its grants,
+// a request without principal gets none.
+type Authentication struct {
+	required       bool
+	policy         *Policy
+	authenticators []Authenticator
+}
+
+func NewAuthentication(required bool, policy *Policy, authenticators ...Authenticator) *Authentication {
+	return &Authentication{
+		required:       required,
+		policy:         policy,
+		authenticators: authenticators,
+	}
+}
+
+// NewLedgerAuthentication creates the authentication of the service
+// configuration. The mTLS client certificate is always used as identity,
+// api keys and jwt tokens are checked and required if auth is configured.
+func NewLedgerAuthentication(l *ledger.Ledger, options *config.AuthOptions, policy *Policy) (*Authentication, error) {
+	authenticators := []Authenticator{}
+
+	if options != nil {
+		authenticators = append(authenticators, NewAPIKeyAuthenticator(options.Keys, l))
+
+		if options.JWKS != "" {
+			keys, err := LoadJWKS(options.JWKS)
+			if err != nil {
+				return nil, err
+			}
+
+			jwt, err := NewJWTAuthenticator(keys, options.Issuer, options.Audience)
+			if err != nil {
+				return nil, err
+			}
+
+			authenticators = append(authenticators, jwt)
+		}
+	}
+
+	authenticators = append(authenticators, AuthenticatorFunc(CertificateAuthenticator))
+
+	return NewAuthentication(options != nil, policy, authenticators...), nil
+}
+
+func (a *Authentication) Authenticate(ctx context.Context, creds *Credentials) (*Principal, error) {
+	var principal *Principal
+
+	for _, authenticator := range a.authenticators {
+		var err error
+
+		principal, err = authenticator.Authenticate(ctx, creds)
+		if err != nil {
+			return nil, err
+		}
+
+		if principal != nil {
+			break
+		}
+	}
+
+	if principal == nil && a.required {
+		return nil, ledger.NewError(ledger.UnauthorizedError, "authentication required")
+	}
+
+	if a.policy != nil {
+		if principal == nil {
+			principal = &Principal{}
+		}
+
+		principal.Grants = a.policy.Grants(principal.Name)
+	}
+
+	return principal, nil
+}
+
+// Middleware authenticates the http requests, paths ending with * are
+// prefixes of public paths
+func (a *Authentication) Middleware(public ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, p := range public {
+				if r.URL.Path == p || (strings.HasSuffix(p, "*") && strings.HasPrefix(r.URL.Path, strings.TrimSuffix(p, "*"))) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			principal, err := a.Authenticate(r.Context(), &Credentials{Header: r.Header, TLS: r.TLS})
+			if isError(w, err) {
+				return
+			}
+
+			if principal != nil {
+				r = r.WithContext(WithPrincipal(r.Context(), principal))
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (a *Authentication) Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := a.grpc(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (a *Authentication) Stream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.grpc(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+}
+
+func (a *Authentication) grpc(ctx context.Context) (context.Context, error) {
+	creds := &Credentials{
+		Header: http.Header{},
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for k, values := range md {
+		for _, v := range values {
+			creds.Header.Add(k, v)
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			creds.TLS = &info.State
+		}
+	}
+
+	principal, err := a.Authenticate(ctx, creds)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	if principal != nil {
+		ctx = WithPrincipal(ctx, principal)
+	}
+
+	return ctx, nil
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// APIKeyAuthenticator checks the api key of the X-API-Key header or the
+// ApiKey authorization against the configured and the stored key hashes
+type APIKeyAuthenticator struct {
+	names  map[string]string
+	ledger *ledger.Ledger
+}
+
+// NewAPIKeyAuthenticator creates an authenticator of the sha256 key hashes
+// by name, the stored keys of the ledger are checked if it isn't nil
+func NewAPIKeyAuthenticator(hashes map[string]string, l *ledger.Ledger) *APIKeyAuthenticator {
+	names := map[string]string{}
+	for name, hash := range hashes {
+		names[strings.ToLower(hash)] = name
+	}
+
+	return &APIKeyAuthenticator{
+		names:  names,
+		ledger: l,
+	}
+}
+
+func (a *APIKeyAuthenticator) Authenticate(ctx context.Context, creds *Credentials) (*Principal, error) {
+	key := creds.Header.Get(APIKeyHeader)
+	if key == "" {
+		key, _ = authorization(creds.Header, "ApiKey")
+	}
+
+	if key == "" {
+		return nil, nil
+	}
+
+	hash := ledger.HashAPIKey(key)
+	if name, ok := a.names[hash]; ok {
+		return &Principal{Name: name, Method: APIKeyMethod, Admin: true}, nil
+	}
+
+	if a.ledger != nil {
+		apiKey, err := a.ledger.GetAPIKey(ctx, hash)
+		if err != nil {
+			if lerr, ok := err.(ledger.Error); ok && lerr.IsError(ledger.NotFoundError) {
+				return nil, ledger.NewError(ledger.UnauthorizedError, "invalid api key")
+			}
+
+			return nil, err
+		}
+
+		if apiKey.Revoked != nil {
+			return nil, ledger.NewError(ledger.UnauthorizedError, "api key %v is revoked", apiKey.ID)
+		}
+
+		return &Principal{Name: apiKey.Name, Method: APIKeyMethod}, nil
+	}
+
+	return nil, ledger.NewError(ledger.UnauthorizedError, "invalid api key")
+}
+
+// CertificateAuthenticator uses the subject of the verified mTLS client
+// certificate as identity
+func CertificateAuthenticator(ctx context.Context, creds *Credentials) (*Principal, error) {
+	if creds.TLS == nil || len(creds.TLS.PeerCertificates) == 0 {
+		return nil, nil
+	}
+
+	subject := creds.TLS.PeerCertificates[0].Subject
+
+	name := subject.CommonName
+	if name == "" {
+		name = subject.String()
+	}
+
+	return &Principal{Name: name, Method: CertificateMethod}, nil
+}
+
+// authorization returns the credentials of the authorization header with
+// the given scheme
+func authorization(header http.Header, scheme string) (string, bool) {
+	value := header.Get("Authorization")
+
+	parts := strings.SplitN(value, " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], scheme) {
+		return "", false
+	}
+
+	return strings.TrimSpace(parts[1]), true
+}
@@ -0,0 +1,251 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ec-systems/core.ledger.server/pkg/config"
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/service"
+	"github.com/ec-systems/core.ledger.server/pkg/service/protobuf"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func Test_Authentication(t *testing.T) {
+	ctx := context.Background()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	jwks := filepath.Join(t.TempDir(), "jwks.json")
+	err = writeJWKS(jwks, "test", &key.PublicKey)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	c := cfg
+	c.Service.WebhookInterval = 0
+	c.Service.Metrics = -1
+	c.Service.Auth = &config.AuthOptions{
+		Keys:     map[string]string{"ops": ledger.HashAPIKey("ops-key")},
+		JWKS:     jwks,
+		Issuer:   "issuer",
+		Audience: "ledger",
+	}
+
+	client, port, _, grpcPort, err := start(ctx, &c)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	time.Sleep(100 * time.Millisecond)
+
+	base := fmt.Sprintf("http://localhost:%d", port)
+	holder := randomName()
+	asset := randomAsset()
+
+	add := func(header http.Header) (*service.Transaction, int) {
+		resp, err := request("PUT", fmt.Sprintf("%v/accounts/%v/%v/1", base, holder, asset), nil, header)
+		if !assert.NoError(t, err) {
+			return nil, 0
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, resp.StatusCode
+		}
+
+		tx := &service.Transaction{}
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(tx))
+		return tx, resp.StatusCode
+	}
+
+	resp, err := request("GET", base+"/health", nil, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	_, code := add(nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	_, code = add(http.Header{service.APIKeyHeader: {"invalid"}})
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	tx, _ := add(http.Header{service.APIKeyHeader: {"ops-key"}})
+	if assert.NotNil(t, tx) {
+		assert.Equal(t, "ops", tx.User)
+	}
+
+	// jwt bearer tokens
+	claims := map[string]interface{}{"sub": "alice", "iss": "issuer", "aud": []string{"ledger"}, "exp": time.Now().Add(time.Minute).Unix()}
+	tx, _ = add(bearer(key, "test", claims))
+	if assert.NotNil(t, tx) {
+		assert.Equal(t, "alice", tx.User)
+	}
+
+	claims["exp"] = time.Now().Add(-time.Minute).Unix()
+	_, code = add(bearer(key, "test", claims))
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	claims["exp"] = time.Now().Add(time.Minute).Unix()
+	claims["aud"] = "other"
+	_, code = add(bearer(key, "test", claims))
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if assert.NoError(t, err) {
+		claims["aud"] = "ledger"
+		_, code = add(bearer(other, "test", claims))
+		assert.Equal(t, http.StatusUnauthorized, code)
+	}
+
+	// stored api keys
+	resp, err = request("POST", base+"/apikeys", &service.APIKeyRequest{Name: "bob"}, http.Header{service.APIKeyHeader: {"ops-key"}})
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	stored := &service.APIKey{}
+	if !assert.NoError(t, json.NewDecoder(resp.Body).Decode(stored)) {
+		return
+	}
+
+	tx, _ = add(http.Header{"Authorization": {"ApiKey " + stored.Key}})
+	if assert.NotNil(t, tx) {
+		assert.Equal(t, "bob", tx.User)
+	}
+
+	// without a policy only the configured keys administrate the ledger
+	resp, err = request("DELETE", fmt.Sprintf("%v/apikeys/%v", base, stored.ID), nil, http.Header{service.APIKeyHeader: {stored.Key}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	}
+
+	resp, err = request("GET", base+"/apikeys", nil, bearer(key, "test", claims))
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	}
+
+	resp, err = request("DELETE", fmt.Sprintf("%v/apikeys/%v", base, stored.ID), nil, http.Header{service.APIKeyHeader: {"ops-key"}})
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		_, code = add(http.Header{service.APIKeyHeader: {stored.Key}})
+		assert.Equal(t, http.StatusUnauthorized, code)
+	}
+
+	// grpc uses the same authentication
+	conn, err := grpc.Dial(fmt.Sprintf("localhost:%d", grpcPort), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer conn.Close()
+
+	gc := protobuf.NewLedgerServiceClient(conn)
+	req := &protobuf.AmountRequest{Holder: holder, Asset: asset.String(), Amount: "1"}
+
+	_, err = gc.Add(ctx, req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	gtx, err := gc.Add(metadata.AppendToOutgoingContext(ctx, "x-api-key", "ops-key"), req)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "ops", gtx.User)
+	}
+}
+
+func Test_CertificateAuthentication(t *testing.T) {
+	auth := service.NewAuthentication(true, nil, service.AuthenticatorFunc(service.CertificateAuthenticator))
+
+	_, err := auth.Authenticate(context.Background(), &service.Credentials{Header: http.Header{}})
+	assert.Error(t, err)
+
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "client", Organization: []string{"ledger"}}}
+	principal, err := auth.Authenticate(context.Background(), &service.Credentials{
+		Header: http.Header{},
+		TLS:    &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}},
+	})
+
+	if assert.NoError(t, err) && assert.NotNil(t, principal) {
+		assert.Equal(t, "client", principal.Name)
+		assert.Equal(t, service.CertificateMethod, principal.Method)
+	}
+}
+
+func request(method string, url string, body interface{}, header http.Header) (*http.Response, error) {
+	var data []byte
+	if body != nil {
+		var err error
+		data, err = json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequest(method, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	return http.DefaultClient.Do(req)
+}
+
+func writeJWKS(file string, kid string, key *rsa.PublicKey) error {
+	jwks := &service.JWKS{
+		Keys: []service.JWK{
+			{
+				Kty: "RSA",
+				Kid: kid,
+				Alg: "RS256",
+				Use: "sig",
+				N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			},
+		},
+	}
+
+	data, err := json.Marshal(jwks)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(file, data, 0600)
+}
+
+func bearer(key *rsa.PrivateKey, kid string, claims map[string]interface{}) http.Header {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": kid})
+	payload, _ := json.Marshal(claims)
+
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(input))
+
+	signature, _ := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+
+	return http.Header{"Authorization": {"Bearer " + input + "." + base64.RawURLEncoding.EncodeToString(signature)}}
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/logger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+)
+
+const (
+	SignatureHeader = "X-Ledger-Signature"
+	EventHeader     = "X-Ledger-Event"
+
+	maxBackoff = 10 * time.Minute
+)
+
+// Sign returns the signature of a webhook payload
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookWorker posts the ledger transactions to the matching webhooks. The
+// position of a webhook moves forward once a transaction is delivered or all
+// retries failed, a restarted worker continues at the stored position.
+type WebhookWorker struct {
+	ledger   *ledger.Ledger
+	client   *http.Client
+	interval time.Duration
+	retries  int
+	backoff  time.Duration
+
+	lock      sync.Mutex
+	positions map[types.ID]position
+}
+
+// position is the last scanned tx of a webhook, it is only valid as long
+// as the webhook isn't modified by someone else
+type position struct {
+	hook uint64
+	tx   uint64
+}
+
+func NewWebhookWorker(ledger *ledger.Ledger, interval time.Duration, retries int, backoff time.Duration, timeout time.Duration) *WebhookWorker {
+	return &WebhookWorker{
+		ledger:    ledger,
+		client:    &http.Client{Timeout: timeout},
+		interval:  interval,
+		retries:   retries,
+		backoff:   backoff,
+		positions: map[types.ID]position{},
+	}
+}
+
+// Run delivers the transactions in the given interval until the context is done
+func (w *WebhookWorker) Run(ctx context.Context) {
+	if w.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.run(ctx)
+		}
+	}
+}
+
+func (w *WebhookWorker) run(ctx context.Context) {
+	last, err := w.ledger.LastTX(ctx)
+	if err != nil {
+		logger.Errorf("webhook delivery failed: %v", err)
+		return
+	}
+
+	hooks := []*ledger.Webhook{}
+	err = w.ledger.Webhooks(ctx, func(ctx context.Context, hook *ledger.Webhook) (bool, error) {
+		hooks = append(hooks, hook)
+		return true, nil
+	})
+
+	if err != nil {
+		logger.Errorf("webhook delivery failed: %v", err)
+		return
+	}
+
+	var wg sync.WaitGroup
+
+	for _, hook := range hooks {
+		wg.Add(1)
+		go func(hook *ledger.Webhook) {
+			defer wg.Done()
+
+			err := w.process(ctx, hook, last)
+			if err != nil && ctx.Err() == nil {
+				logger.Errorf("webhook %v delivery failed: %v", hook.ID, err)
+			}
+		}(hook)
+	}
+
+	wg.Wait()
+}
+
+func (w *WebhookWorker) process(ctx context.Context, hook *ledger.Webhook, last uint64) error {
+	from := hook.Position
+
+	w.lock.Lock()
+	p, ok := w.positions[hook.ID]
+	w.lock.Unlock()
+
+	if ok && p.hook == hook.TX() && p.tx > from {
+		from = p.tx
+	}
+
+	if from >= last {
+		return nil
+	}
+
+	err := w.ledger.Events(ctx, from, last, hook.Filter(), func(ctx context.Context, e *ledger.Event) (bool, error) {
+		err := w.deliver(ctx, hook, e)
+		if err != nil {
+			return false, err
+		}
+
+		if !e.Last {
+			return true, nil
+		}
+
+		hook.Position = e.Tx
+
+		err = w.ledger.UpdateWebhook(ctx, hook)
+		if lerr, ok := err.(ledger.Error); ok && lerr.IsError(ledger.ConflictError) {
+			// replayed in the meantime, the next run continues at the new position
+			logger.Infof("webhook %v was modified, stop delivery at tx %v", hook.ID, e.Tx)
+			return false, nil
+		}
+
+		return err == nil, err
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.lock.Lock()
+	w.positions[hook.ID] = position{hook: hook.TX(), tx: last}
+	w.lock.Unlock()
+
+	return nil
+}
+
+// deliver posts the transaction until it is accepted or all retries failed,
+// every attempt is recorded
+func (w *WebhookWorker) deliver(ctx context.Context, hook *ledger.Webhook, e *ledger.Event) error {
+	output := &Transaction{}
+	output.Set(w.ledger, e.Transaction)
+
+	body, err := json.Marshal(&WebhookEvent{
+		Webhook:     hook.ID.UUID,
+		Tx:          e.Tx,
+		Transaction: output,
+	})
+	if err != nil {
+		return err
+	}
+
+	backoff := w.backoff
+
+	for attempt := 1; ; attempt++ {
+		code, err := w.post(ctx, hook, e.Tx, body)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		delivery := &ledger.Delivery{
+			Webhook:     hook.ID,
+			Tx:          e.Tx,
+			Transaction: e.Transaction.ID,
+			Attempt:     attempt,
+			Status:      ledger.Delivered,
+			Code:        code,
+		}
+
+		if err != nil {
+			delivery.Status = ledger.Failed
+			delivery.Error = err.Error()
+		}
+
+		rerr := w.ledger.AddDelivery(ctx, delivery)
+		if rerr != nil {
+			return rerr
+		}
+
+		if err == nil {
+			return nil
+		}
+
+		if attempt > w.retries {
+			logger.Warnf("webhook %v delivery of tx %v failed after %v attempts: %v", hook.ID, e.Tx, attempt, err)
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+func (w *WebhookWorker) post(ctx context.Context, hook *ledger.Webhook, tx uint64, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, fmt.Sprint(tx))
+	req.Header.Set(SignatureHeader, Sign(hook.Secret, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %v", resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/logger"
+	"github.com/go-chi/chi/v5"
+)
+
+type EventsService struct {
+	chi.Router
+	ledger *ledger.Ledger
+}
+
+func NewEventsService(ledger *ledger.Ledger) chi.Router {
+	router := chi.NewRouter()
+	svc := &EventsService{
+		Router: router,
+		ledger: ledger,
+	}
+
+	// stream the ledger transactions
+	router.Get("/", svc.events)
+
+	return svc
+}
+
+// @Summary      Stream Transactions
+// @Description  Stream new and updated transactions as server-sent events, the event id is the immudb tx of the transaction. A stream resumes after the Last-Event-ID header or the from parameter, without both it starts at the current tx.
+// @Tags         Events
+// @Produce      text/event-stream
+// @Param        Last-Event-ID	header	string	false	"Resume after this immudb tx"
+// @Param        from   	query      	int 	false	"Start after this immudb tx"
+// @Param        holder   	query      	string 	false	"Account Holder"
+// @Param        asset   	query      	string 	false	"Asset Symbol"
+// @Param        status   	query      	string 	false	"Status"
+// @Success 	 200 		{object} 	service.Transaction
+// @Failure      400
+// @Failure      500
+// @Router       /events [get]
+func (e *EventsService) events(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	filter, err := e.filter(r)
+	if isError(w, err) {
+		return
+	}
+
+	from, err := e.from(r)
+	if isError(w, err) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	err = e.ledger.Subscribe(r.Context(), from, filter, func(ctx context.Context, event *ledger.Event) (bool, error) {
+		if !permitted(r.Context(), ReadOperation, event.Transaction.Holder, event.Transaction.Asset) {
+			// the id keeps the position of a resumed stream in sync
+			if event.Last {
+				fmt.Fprintf(w, "id: %v\n\n", event.Tx)
+				flusher.Flush()
+			}
+
+			return true, nil
+		}
+
+		output := &Transaction{}
+		output.Set(e.ledger, event.Transaction)
+
+		data, err := json.Marshal(output)
+		if err != nil {
+			return false, err
+		}
+
+		// the id is only sent with the last event of a tx, a resumed
+		// stream repeats the events of an incomplete tx
+		if event.Last {
+			fmt.Fprintf(w, "id: %v\n", event.Tx)
+		}
+
+		_, err = fmt.Fprintf(w, "data: %s\n\n", data)
+		if err != nil {
+			return false, err
+		}
+
+		flusher.Flush()
+
+		return true, nil
+	})
+
+	if err != nil && r.Context().Err() == nil {
+		logger.Errorf("Event stream failed: %v", err)
+	}
+}
+
+func (e *EventsService) filter(r *http.Request) (*ledger.Filter, error) {
+	query := r.URL.Query()
+	filter := ledger.NewFilter()
+
+	filter.Holder = query.Get("holder")
+
+	asset, err := e.ledger.SupportedAssets().Parse(query.Get("asset"))
+	if err != nil {
+		return nil, ledger.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	filter.Asset = asset
+
+	if status := query.Get("status"); status != "" {
+		value, err := e.ledger.SupportedStatus().Parse(status)
+		if err != nil {
+			return nil, ledger.NewError(http.StatusBadRequest, err.Error())
+		}
+
+		filter.Status = value
+	}
+
+	return filter, nil
+}
+
+func (e *EventsService) from(r *http.Request) (uint64, error) {
+	from := r.Header.Get("Last-Event-ID")
+	if from == "" {
+		from = r.URL.Query().Get("from")
+	}
+
+	if from == "" {
+		return e.ledger.LastTX(r.Context())
+	}
+
+	tx, err := strconv.ParseUint(from, 10, 64)
+	if err != nil {
+		return 0, ledger.NewError(http.StatusBadRequest, "invalid event id %v", from)
+	}
+
+	return tx, nil
+}
@@ -0,0 +1,102 @@
+package service_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ec-systems/core.ledger.server/pkg/service"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+type event struct {
+	ID          string
+	Transaction *service.Transaction
+}
+
+func events(t *testing.T, count int, lastEventID string, format string, args ...interface{}) []*event {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url+format, nil)
+	if !assert.NoError(t, err) {
+		return nil
+	}
+
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return nil
+	}
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	list := []*event{}
+	e := &event{}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for len(list) < count && scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "id: "):
+			e.ID = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "data: "):
+			e.Transaction = &service.Transaction{}
+			err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), e.Transaction)
+			if !assert.NoError(t, err) {
+				return nil
+			}
+		case line == "":
+			list = append(list, e)
+			e = &event{}
+		}
+	}
+
+	return list
+}
+
+func Test_Events(t *testing.T) {
+	holder := randomName()
+	asset := randomAsset()
+
+	amount1, _ := decimal.NewFromString("1.5")
+	amount2, _ := decimal.NewFromString("2")
+
+	for _, amount := range []decimal.Decimal{amount1, amount2} {
+		resp, err := put("/accounts/%v/%v/%v", holder, asset, amount)
+		if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+			return
+		}
+	}
+
+	list := events(t, 2, "", "/events?from=0&holder="+holder)
+	if !assert.Len(t, list, 2) {
+		return
+	}
+
+	assert.Equal(t, amount1.String(), list[0].Transaction.Amount.String())
+	assert.Equal(t, amount2.String(), list[1].Transaction.Amount.String())
+	assert.NotEmpty(t, list[0].ID)
+	assert.NotEmpty(t, list[1].ID)
+
+	list = events(t, 1, list[0].ID, "/events?holder="+holder)
+	if assert.Len(t, list, 1) {
+		assert.Equal(t, amount2.String(), list[0].Transaction.Amount.String())
+	}
+
+	resp, err := get("/events?from=invalid")
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+}
@@ -0,0 +1,493 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/service/protobuf"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	NextCursorTrailer = "next-cursor"
+)
+
+// GrpcService implements the gRPC ledger service
+type GrpcService struct {
+	protobuf.UnimplementedLedgerServiceServer
+	ledger *ledger.Ledger
+}
+
+func NewGrpcService(ledger *ledger.Ledger) *GrpcService {
+	return &GrpcService{
+		ledger: ledger,
+	}
+}
+
+func (g *GrpcService) Register(server *grpc.Server) {
+	protobuf.RegisterLedgerServiceServer(server, g)
+}
+
+func (g *GrpcService) Add(ctx context.Context, req *protobuf.AmountRequest) (*protobuf.Transaction, error) {
+	return g.amount(ctx, req, AddOperation, g.ledger.Add)
+}
+
+func (g *GrpcService) Remove(ctx context.Context, req *protobuf.AmountRequest) (*protobuf.Transaction, error) {
+	return g.amount(ctx, req, RemoveOperation, g.ledger.Remove)
+}
+
+func (g *GrpcService) amount(ctx context.Context, req *protobuf.AmountRequest, op Operation, f func(context.Context, string, types.Asset, decimal.Decimal, ...ledger.TransactionOption) (*ledger.Transaction, error)) (*protobuf.Transaction, error) {
+	if req.Holder == "" {
+		return nil, status.Error(codes.InvalidArgument, "holder is mandatory")
+	}
+
+	asset, err := g.asset(req.Asset)
+	if err != nil {
+		return nil, err
+	}
+
+	err = authorize(ctx, op, req.Holder, asset)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid amount %v: %v", req.Amount, err)
+	}
+
+	account, err := g.account(req.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []ledger.TransactionOption{}
+
+	if !account.Empty() {
+		options = append(options, ledger.Account(account))
+	}
+
+	if req.Order != "" {
+		options = append(options, ledger.OrderID(req.Order))
+	}
+
+	if req.Item != "" {
+		options = append(options, ledger.OrderItemID(req.Item))
+	}
+
+	if req.Reference != "" {
+		options = append(options, ledger.Reference(req.Reference))
+	}
+
+	if req.IdempotencyKey != "" {
+		options = append(options, ledger.IdempotencyKey(req.IdempotencyKey))
+	}
+
+	tx, err := f(ctx, req.Holder, asset, amount, options...)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return g.transaction(tx), nil
+}
+
+func (g *GrpcService) Cancel(ctx context.Context, req *protobuf.TransactionRequest) (*protobuf.Transaction, error) {
+	in, err := g.in(req.Holder, req.Asset, req.Account, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = authorize(ctx, CancelOperation, in.Holder, in.Asset)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	tx, err := g.ledger.Cancel(ctx, in.Holder, in.Asset, in.Account, in.ID)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return g.transaction(tx), nil
+}
+
+func (g *GrpcService) ChangeStatus(ctx context.Context, req *protobuf.StatusRequest) (*protobuf.Transaction, error) {
+	in, err := g.in(req.Holder, req.Asset, req.Account, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := g.status(req.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	if s == types.AllStatuses {
+		return nil, status.Error(codes.InvalidArgument, "status is mandatory")
+	}
+
+	err = authorize(ctx, StatusOperation, in.Holder, in.Asset)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	tx, err := g.ledger.Status(ctx, in, s)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return g.transaction(tx), nil
+}
+
+func (g *GrpcService) Balance(ctx context.Context, req *protobuf.BalanceRequest) (*protobuf.BalanceResponse, error) {
+	if req.Holder == "" {
+		return nil, status.Error(codes.InvalidArgument, "holder is mandatory")
+	}
+
+	asset, err := g.asset(req.Asset)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := g.account(req.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := g.status(req.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	if asset == types.AllAssets {
+		err = authorizeHolder(ctx, ReadOperation, req.Holder)
+	} else {
+		err = authorize(ctx, ReadOperation, req.Holder, asset)
+	}
+
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	balances, err := g.ledger.Balance(ctx, req.Holder, asset, account, s)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	if len(balances) == 0 {
+		return nil, status.Errorf(codes.NotFound, "no accounts for holder %v found", req.Holder)
+	}
+
+	resp := &protobuf.BalanceResponse{}
+
+	for asset, b := range balances {
+		if !permitted(ctx, ReadOperation, req.Holder, asset) {
+			continue
+		}
+
+		balance := &protobuf.Balance{
+			Asset:     asset.String(),
+			Count:     uint64(b.Count),
+			Sum:       b.Sum.String(),
+			Available: b.Available.String(),
+			Pending:   b.Pending().String(),
+			Finished:  b.Finished().String(),
+			Canceled:  b.Canceled().String(),
+			Held:      b.Held.String(),
+		}
+
+		for account, a := range b.Accounts {
+			balance.Accounts = append(balance.Accounts, &protobuf.AccountBalance{
+				ID:        account.String(),
+				Count:     uint64(a.Count),
+				Sum:       a.Sum.String(),
+				Available: a.Available.String(),
+				Pending:   a.Pending().String(),
+				Finished:  a.Finished().String(),
+				Canceled:  a.Canceled().String(),
+				Held:      a.Held.String(),
+			})
+		}
+
+		sort.Slice(balance.Accounts, func(i, j int) bool { return balance.Accounts[i].ID < balance.Accounts[j].ID })
+
+		resp.Balances = append(resp.Balances, balance)
+	}
+
+	sort.Slice(resp.Balances, func(i, j int) bool { return resp.Balances[i].Asset < resp.Balances[j].Asset })
+
+	return resp, nil
+}
+
+// Transactions streams the transactions of a holder, the cursor of the next
+// page is returned in the next-cursor trailer
+func (g *GrpcService) Transactions(req *protobuf.TransactionsRequest, stream protobuf.LedgerService_TransactionsServer) error {
+	if req.Holder == "" {
+		return status.Error(codes.InvalidArgument, "holder is mandatory")
+	}
+
+	asset, err := g.asset(req.Asset)
+	if err != nil {
+		return err
+	}
+
+	account, err := g.account(req.Account)
+	if err != nil {
+		return err
+	}
+
+	err = authorize(stream.Context(), ReadOperation, req.Holder, asset)
+	if err != nil {
+		return grpcError(err)
+	}
+
+	if req.Limit < 0 {
+		return status.Errorf(codes.InvalidArgument, "invalid limit %v", req.Limit)
+	}
+
+	page := ledger.NewPage()
+	page.Limit = int(req.Limit)
+	page.Cursor = req.Cursor
+	page.Desc = req.Desc
+	page.Order = req.Order
+
+	page.Status, err = g.status(req.Status)
+	if err != nil {
+		return err
+	}
+
+	if req.From != nil {
+		page.From = req.From.AsTime()
+	}
+
+	if req.To != nil {
+		page.To = req.To.AsTime()
+	}
+
+	next, err := g.ledger.TransactionsPage(stream.Context(), req.Holder, asset, account, page, func(ctx context.Context, tx *ledger.Transaction) (bool, error) {
+		err := stream.Send(g.transaction(tx))
+		return err == nil, err
+	})
+
+	if err != nil {
+		return grpcError(err)
+	}
+
+	if next != "" {
+		stream.SetTrailer(metadata.Pairs(NextCursorTrailer, next))
+	}
+
+	return nil
+}
+
+func (g *GrpcService) History(ctx context.Context, req *protobuf.TransactionRequest) (*protobuf.HistoryResponse, error) {
+	in, err := g.in(req.Holder, req.Asset, req.Account, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = authorize(ctx, ReadOperation, in.Holder, in.Asset)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	resp := &protobuf.HistoryResponse{}
+
+	err = g.ledger.History(ctx, in.ID, func(ctx context.Context, tx *ledger.Transaction) (bool, error) {
+		if tx.Holder != in.Holder || tx.Asset != in.Asset || tx.Account != in.Account || tx.ID != in.ID {
+			return false, ledger.NewError(ledger.BadRequestError, "invalid holder/asset/account/id for tx history: %v", in.ID)
+		}
+
+		resp.Transactions = append(resp.Transactions, g.transaction(tx))
+		return true, nil
+	})
+
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return resp, nil
+}
+
+func (g *GrpcService) Holders(ctx context.Context, req *protobuf.HoldersRequest) (*protobuf.HoldersResponse, error) {
+	holders := map[string]*protobuf.Holder{}
+
+	err := g.ledger.Holders(ctx, func(holder string, account types.Account, asset types.Asset) (bool, error) {
+		if !permitted(ctx, ReadOperation, holder, asset) {
+			return true, nil
+		}
+
+		h, ok := holders[holder]
+		if !ok {
+			h = &protobuf.Holder{
+				Name: holder,
+			}
+
+			holders[holder] = h
+		}
+
+		h.Accounts = append(h.Accounts, &protobuf.Account{
+			Account: account.String(),
+			Asset:   asset.String(),
+		})
+
+		return true, nil
+	})
+
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	resp := &protobuf.HoldersResponse{}
+	for _, h := range holders {
+		resp.Holders = append(resp.Holders, h)
+	}
+
+	sort.Slice(resp.Holders, func(i, j int) bool { return resp.Holders[i].Name < resp.Holders[j].Name })
+
+	return resp, nil
+}
+
+func (g *GrpcService) transaction(tx *ledger.Transaction) *protobuf.Transaction {
+	t := &protobuf.Transaction{
+		ID:        tx.ID.String(),
+		Account:   tx.Account.String(),
+		Holder:    tx.Holder,
+		Order:     tx.Order,
+		Item:      tx.Item,
+		Journal:   tx.Journal,
+		Asset:     tx.Asset.String(),
+		Amount:    tx.Amount.String(),
+		Status:    tx.Status.String(g.ledger.SupportedStatus()),
+		Reference: tx.Reference,
+		User:      tx.User,
+	}
+
+	if tx.Created != nil {
+		t.Created = timestamppb.New(*tx.Created)
+	}
+
+	if tx.Modified != nil {
+		t.Modified = timestamppb.New(*tx.Modified)
+	}
+
+	return t
+}
+
+// in parses a reference to a single transaction, all parts are mandatory
+func (g *GrpcService) in(holder string, asset string, account string, id string) (*ledger.Transaction, error) {
+	if holder == "" {
+		return nil, status.Error(codes.InvalidArgument, "holder is mandatory")
+	}
+
+	a, err := g.asset(asset)
+	if err != nil {
+		return nil, err
+	}
+
+	if a == types.AllAssets {
+		return nil, status.Error(codes.InvalidArgument, "asset is mandatory")
+	}
+
+	acc, err := g.account(account)
+	if err != nil {
+		return nil, err
+	}
+
+	if acc.Empty() {
+		return nil, status.Error(codes.InvalidArgument, "account is mandatory")
+	}
+
+	guid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "transaction id is invalid: %v", err)
+	}
+
+	tx := &ledger.Transaction{
+		ID:      types.ID{UUID: guid},
+		Holder:  holder,
+		Asset:   a,
+		Account: acc,
+	}
+
+	if tx.ID.IsEmpty() {
+		return nil, status.Error(codes.InvalidArgument, "transaction id is empty")
+	}
+
+	return tx, nil
+}
+
+func (g *GrpcService) asset(asset string) (types.Asset, error) {
+	a, err := g.ledger.SupportedAssets().Parse(asset)
+	if err != nil {
+		return types.AllAssets, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return a, nil
+}
+
+func (g *GrpcService) account(account string) (types.Account, error) {
+	a := types.Account(account)
+	if !a.Empty() && !a.Check() {
+		return types.AllAccounts, status.Errorf(codes.InvalidArgument, "invalid checksum for account %v", account)
+	}
+
+	return a, nil
+}
+
+func (g *GrpcService) status(s string) (types.Status, error) {
+	if s == "" {
+		return types.AllStatuses, nil
+	}
+
+	value, err := g.ledger.SupportedStatus().Parse(s)
+	if err != nil {
+		return types.AllStatuses, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return value, nil
+}
+
+// grpcError maps the ledger error codes to gRPC status codes
+func grpcError(err error) error {
+	lerr, ok := err.(ledger.Error)
+	if !ok {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	var code codes.Code
+
+	switch lerr.Code() {
+	case ledger.AccountNotFoundError, ledger.NotFoundError:
+		code = codes.NotFound
+	case ledger.TooManyAccountsError, ledger.NotEnoughAssetsError, ledger.InvalidStatusError, ledger.NotAcceptable:
+		code = codes.FailedPrecondition
+	case ledger.VerificationError:
+		code = codes.DataLoss
+	case ledger.BadRequestError, ledger.InvalidAmountError:
+		code = codes.InvalidArgument
+	case ledger.UnauthorizedError:
+		code = codes.Unauthenticated
+	case ledger.ForbiddenError:
+		code = codes.PermissionDenied
+	case ledger.ConflictError:
+		code = codes.Aborted
+	case ledger.InternalError:
+		code = codes.Internal
+	default:
+		code = codes.Unknown
+	}
+
+	return status.Error(code, lerr.Error())
+}
@@ -0,0 +1,125 @@
+package service_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/ec-systems/core.ledger.server/pkg/service"
+	"github.com/ec-systems/core.ledger.server/pkg/service/protobuf"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+func Test_Grpc(t *testing.T) {
+	ctx := context.Background()
+
+	conn, err := grpc.Dial(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer conn.Close()
+
+	c := protobuf.NewLedgerServiceClient(conn)
+
+	holder := randomName()
+	asset := randomAsset().String()
+
+	tx1, err := c.Add(ctx, &protobuf.AmountRequest{Holder: holder, Asset: asset, Amount: "3"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "3", tx1.Amount)
+	assert.Equal(t, types.Created.String(types.DefaultStatusMap), tx1.Status)
+
+	_, err = c.Remove(ctx, &protobuf.AmountRequest{Holder: holder, Asset: asset, Amount: "5"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = c.Add(ctx, &protobuf.AmountRequest{Holder: holder, Asset: "invalid", Amount: "1"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	tx2, err := c.Remove(ctx, &protobuf.AmountRequest{Holder: holder, Asset: asset, Amount: "1", Account: tx1.Account})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	finished := types.Finished.String(types.DefaultStatusMap)
+
+	tx1, err = c.ChangeStatus(ctx, &protobuf.StatusRequest{Holder: holder, Asset: asset, Account: tx1.Account, ID: tx1.ID, Status: finished})
+	if assert.NoError(t, err) {
+		assert.Equal(t, finished, tx1.Status)
+	}
+
+	_, err = c.Cancel(ctx, &protobuf.TransactionRequest{Holder: holder, Asset: asset, Account: tx2.Account, ID: tx2.ID})
+	assert.NoError(t, err)
+
+	balance, err := c.Balance(ctx, &protobuf.BalanceRequest{Holder: holder})
+	if assert.NoError(t, err) && assert.Len(t, balance.Balances, 1) {
+		assert.Equal(t, asset, balance.Balances[0].Asset)
+		assert.Equal(t, "3", balance.Balances[0].Sum)
+	}
+
+	_, err = c.Balance(ctx, &protobuf.BalanceRequest{Holder: randomName()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	history, err := c.History(ctx, &protobuf.TransactionRequest{Holder: holder, Asset: asset, Account: tx1.Account, ID: tx1.ID})
+	if assert.NoError(t, err) && assert.Len(t, history.Transactions, 2) {
+		assert.Equal(t, finished, history.Transactions[1].Status)
+	}
+
+	stream, err := c.Transactions(ctx, &protobuf.TransactionsRequest{Holder: holder, Asset: asset, Limit: 2})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	txs := []*protobuf.Transaction{}
+	for {
+		tx, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		txs = append(txs, tx)
+	}
+
+	assert.Len(t, txs, 2)
+	cursor := stream.Trailer().Get(service.NextCursorTrailer)
+	if assert.Len(t, cursor, 1) {
+		stream, err := c.Transactions(ctx, &protobuf.TransactionsRequest{Holder: holder, Asset: asset, Cursor: cursor[0]})
+		if assert.NoError(t, err) {
+			cnt := 0
+			for {
+				_, err := stream.Recv()
+				if err != nil {
+					assert.Equal(t, io.EOF, err)
+					break
+				}
+				cnt++
+			}
+
+			// the add, the remove and the cancel of the remove
+			assert.Equal(t, 1, cnt)
+			assert.Empty(t, stream.Trailer().Get(service.NextCursorTrailer))
+		}
+	}
+
+	holders, err := c.Holders(ctx, &protobuf.HoldersRequest{})
+	if assert.NoError(t, err) {
+		found := false
+		for _, h := range holders.Holders {
+			found = found || h.Name == holder
+		}
+
+		assert.True(t, found)
+	}
+}
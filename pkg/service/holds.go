@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type HoldsService struct {
+	chi.Router
+	ledger *ledger.Ledger
+}
+
+func NewHoldsService(ledger *ledger.Ledger) chi.Router {
+	router := chi.NewRouter()
+	svc := &HoldsService{
+		Router: router,
+		ledger: ledger,
+	}
+
+	// reserve assets of a holder
+	router.Post("/", svc.hold)
+	// list the holds of a holder
+	router.Get("/", svc.holds)
+	// show a hold
+	router.Get("/{id}", svc.get)
+	// debit the held assets
+	router.Post("/{id}/capture", svc.capture)
+	// release the held assets
+	router.Post("/{id}/void", svc.void)
+
+	return svc
+}
+
+// @Summary      Hold Assets
+// @Description  Reserve assets of a holder, the held amount is no longer available until the hold is captured, voided or expired
+// @Tags         Holds
+// @Accept       json
+// @Produce      json
+// @Param        hold  	body      	service.HoldRequest  true  	"Hold"
+// @Success      200  {object}  service.Hold
+// @Failure      400
+// @Failure      404
+// @Failure      406
+// @Failure      500
+// @Router       /holds/ [post]
+func (h *HoldsService) hold(w http.ResponseWriter, r *http.Request) {
+	req := &HoldRequest{}
+
+	err := json.NewDecoder(r.Body).Decode(req)
+	if err != nil {
+		http.Error(w, "invalid hold request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.Holder == "" {
+		http.Error(w, "holder is mandatory", http.StatusBadRequest)
+		return
+	}
+
+	asset, err := h.ledger.SupportedAssets().Parse(req.Asset)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if asset == types.AllAssets {
+		http.Error(w, "asset is mandatory", http.StatusBadRequest)
+		return
+	}
+
+	if isError(w, authorize(r.Context(), RemoveOperation, req.Holder, asset)) {
+		return
+	}
+
+	options := []ledger.TransactionOption{}
+
+	account := types.Account(req.Account)
+	if !account.Empty() {
+		if !account.Check() {
+			http.Error(w, fmt.Sprintf("invalid checksum for account %v", account), http.StatusBadRequest)
+			return
+		}
+
+		options = append(options, ledger.Account(account))
+	}
+
+	if req.Order != "" {
+		options = append(options, ledger.OrderID(req.Order))
+	}
+
+	if req.Reference != "" {
+		options = append(options, ledger.Reference(req.Reference))
+	}
+
+	var expires time.Time
+	if req.Expires != nil {
+		expires = *req.Expires
+	}
+
+	hold, err := h.ledger.Hold(r.Context(), req.Holder, asset, req.Amount, expires, options...)
+	if isError(w, err) {
+		return
+	}
+
+	output := &Hold{}
+	output.Set(hold)
+	render.JSON(w, r, output)
+}
+
+// @Summary      List Holds
+// @Description  List all holds of a holder
+// @Tags         Holds
+// @Produce      json
+// @Param        holder   	query      	string  true  	"Holder"
+// @Param        asset   	query      	string  false  	"Asset"
+// @Success      200  {array}  service.Hold
+// @Failure      400
+// @Failure      404
+// @Failure      500
+// @Router       /holds/ [get]
+func (h *HoldsService) holds(w http.ResponseWriter, r *http.Request) {
+	holder := r.URL.Query().Get("holder")
+	if holder == "" {
+		http.Error(w, "holder is mandatory", http.StatusBadRequest)
+		return
+	}
+
+	var err error
+
+	asset := types.AllAssets
+	if a := r.URL.Query().Get("asset"); a != "" {
+		tmp, err := h.ledger.SupportedAssets().Parse(a)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		asset = tmp
+	}
+
+	if asset == types.AllAssets {
+		err = authorizeHolder(r.Context(), ReadOperation, holder)
+	} else {
+		err = authorize(r.Context(), ReadOperation, holder, asset)
+	}
+
+	if isError(w, err) {
+		return
+	}
+
+	output := []*Hold{}
+
+	err = h.ledger.Holds(r.Context(), holder, asset, types.AllAccounts, func(ctx context.Context, hold *ledger.Hold) (bool, error) {
+		if !permitted(r.Context(), ReadOperation, hold.Holder, hold.Asset) {
+			return true, nil
+		}
+
+		o := &Hold{}
+		o.Set(hold)
+		output = append(output, o)
+		return true, nil
+	})
+
+	if isError(w, err) {
+		return
+	}
+
+	render.JSON(w, r, output)
+}
+
+// @Summary      Show Hold
+// @Description  Show a hold
+// @Tags         Holds
+// @Produce      json
+// @Param        id   		path      	string  true  	"Hold ID"
+// @Success      200  {object}  service.Hold
+// @Failure      400
+// @Failure      404
+// @Failure      500
+// @Router       /holds/{id} [get]
+func (h *HoldsService) get(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.id(w, r)
+	if !ok {
+		return
+	}
+
+	hold, err := h.ledger.GetHold(r.Context(), id)
+	if isError(w, err) {
+		return
+	}
+
+	if isError(w, authorize(r.Context(), ReadOperation, hold.Holder, hold.Asset)) {
+		return
+	}
+
+	output := &Hold{}
+	output.Set(hold)
+	render.JSON(w, r, output)
+}
+
+// @Summary      Capture Hold
+// @Description  Debit the held assets, the hold is released in the same ledger transaction
+// @Tags         Holds
+// @Produce      json
+// @Param        id   		path      	string  true  	"Hold ID"
+// @Success      200  {object}  service.Capture
+// @Failure      400
+// @Failure      404
+// @Failure      500
+// @Router       /holds/{id}/capture [post]
+func (h *HoldsService) capture(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.id(w, r)
+	if !ok {
+		return
+	}
+
+	if !h.authorize(w, r, id, RemoveOperation) {
+		return
+	}
+
+	hold, tx, err := h.ledger.Capture(r.Context(), id)
+	if isError(w, err) {
+		return
+	}
+
+	output := &Capture{}
+	output.Hold.Set(hold)
+	output.Transaction.Set(h.ledger, tx)
+	render.JSON(w, r, output)
+}
+
+// @Summary      Void Hold
+// @Description  Release the held assets without a debit
+// @Tags         Holds
+// @Produce      json
+// @Param        id   		path      	string  true  	"Hold ID"
+// @Success      200  {object}  service.Hold
+// @Failure      400
+// @Failure      404
+// @Failure      500
+// @Router       /holds/{id}/void [post]
+func (h *HoldsService) void(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.id(w, r)
+	if !ok {
+		return
+	}
+
+	if !h.authorize(w, r, id, CancelOperation) {
+		return
+	}
+
+	hold, err := h.ledger.Void(r.Context(), id)
+	if isError(w, err) {
+		return
+	}
+
+	output := &Hold{}
+	output.Set(hold)
+	render.JSON(w, r, output)
+}
+
+// authorize checks the operation against the holder and asset of the hold
+func (h *HoldsService) authorize(w http.ResponseWriter, r *http.Request, id types.ID, op Operation) bool {
+	if PrincipalFrom(r.Context()) == nil {
+		return true
+	}
+
+	hold, err := h.ledger.GetHold(r.Context(), id)
+	if isError(w, err) {
+		return false
+	}
+
+	return !isError(w, authorize(r.Context(), op, hold.Holder, hold.Asset))
+}
+
+func (h *HoldsService) id(w http.ResponseWriter, r *http.Request) (types.ID, bool) {
+	guid, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("hold id is invalid: %v", err), http.StatusBadRequest)
+		return types.ZeroID, false
+	}
+
+	return types.ID{UUID: guid}, true
+}
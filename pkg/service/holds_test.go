@@ -0,0 +1,88 @@
+package service_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/ec-systems/core.ledger.server/pkg/service"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Holds(t *testing.T) {
+	holder := randomName()
+	asset := randomAsset()
+
+	amount1, _ := decimal.NewFromString("2.5")
+	amount2, _ := decimal.NewFromString("2")
+
+	resp, err := put("/accounts/%v/%v/%v", holder, asset, amount1)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	resp, err = post(&service.HoldRequest{Holder: holder, Asset: asset.String(), Amount: amount2}, "/holds")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	var hold service.Hold
+	err = json.NewDecoder(resp.Body).Decode(&hold)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "Held", hold.Status)
+
+	resp, err = post(&service.HoldRequest{Holder: holder, Asset: asset.String(), Amount: amount2}, "/holds")
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+
+	resp, err = get("/accounts/%v/%v", holder, asset)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	var balances []*service.Balance
+	err = json.NewDecoder(resp.Body).Decode(&balances)
+	if assert.NoError(t, err) && assert.Len(t, balances, 1) {
+		assert.Equal(t, amount2.String(), balances[0].Held.String())
+	}
+
+	resp, err = post(nil, "/holds/%v/capture", hold.ID)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	var capture service.Capture
+	err = json.NewDecoder(resp.Body).Decode(&capture)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Captured", capture.Hold.Status)
+		assert.Equal(t, capture.Transaction.ID.String(), capture.Hold.Transaction)
+		assert.Equal(t, amount2.Neg().String(), capture.Transaction.Amount.String())
+	}
+
+	resp, err = post(nil, "/holds/%v/void", hold.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+
+	resp, err = get("/holds/%v", hold.ID)
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		err = json.NewDecoder(resp.Body).Decode(&hold)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "Captured", hold.Status)
+		}
+	}
+
+	resp, err = get("/holds?holder=%v", holder)
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		var holds []*service.Hold
+		err = json.NewDecoder(resp.Body).Decode(&holds)
+		if assert.NoError(t, err) {
+			assert.Len(t, holds, 1)
+		}
+	}
+}
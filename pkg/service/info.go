@@ -28,7 +28,7 @@ func NewInfoService(ledger *ledger.Ledger) chi.Router {
 }
 
 // @Summary      Supported Assets
-// @Description  List of the assets supported by the ledger
+// @Description  List of the assets supported by the ledger with their precision and amount limits
 // @Tags         Info
 // @Produce      json
 // @Success      200  {array}  service.Asset
@@ -38,10 +38,9 @@ func (i *InfoService) assets(w http.ResponseWriter, r *http.Request) {
 	al := Assets{}
 
 	for k, v := range assets {
-		al = append(al, Asset{
-			Symbol: k.String(),
-			Name:   v,
-		})
+		asset := Asset{}
+		asset.Set(k, v)
+		al = append(al, asset)
 	}
 
 	sort.Sort(al)
@@ -50,17 +49,23 @@ func (i *InfoService) assets(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary      Supported Statuses
-// @Description  List of the statuses supported by the ledger
+// @Description  List of the statuses supported by the ledger with the allowed status changes
 // @Tags         Info
 // @Produce      json
 // @Success      200  {array}  service.Statuses
 // @Router       /info/statuses [get]
 func (i *InfoService) status(w http.ResponseWriter, r *http.Request) {
 	statuses := i.ledger.SupportedStatus()
+	transitions := i.ledger.StatusTransitions()
 	sl := Statuses{}
 
 	for k, v := range statuses {
-		sl = append(sl, Status{int(v), k})
+		sl = append(sl, Status{
+			ID:          int(v),
+			Name:        k,
+			Transitions: transitions.Next(statuses, v),
+			Terminal:    transitions.Terminal(statuses, v),
+		})
 	}
 
 	sort.Sort(sl)
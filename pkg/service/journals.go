@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type JournalsService struct {
+	chi.Router
+	ledger *ledger.Ledger
+}
+
+func NewJournalsService(ledger *ledger.Ledger) chi.Router {
+	router := chi.NewRouter()
+	svc := &JournalsService{
+		Router: router,
+		ledger: ledger,
+	}
+
+	// book a multi-leg journal entry
+	router.Post("/", svc.journal)
+	// list the legs of a journal
+	router.Get("/{id}", svc.legs)
+
+	return svc
+}
+
+// @Summary      Book Journal
+// @Description  Book a multi-leg journal entry, the legs have to sum up to zero per asset
+// @Tags         Journals
+// @Accept       json
+// @Produce      json
+// @Param        journal  	body      	service.JournalRequest  true  	"Journal"
+// @Success      200  {object}  service.Journal
+// @Failure      400
+// @Failure      404
+// @Failure      406
+// @Failure      500
+// @Router       /journals/ [post]
+func (j *JournalsService) journal(w http.ResponseWriter, r *http.Request) {
+	req := &JournalRequest{}
+
+	err := json.NewDecoder(r.Body).Decode(req)
+	if err != nil {
+		http.Error(w, "invalid journal request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	legs := []ledger.JournalLeg{}
+
+	for i, leg := range req.Legs {
+		if leg.Holder == "" {
+			http.Error(w, fmt.Sprintf("holder of leg %v is mandatory", i), http.StatusBadRequest)
+			return
+		}
+
+		asset, err := j.ledger.SupportedAssets().Parse(leg.Asset)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if asset == types.AllAssets {
+			http.Error(w, fmt.Sprintf("asset of leg %v is mandatory", i), http.StatusBadRequest)
+			return
+		}
+
+		account := types.Account(leg.Account)
+		if !account.Empty() && !account.Check() {
+			http.Error(w, fmt.Sprintf("invalid checksum for account %v", account), http.StatusBadRequest)
+			return
+		}
+
+		op := AddOperation
+		if leg.Amount.IsNegative() {
+			op = RemoveOperation
+		}
+
+		if isError(w, authorize(r.Context(), op, leg.Holder, asset)) {
+			return
+		}
+
+		legs = append(legs, ledger.JournalLeg{
+			Holder:  leg.Holder,
+			Account: account,
+			Asset:   asset,
+			Amount:  leg.Amount,
+		})
+	}
+
+	options := []ledger.TransactionOption{}
+
+	if req.Order != "" {
+		options = append(options, ledger.OrderID(req.Order))
+	}
+
+	if req.Reference != "" {
+		options = append(options, ledger.Reference(req.Reference))
+	}
+
+	journal, err := j.ledger.Journal(r.Context(), legs, options...)
+	if isError(w, err) {
+		return
+	}
+
+	output := &Journal{
+		ID:   journal.ID.UUID,
+		Legs: []*Transaction{},
+	}
+
+	for _, tx := range journal.Legs {
+		leg := &Transaction{}
+		leg.Set(j.ledger, tx)
+		output.Legs = append(output.Legs, leg)
+	}
+
+	render.JSON(w, r, output)
+}
+
+// @Summary      Show Journal
+// @Description  List all legs of a journal
+// @Tags         Journals
+// @Produce      json
+// @Param        id   		path      	string  true  	"Journal ID"
+// @Success      200  {object}  service.Journal
+// @Failure      400
+// @Failure      404
+// @Failure      500
+// @Router       /journals/{id} [get]
+func (j *JournalsService) legs(w http.ResponseWriter, r *http.Request) {
+	guid, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("journal id is invalid: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	output := &Journal{
+		ID:   guid,
+		Legs: []*Transaction{},
+	}
+
+	err = j.ledger.JournalLegs(r.Context(), types.ID{UUID: guid}, func(ctx context.Context, tx *ledger.Transaction) (bool, error) {
+		if err := authorize(r.Context(), ReadOperation, tx.Holder, tx.Asset); err != nil {
+			return false, err
+		}
+
+		leg := &Transaction{}
+		leg.Set(j.ledger, tx)
+		output.Legs = append(output.Legs, leg)
+		return true, nil
+	})
+
+	if isError(w, err) {
+		return
+	}
+
+	if len(output.Legs) == 0 {
+		http.Error(w, fmt.Sprintf("journal %v not found", guid), http.StatusNotFound)
+		return
+	}
+
+	render.JSON(w, r, output)
+}
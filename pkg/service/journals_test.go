@@ -0,0 +1,99 @@
+package service_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/ec-systems/core.ledger.server/pkg/service"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Journal(t *testing.T) {
+	from := randomName()
+	to := from + "_to"
+	fee := from + "_fee"
+	asset := randomAsset()
+	ref := randomName()
+
+	amount1, _ := decimal.NewFromString("2.5")
+	amount2, _ := decimal.NewFromString("2")
+	amount3, _ := decimal.NewFromString("0.5")
+
+	resp, err := put("/accounts/%v/%v/%v", from, asset, amount1)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	resp, err = post(&service.JournalRequest{
+		Reference: ref,
+		Legs: []service.JournalLegRequest{
+			{Holder: from, Asset: asset.String(), Amount: amount1.Neg()},
+			{Holder: to, Asset: asset.String(), Amount: amount2},
+		},
+	}, "/journals")
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+
+	resp, err = post(&service.JournalRequest{
+		Reference: ref,
+		Legs: []service.JournalLegRequest{
+			{Holder: from, Asset: asset.String(), Amount: amount1.Neg()},
+			{Holder: to, Asset: asset.String(), Amount: amount2},
+			{Holder: fee, Asset: asset.String(), Amount: amount3},
+		},
+	}, "/journals")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	var journal service.Journal
+	err = json.NewDecoder(resp.Body).Decode(&journal)
+	if !assert.NoError(t, err) || !assert.Len(t, journal.Legs, 3) {
+		return
+	}
+
+	assert.Equal(t, from, journal.Legs[0].Holder)
+	assert.Equal(t, amount1.Neg().String(), journal.Legs[0].Amount.String())
+	assert.Equal(t, to, journal.Legs[1].Holder)
+	assert.Equal(t, amount2.String(), journal.Legs[1].Amount.String())
+	assert.Equal(t, fee, journal.Legs[2].Holder)
+	assert.Equal(t, amount3.String(), journal.Legs[2].Amount.String())
+
+	resp, err = get("/journals/%v", journal.ID)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	var legs service.Journal
+	err = json.NewDecoder(resp.Body).Decode(&legs)
+	if assert.NoError(t, err) && assert.Len(t, legs.Legs, 3) {
+		assert.Equal(t, journal.ID, legs.ID)
+		for _, leg := range legs.Legs {
+			assert.Equal(t, ref, leg.Reference)
+		}
+	}
+
+	// a leg leads back to its journal
+	resp, err = get("/transactions/%v", journal.Legs[0].ID)
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		var tx service.Transaction
+		err = json.NewDecoder(resp.Body).Decode(&tx)
+		if assert.NoError(t, err) {
+			assert.Equal(t, journal.ID.String(), tx.Journal)
+		}
+	}
+
+	resp, err = get("/accounts/%v/%v", fee, asset)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	var balances []*service.Balance
+	err = json.NewDecoder(resp.Body).Decode(&balances)
+	if assert.NoError(t, err) && assert.Len(t, balances, 1) {
+		assert.Equal(t, amount3.String(), balances[0].Sum.String())
+	}
+}
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+)
+
+// JWK is a public json web key of a JWKS file
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Use string `json:"use,omitempty"`
+
+	// RSA keys
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// EC keys
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+func LoadJWKS(file string) (*JWKS, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jwks file: %v", err)
+	}
+
+	keys := &JWKS{}
+	err = json.Unmarshal(data, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse jwks file %v: %v", file, err)
+	}
+
+	return keys, nil
+}
+
+var curves = map[string]elliptic.Curve{
+	"ES256": elliptic.P256(),
+	"ES384": elliptic.P384(),
+	"ES512": elliptic.P521(),
+}
+
+type jwtKey struct {
+	alg string
+	key crypto.PublicKey
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type jwtClaims struct {
+	Subject   string          `json:"sub"`
+	Issuer    string          `json:"iss"`
+	Audience  json.RawMessage `json:"aud"`
+	Expires   *int64          `json:"exp"`
+	NotBefore *int64          `json:"nbf"`
+}
+
+// JWTAuthenticator checks the bearer token of the authorization header
+// against the keys of a JWKS, the subject of the token is the principal.
+// RS256, RS384, RS512, ES256, ES384 and ES512 signatures are supported,
+// the token must expire.
+type JWTAuthenticator struct {
+	keys     map[string]*jwtKey
+	issuer   string
+	audience string
+}
+
+func NewJWTAuthenticator(jwks *JWKS, issuer string, audience string) (*JWTAuthenticator, error) {
+	keys := map[string]*jwtKey{}
+
+	for _, k := range jwks.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("invalid jwk %v: %v", k.Kid, err)
+		}
+
+		keys[k.Kid] = &jwtKey{
+			alg: k.Alg,
+			key: key,
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no signature keys in jwks")
+	}
+
+	return &JWTAuthenticator{
+		keys:     keys,
+		issuer:   issuer,
+		audience: audience,
+	}, nil
+}
+
+func (j *JWTAuthenticator) Authenticate(ctx context.Context, creds *Credentials) (*Principal, error) {
+	token, ok := authorization(creds.Header, "Bearer")
+	if !ok {
+		return nil, nil
+	}
+
+	subject, err := j.Verify(token, time.Now())
+	if err != nil {
+		return nil, ledger.NewError(ledger.UnauthorizedError, "invalid token: %v", err)
+	}
+
+	return &Principal{Name: subject, Method: JWTMethod}, nil
+}
+
+// Verify checks the signature and the claims of the token and returns its
+// subject
+func (j *JWTAuthenticator) Verify(token string, now time.Time) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("malformed token")
+	}
+
+	header := &jwtHeader{}
+	err := decodeSegment(parts[0], header)
+	if err != nil {
+		return "", fmt.Errorf("malformed header: %v", err)
+	}
+
+	key, ok := j.keys[header.Kid]
+	if !ok && header.Kid == "" && len(j.keys) == 1 {
+		for _, k := range j.keys {
+			key, ok = k, true
+		}
+	}
+
+	if !ok {
+		return "", fmt.Errorf("unknown key '%v'", header.Kid)
+	}
+
+	if key.alg != "" && key.alg != header.Alg {
+		return "", fmt.Errorf("algorithm %v doesn't match the key", header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("malformed signature: %v", err)
+	}
+
+	err = verifySignature(header.Alg, key.key, parts[0]+"."+parts[1], signature)
+	if err != nil {
+		return "", err
+	}
+
+	claims := &jwtClaims{}
+	err = decodeSegment(parts[1], claims)
+	if err != nil {
+		return "", fmt.Errorf("malformed claims: %v", err)
+	}
+
+	if claims.Expires == nil {
+		return "", fmt.Errorf("token without expiry")
+	}
+
+	if now.Unix() >= *claims.Expires {
+		return "", fmt.Errorf("token expired")
+	}
+
+	if claims.NotBefore != nil && now.Unix() < *claims.NotBefore {
+		return "", fmt.Errorf("token not valid yet")
+	}
+
+	if j.issuer != "" && claims.Issuer != j.issuer {
+		return "", fmt.Errorf("invalid issuer '%v'", claims.Issuer)
+	}
+
+	if j.audience != "" && !claims.audience(j.audience) {
+		return "", fmt.Errorf("invalid audience")
+	}
+
+	if claims.Subject == "" {
+		return "", fmt.Errorf("token without subject")
+	}
+
+	return claims.Subject, nil
+}
+
+func (c *jwtClaims) audience(audience string) bool {
+	var single string
+	if json.Unmarshal(c.Audience, &single) == nil {
+		return single == audience
+	}
+
+	var list []string
+	if json.Unmarshal(c.Audience, &list) == nil {
+		for _, a := range list {
+			if a == audience {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func verifySignature(alg string, key crypto.PublicKey, input string, signature []byte) error {
+	var hash crypto.Hash
+
+	switch alg {
+	case "RS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512", "ES512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported algorithm '%v'", alg)
+	}
+
+	h := hash.New()
+	h.Write([]byte(input))
+	digest := h.Sum(nil)
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return fmt.Errorf("algorithm %v doesn't match the rsa key", alg)
+		}
+
+		if rsa.VerifyPKCS1v15(k, hash, digest, signature) != nil {
+			return fmt.Errorf("invalid signature")
+		}
+	case *ecdsa.PublicKey:
+		if curves[alg] != k.Curve {
+			return fmt.Errorf("algorithm %v doesn't match the ec key", alg)
+		}
+
+		size := (k.Curve.Params().BitSize + 7) / 8
+
+		if len(signature) != 2*size {
+			return fmt.Errorf("invalid signature")
+		}
+
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+
+		if !ecdsa.Verify(k, digest, r, s) {
+			return fmt.Errorf("invalid signature")
+		}
+	default:
+		return fmt.Errorf("unsupported key type")
+	}
+
+	return nil
+}
+
+func (k *JWK) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %v", err)
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent: %v", err)
+		}
+
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		var curve elliptic.Curve
+
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve '%v'", k.Crv)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x coordinate: %v", err)
+		}
+
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid y coordinate: %v", err)
+		}
+
+		key := &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, fmt.Errorf("point not on curve %v", k.Crv)
+		}
+
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type '%v'", k.Kty)
+	}
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
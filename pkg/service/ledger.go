@@ -4,11 +4,13 @@ package service
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/ec-systems/core.ledger.server/pkg/config"
 	"github.com/ec-systems/core.ledger.server/pkg/ledger"
 	"github.com/ec-systems/core.ledger.server/pkg/logger"
 	"github.com/go-chi/chi/v5/middleware"
+	"google.golang.org/grpc"
 
 	_ "github.com/ec-systems/core.ledger.server/docs"
 	httpSwagger "github.com/swaggo/http-swagger"
@@ -17,6 +19,10 @@ import (
 type LedgerService struct {
 	svc *MTlsService
 	cfg *config.ServiceConfig
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	webhooks *WebhookWorker
 }
 
 // @title Core Ledger
@@ -33,6 +39,13 @@ func NewLedgerService(ctx context.Context, ledger *ledger.Ledger, cfg *config.Se
 		cfg: cfg,
 	}
 
+	svc.ctx, svc.cancel = context.WithCancel(ctx)
+
+	if !cfg.ReadOnly && cfg.WebhookInterval > 0 {
+		logger.Info("Enable webhook delivery")
+		svc.webhooks = NewWebhookWorker(ledger, cfg.WebhookInterval, cfg.WebhookRetries, cfg.WebhookBackoff, cfg.WebhookTimeout)
+	}
+
 	var swagger ServiceOption
 	if !cfg.Production {
 		logger.Info("Enable swagger documentation: /swagger/index.html")
@@ -45,6 +58,26 @@ func NewLedgerService(ctx context.Context, ledger *ledger.Ledger, cfg *config.Se
 		accessLogger = Use(middleware.Logger)
 	}
 
+	var policy *Policy
+	if cfg.Policy != "" {
+		var err error
+
+		logger.Infof("Enable authorization policy %v", cfg.Policy)
+		policy, err = LoadPolicy(cfg.Policy)
+		if err != nil {
+			return nil, fmt.Errorf("authorization: %v", err)
+		}
+	}
+
+	auth, err := NewLedgerAuthentication(ledger, cfg.Auth, policy)
+	if err != nil {
+		return nil, fmt.Errorf("authentication: %v", err)
+	}
+
+	if cfg.Auth != nil {
+		logger.Info("Enable authentication")
+	}
+
 	var redirect ServiceOption
 	if swagger != nil {
 		logger.Info("Enable redirect to swagger ui")
@@ -55,11 +88,22 @@ func NewLedgerService(ctx context.Context, ledger *ledger.Ledger, cfg *config.Se
 		Metrics(cfg.Metrics, "ledger"),
 		accessLogger,
 		Use(middleware.Recoverer),
+		Use(auth.Middleware("/", "/health", "/swagger/*")),
 		Device(cfg.Device),
 		Port(cfg.Port),
+		Grpc(cfg.Grpc, NewGrpcService(ledger).Register),
+		GrpcOptions(grpc.UnaryInterceptor(auth.Unary), grpc.StreamInterceptor(auth.Stream)),
 		MTls((*config.MTLsOptions)(cfg.MTls)),
 		Mount("/accounts", NewAccountsService(ledger)),
 		Mount("/assets", NewAssetsService(ledger)),
+		Mount("/transfers", NewTransfersService(ledger)),
+		Mount("/journals", NewJournalsService(ledger)),
+		Mount("/holds", NewHoldsService(ledger)),
+		Mount("/transactions", NewTransactionsService(ledger)),
+		Mount("/orders", NewOrdersService(ledger)),
+		Mount("/events", NewEventsService(ledger)),
+		Mount("/webhooks", NewWebhooksService(ledger)),
+		Mount("/apikeys", NewAPIKeysService(ledger)),
 		Mount("/info", NewInfoService(ledger)),
 		Method("GET", NewHealthService(ledger)),
 		MetricsMethod("GET", NewHealthService(ledger)),
@@ -71,10 +115,15 @@ func NewLedgerService(ctx context.Context, ledger *ledger.Ledger, cfg *config.Se
 }
 
 func (l *LedgerService) Start() chan error {
+	if l.webhooks != nil {
+		go l.webhooks.Run(l.ctx)
+	}
+
 	return l.svc.Start()
 }
 
 func (l *LedgerService) Stop(ctx context.Context) error {
+	l.cancel()
 	return l.svc.Stop(ctx)
 }
 
@@ -5,6 +5,7 @@ import (
 
 	"github.com/ec-systems/core.ledger.server/pkg/config"
 	"github.com/ec-systems/core.ledger.server/pkg/metrics"
+	"google.golang.org/grpc"
 )
 
 type ServiceOption interface {
@@ -38,6 +39,21 @@ func Metrics(port int, name string, buckets ...float64) ServiceOption {
 	})
 }
 
+func Grpc(port int, services ...func(*grpc.Server)) ServiceOption {
+	return ServiceOptionFunc(func(c *MTlsService) {
+		if port > 0 {
+			c.grpcPort = port
+			c.grpcServices = append(c.grpcServices, services...)
+		}
+	})
+}
+
+func GrpcOptions(options ...grpc.ServerOption) ServiceOption {
+	return ServiceOptionFunc(func(c *MTlsService) {
+		c.grpcOptions = append(c.grpcOptions, options...)
+	})
+}
+
 func MTls(options *config.MTLsOptions) ServiceOption {
 	return ServiceOptionFunc(func(c *MTlsService) {
 		c.mtls = options
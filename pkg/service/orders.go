@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+type OrdersService struct {
+	chi.Router
+	ledger *ledger.Ledger
+}
+
+func NewOrdersService(ledger *ledger.Ledger) chi.Router {
+	router := chi.NewRouter()
+	svc := &OrdersService{
+		Router: router,
+		ledger: ledger,
+	}
+
+	// list the orders of a holder
+	router.Get("/{holder}", svc.orders)
+	// show an order
+	router.Get("/{holder}/{order}", svc.get)
+	// show an item of an order
+	router.Get("/{holder}/{order}/{item}", svc.get)
+
+	return svc
+}
+
+// @Summary      List Orders
+// @Description  List the orders of a holder with their transactions, net amounts by asset and status
+// @Tags         Orders
+// @Produce      json
+// @Param        holder   	path      	string  true  	"Holder"
+// @Success      200  {array}  service.Order
+// @Failure      400
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /orders/{holder} [get]
+func (o *OrdersService) orders(w http.ResponseWriter, r *http.Request) {
+	holder := chi.URLParam(r, "holder")
+
+	if isError(w, authorizeHolder(r.Context(), ReadOperation, holder)) {
+		return
+	}
+
+	ids := []string{}
+	err := o.ledger.Orders(r.Context(), holder, func(ctx context.Context, tx *ledger.Transaction) (bool, error) {
+		if tx.Order != "" {
+			ids = append(ids, tx.Order)
+		}
+
+		return true, nil
+	})
+
+	if isError(w, err) {
+		return
+	}
+
+	result := []*Order{}
+	for _, id := range ids {
+		order, err := o.order(r.Context(), holder, id, "")
+		if isError(w, err) {
+			return
+		}
+
+		if len(order.Items) == 0 {
+			continue
+		}
+
+		output := &Order{}
+		output.Set(o.ledger, order)
+		result = append(result, output)
+	}
+
+	if len(result) == 0 {
+		http.Error(w, fmt.Sprintf("no orders for holder %v found", holder), http.StatusNotFound)
+		return
+	}
+
+	render.JSON(w, r, result)
+}
+
+// @Summary      Show Order
+// @Description  Show the transactions of an order or of one of its items, the net amounts by asset and the status derived from the items
+// @Tags         Orders
+// @Produce      json
+// @Param        holder   	path      	string  true  	"Holder"
+// @Param        order   	path      	string  true  	"Order ID"
+// @Param        item   	path      	string  false  	"Item ID"
+// @Success      200  {object}  service.Order
+// @Failure      400
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /orders/{holder}/{order} [get]
+// @Router       /orders/{holder}/{order}/{item} [get]
+func (o *OrdersService) get(w http.ResponseWriter, r *http.Request) {
+	holder := chi.URLParam(r, "holder")
+	id := chi.URLParam(r, "order")
+	item := chi.URLParam(r, "item")
+
+	if isError(w, authorizeHolder(r.Context(), ReadOperation, holder)) {
+		return
+	}
+
+	order, err := o.order(r.Context(), holder, id, item)
+	if isError(w, err) {
+		return
+	}
+
+	if len(order.Items) == 0 {
+		if item != "" {
+			http.Error(w, fmt.Sprintf("item %v of order %v not found", item, id), http.StatusNotFound)
+		} else {
+			http.Error(w, fmt.Sprintf("order %v not found", id), http.StatusNotFound)
+		}
+
+		return
+	}
+
+	output := &Order{}
+	output.Set(o.ledger, order)
+	render.JSON(w, r, output)
+}
+
+// order collects the transactions of the permitted assets only, the net
+// amounts and the status are derived from these transactions
+func (o *OrdersService) order(ctx context.Context, holder string, id string, item string) (*ledger.Order, error) {
+	order := ledger.NewOrder(holder, id)
+	err := o.ledger.OrderItems(ctx, holder, id, item, func(ctx context.Context, tx *ledger.Transaction) (bool, error) {
+		if permitted(ctx, ReadOperation, tx.Holder, tx.Asset) {
+			order.Add(tx)
+		}
+
+		return true, nil
+	})
+
+	return order, err
+}
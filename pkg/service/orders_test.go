@@ -0,0 +1,83 @@
+package service_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/ec-systems/core.ledger.server/pkg/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Orders(t *testing.T) {
+	holder := randomName()
+	asset := randomAsset()
+	order := randomName()
+
+	resp, err := put("/accounts/%v/%v/%v?order=%v&item=%v", holder, asset, 3, order, "001")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	resp, err = put("/accounts/%v/%v/%v?order=%v&item=%v", holder, asset, 2, order, "002")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	var tx service.Transaction
+	err = json.NewDecoder(resp.Body).Decode(&tx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	resp, err = del("/accounts/%v/%v/%v/%v", holder, asset, tx.Account, tx.ID)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	resp, err = get("/orders/%v/%v", holder, order)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	result := &service.Order{}
+	err = json.NewDecoder(resp.Body).Decode(result)
+	if assert.NoError(t, err) && assert.Len(t, result.Items, 2) && assert.Len(t, result.Net, 1) {
+		assert.Equal(t, "Open", result.Status)
+		assert.Equal(t, asset.String(), result.Net[0].Asset)
+		assert.Equal(t, "3", result.Net[0].Sum.String())
+	}
+
+	resp, err = get("/orders/%v/%v/%v", holder, order, "002")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	result = &service.Order{}
+	err = json.NewDecoder(resp.Body).Decode(result)
+	if assert.NoError(t, err) && assert.Len(t, result.Items, 1) {
+		assert.Equal(t, "Canceled", result.Status)
+		assert.Len(t, result.Items[0].Transactions, 2)
+	}
+
+	resp, err = get("/orders/%v", holder)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	orders := []*service.Order{}
+	err = json.NewDecoder(resp.Body).Decode(&orders)
+	if assert.NoError(t, err) && assert.Len(t, orders, 1) {
+		assert.Equal(t, order, orders[0].Order)
+	}
+
+	resp, err = get("/orders/%v/%v/%v", holder, order, "003")
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+
+	resp, err = get("/orders/%v", holder+"_unknown")
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+type Operation string
+
+const (
+	AddOperation    Operation = "add"
+	RemoveOperation Operation = "remove"
+	CancelOperation Operation = "cancel"
+	StatusOperation Operation = "status"
+	ReadOperation   Operation = "read"
+	AdminOperation  Operation = "admin"
+)
+
+var operations = []Operation{AddOperation, RemoveOperation, CancelOperation, StatusOperation, ReadOperation, AdminOperation}
+
+// Role allows operations on a list of assets and on the holders matching
+// one of the patterns, empty lists allow all assets or holders
+type Role struct {
+	Name       string      `yaml:"name"`
+	Operations []Operation `yaml:"operations"`
+	Assets     []string    `yaml:"assets,omitempty"`
+	Holders    []string    `yaml:"holders,omitempty"`
+}
+
+// Policy maps the principals to their roles, the keys of Principals are
+// patterns of the principal names
+type Policy struct {
+	Roles      []*Role             `yaml:"roles"`
+	Principals map[string][]string `yaml:"principals"`
+}
+
+func LoadPolicy(file string) (*Policy, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %v", err)
+	}
+
+	return ParsePolicy(data)
+}
+
+func ParsePolicy(data []byte) (*Policy, error) {
+	policy := &Policy{}
+
+	err := yaml.Unmarshal(data, policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %v", err)
+	}
+
+	return policy, policy.Validate()
+}
+
+func (p *Policy) Validate() error {
+	roles := map[string]bool{}
+
+	for _, role := range p.Roles {
+		if role.Name == "" {
+			return fmt.Errorf("role without name")
+		}
+
+		for _, op := range role.Operations {
+			if !op.valid() {
+				return fmt.Errorf("role %v: unknown operation '%v'", role.Name, op)
+			}
+		}
+
+		for _, holder := range role.Holders {
+			if _, err := path.Match(holder, ""); err != nil {
+				return fmt.Errorf("role %v: invalid holder pattern '%v'", role.Name, holder)
+			}
+		}
+
+		roles[role.Name] = true
+	}
+
+	for principal, names := range p.Principals {
+		if _, err := path.Match(principal, ""); err != nil {
+			return fmt.Errorf("invalid principal pattern '%v'", principal)
+		}
+
+		for _, name := range names {
+			if !roles[name] {
+				return fmt.Errorf("principal %v: unknown role '%v'", principal, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Grants returns the roles of all principal patterns matching the name
+func (p *Policy) Grants(name string) Grants {
+	names := map[string]bool{}
+	for pattern, roles := range p.Principals {
+		if ok, _ := path.Match(pattern, name); ok && name != "" {
+			for _, role := range roles {
+				names[role] = true
+			}
+		}
+	}
+
+	grants := Grants{}
+	for _, role := range p.Roles {
+		if names[role.Name] {
+			grants = append(grants, role)
+		}
+	}
+
+	return grants
+}
+
+// Grants are the roles of a principal
+type Grants []*Role
+
+// Allow checks if one of the roles allows the operation, an empty holder
+// stands for all holders and AllAssets for all assets
+func (g Grants) Allow(op Operation, holder string, asset types.Asset) bool {
+	for _, role := range g {
+		if role.operation(op) && role.holder(holder) && role.asset(asset) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllowHolder checks if one of the roles allows the operation on at least
+// one asset of the holder
+func (g Grants) AllowHolder(op Operation, holder string) bool {
+	for _, role := range g {
+		if role.operation(op) && role.holder(holder) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllowOperation checks if one of the roles allows the operation regardless
+// of holders and assets
+func (g Grants) AllowOperation(op Operation) bool {
+	for _, role := range g {
+		if role.operation(op) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Role) operation(op Operation) bool {
+	for _, o := range r.Operations {
+		if o == op {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Role) holder(holder string) bool {
+	if len(r.Holders) == 0 {
+		return true
+	}
+
+	if holder == "" {
+		return false
+	}
+
+	for _, pattern := range r.Holders {
+		if ok, _ := path.Match(pattern, holder); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Role) asset(asset types.Asset) bool {
+	if len(r.Assets) == 0 {
+		return true
+	}
+
+	for _, a := range r.Assets {
+		if strings.EqualFold(a, asset.String()) && asset != types.AllAssets {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (o Operation) valid() bool {
+	for _, op := range operations {
+		if o == op {
+			return true
+		}
+	}
+
+	return false
+}
+
+// authorize checks if the principal of the context is allowed to run the
+// operation, all operations are allowed without a policy
+func authorize(ctx context.Context, op Operation, holder string, asset types.Asset) error {
+	principal := PrincipalFrom(ctx)
+	if principal == nil || principal.Grants == nil {
+		return nil
+	}
+
+	if principal.Grants.Allow(op, holder, asset) {
+		return nil
+	}
+
+	target := "all holders"
+	if holder != "" {
+		target = "holder " + holder
+	}
+
+	if asset != types.AllAssets {
+		target = fmt.Sprintf("%v of %v", asset, target)
+	}
+
+	return ledger.NewError(ledger.ForbiddenError, "%v is not allowed to %v %v", principal.name(), op, target)
+}
+
+// authorizeHolder checks if the principal of the context is allowed to run
+// the operation on any asset of the holder
+func authorizeHolder(ctx context.Context, op Operation, holder string) error {
+	principal := PrincipalFrom(ctx)
+	if principal == nil || principal.Grants == nil || principal.Grants.AllowHolder(op, holder) {
+		return nil
+	}
+
+	return authorize(ctx, op, holder, types.AllAssets)
+}
+
+// authorizeOperation is a middleware for operations without holders and
+// assets like the administration of webhooks and api keys
+func authorizeOperation(op Operation) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal := PrincipalFrom(r.Context())
+			if principal != nil && !principal.allowOperation(op) {
+				isError(w, ledger.NewError(ledger.ForbiddenError, "%v is not allowed to %v", principal.name(), op))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// permitted filters the items of a listing
+func permitted(ctx context.Context, op Operation, holder string, asset types.Asset) bool {
+	return authorize(ctx, op, holder, asset) == nil
+}
+
+// allowOperation checks the grants of the principal, without a policy the
+// administration needs one of the statically configured api keys
+func (p *Principal) allowOperation(op Operation) bool {
+	if p.Grants != nil {
+		return p.Grants.AllowOperation(op)
+	}
+
+	return op != AdminOperation || p.Admin
+}
+
+func (p *Principal) name() string {
+	if p.Name == "" {
+		return "anonymous"
+	}
+
+	return p.Name
+}
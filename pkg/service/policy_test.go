@@ -0,0 +1,204 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ec-systems/core.ledger.server/pkg/config"
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/service"
+	"github.com/ec-systems/core.ledger.server/pkg/service/protobuf"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var testPolicy = `
+roles:
+  - name: reader
+    operations: [read]
+  - name: teller
+    operations: [add, remove]
+    assets: [BTC, eth]
+    holders: ["customer-*"]
+  - name: operator
+    operations: [cancel, status]
+    holders: ["customer-1"]
+  - name: admin
+    operations: [admin]
+principals:
+  alice: [reader, teller]
+  "ops-*": [operator]
+  root: [admin, reader]
+`
+
+func policy(t *testing.T) *service.Policy {
+	p, err := service.ParsePolicy([]byte(testPolicy))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	return p
+}
+
+func Test_PolicyOperations(t *testing.T) {
+	grants := policy(t).Grants("alice")
+
+	assert.True(t, grants.Allow(service.ReadOperation, "bob", types.Asset("XRP")))
+	assert.True(t, grants.Allow(service.AddOperation, "customer-1", types.Asset("BTC")))
+	assert.True(t, grants.Allow(service.RemoveOperation, "customer-1", types.Asset("BTC")))
+	assert.False(t, grants.Allow(service.CancelOperation, "customer-1", types.Asset("BTC")))
+	assert.False(t, grants.Allow(service.StatusOperation, "customer-1", types.Asset("BTC")))
+	assert.False(t, grants.AllowOperation(service.AdminOperation))
+}
+
+func Test_PolicyAssets(t *testing.T) {
+	grants := policy(t).Grants("alice")
+
+	assert.True(t, grants.Allow(service.AddOperation, "customer-1", types.Asset("BTC")))
+	assert.True(t, grants.Allow(service.AddOperation, "customer-1", types.Asset("ETH")))
+	assert.False(t, grants.Allow(service.AddOperation, "customer-1", types.Asset("XRP")))
+	assert.False(t, grants.Allow(service.AddOperation, "customer-1", types.AllAssets))
+
+	// the reader role isn't restricted to assets
+	assert.True(t, grants.Allow(service.ReadOperation, "customer-1", types.AllAssets))
+}
+
+func Test_PolicyHolders(t *testing.T) {
+	grants := policy(t).Grants("alice")
+
+	assert.True(t, grants.Allow(service.AddOperation, "customer-42", types.Asset("BTC")))
+	assert.False(t, grants.Allow(service.AddOperation, "vendor-42", types.Asset("BTC")))
+	assert.False(t, grants.Allow(service.AddOperation, "", types.Asset("BTC")))
+	assert.True(t, grants.AllowHolder(service.AddOperation, "customer-42"))
+	assert.False(t, grants.AllowHolder(service.AddOperation, "vendor-42"))
+
+	grants = policy(t).Grants("ops-eu")
+	assert.True(t, grants.Allow(service.CancelOperation, "customer-1", types.Asset("XRP")))
+	assert.False(t, grants.Allow(service.CancelOperation, "customer-2", types.Asset("XRP")))
+}
+
+func Test_PolicyPrincipals(t *testing.T) {
+	p := policy(t)
+
+	assert.Len(t, p.Grants("alice"), 2)
+	assert.Len(t, p.Grants("ops-eu"), 1)
+	assert.Len(t, p.Grants("ops-us"), 1)
+	assert.Len(t, p.Grants("root"), 2)
+	assert.True(t, p.Grants("root").AllowOperation(service.AdminOperation))
+
+	// unknown and anonymous principals have no roles
+	assert.NotNil(t, p.Grants("mallory"))
+	assert.Empty(t, p.Grants("mallory"))
+	assert.Empty(t, p.Grants(""))
+	assert.False(t, p.Grants("").Allow(service.ReadOperation, "customer-1", types.Asset("BTC")))
+}
+
+func Test_PolicyValidation(t *testing.T) {
+	tests := map[string]string{
+		"unknown operation": "roles: [{name: r, operations: [delete]}]",
+		"role without name": "roles: [{operations: [read]}]",
+		"holder pattern":    "roles: [{name: r, operations: [read], holders: ['[']}]",
+		"principal pattern": "roles: [{name: r, operations: [read]}]\nprincipals: {'[': [r]}",
+		"unknown role":      "roles: [{name: r, operations: [read]}]\nprincipals: {alice: [w]}",
+		"invalid yaml":      "roles: {",
+	}
+
+	for name, data := range tests {
+		_, err := service.ParsePolicy([]byte(data))
+		assert.Error(t, err, name)
+	}
+
+	_, err := service.LoadPolicy(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func Test_Authorization(t *testing.T) {
+	ctx := context.Background()
+
+	file := filepath.Join(t.TempDir(), "policy.yaml")
+	if !assert.NoError(t, os.WriteFile(file, []byte(testPolicy), 0600)) {
+		return
+	}
+
+	c := cfg
+	c.Service.WebhookInterval = 0
+	c.Service.Metrics = -1
+	c.Service.Policy = file
+	c.Service.Auth = &config.AuthOptions{
+		Keys: map[string]string{
+			"alice":  ledger.HashAPIKey("alice-key"),
+			"ops-eu": ledger.HashAPIKey("ops-key"),
+			"root":   ledger.HashAPIKey("root-key"),
+		},
+	}
+
+	client, port, _, grpcPort, err := start(ctx, &c)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer client.Close(ctx)
+
+	time.Sleep(100 * time.Millisecond)
+
+	base := fmt.Sprintf("http://localhost:%d", port)
+	holder := "customer-" + randomName()
+
+	call := func(method string, path string, key string) int {
+		resp, err := request(method, base+path, nil, http.Header{service.APIKeyHeader: {key}})
+		if !assert.NoError(t, err) {
+			return 0
+		}
+
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusOK, call("PUT", fmt.Sprintf("/accounts/%v/BTC/1", holder), "alice-key"))
+	assert.Equal(t, http.StatusForbidden, call("PUT", fmt.Sprintf("/accounts/%v/XRP/1", holder), "alice-key"))
+	assert.Equal(t, http.StatusForbidden, call("PUT", "/accounts/vendor/BTC/1", "alice-key"))
+	assert.Equal(t, http.StatusForbidden, call("PUT", fmt.Sprintf("/accounts/%v/BTC/1", holder), "ops-key"))
+	assert.Equal(t, http.StatusOK, call("GET", fmt.Sprintf("/accounts/%v", holder), "alice-key"))
+	assert.Equal(t, http.StatusForbidden, call("GET", fmt.Sprintf("/accounts/%v", holder), "ops-key"))
+
+	// administration
+	assert.Equal(t, http.StatusForbidden, call("GET", "/webhooks", "alice-key"))
+	assert.Equal(t, http.StatusForbidden, call("GET", "/apikeys", "alice-key"))
+	assert.Equal(t, http.StatusOK, call("GET", "/apikeys", "root-key"))
+
+	// the reason is returned
+	resp, err := request("PUT", fmt.Sprintf("%v/accounts/%v/XRP/1", base, holder), nil, http.Header{service.APIKeyHeader: {"alice-key"}})
+	if assert.NoError(t, err) {
+		body := make([]byte, 256)
+		n, _ := resp.Body.Read(body)
+		assert.Contains(t, string(body[:n]), "alice is not allowed to add XRP of holder "+holder)
+	}
+
+	// grpc uses the same policy
+	conn, err := grpc.Dial(fmt.Sprintf("localhost:%d", grpcPort), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer conn.Close()
+
+	gc := protobuf.NewLedgerServiceClient(conn)
+
+	_, err = gc.Add(metadata.AppendToOutgoingContext(ctx, "x-api-key", "alice-key"), &protobuf.AmountRequest{Holder: holder, Asset: "XRP", Amount: "1"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = gc.Add(metadata.AppendToOutgoingContext(ctx, "x-api-key", "alice-key"), &protobuf.AmountRequest{Holder: holder, Asset: "ETH", Amount: "1"})
+	assert.NoError(t, err)
+
+	_, err = gc.Balance(metadata.AppendToOutgoingContext(ctx, "x-api-key", "ops-key"), &protobuf.BalanceRequest{Holder: holder})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
@@ -6,6 +6,7 @@ import (
 	"crypto/x509"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"sync"
 	"time"
@@ -13,6 +14,8 @@ import (
 	"github.com/ec-systems/core.ledger.server/pkg/config"
 	"github.com/ec-systems/core.ledger.server/pkg/logger"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -21,12 +24,17 @@ type MTlsService struct {
 	dev         string
 	port        int
 	metricsPort int
+	grpcPort    int
 	mtls        *config.MTLsOptions
 
 	done chan bool
 
 	server  *http.Server
 	mserver *http.Server
+	gserver *grpc.Server
+
+	grpcServices []func(*grpc.Server)
+	grpcOptions  []grpc.ServerOption
 
 	router  *chi.Mux
 	metrics *chi.Mux
@@ -53,7 +61,30 @@ func (l *MTlsService) Start() chan error {
 	var wg sync.WaitGroup
 
 	addr := fmt.Sprintf("%v:%v", l.dev, l.port)
-	errChan := make(chan error, 1)
+
+	// each listener sends at most one error, the channel is closed
+	// after all of them stopped
+	listeners := 1
+	if l.metricsPort > 0 {
+		listeners++
+	}
+
+	if l.grpcPort > 0 {
+		listeners++
+	}
+
+	errChan := make(chan error, listeners)
+
+	if l.grpcPort > 0 {
+		server, err := l.newGrpcServer()
+		if err != nil {
+			errChan <- err
+			close(errChan)
+			return errChan
+		}
+
+		l.gserver = server
+	}
 
 	wg.Add(1)
 	go func() {
@@ -73,7 +104,6 @@ func (l *MTlsService) Start() chan error {
 
 			logger.Infof("Ledger TLS service listen on %v:%v", l.dev, l.port)
 			errChan <- server.ListenAndServeTLS(l.mtls.Certificate, l.mtls.Pkey)
-			close(errChan)
 		} else {
 			l.server = &http.Server{
 				Addr:    addr,
@@ -115,6 +145,29 @@ func (l *MTlsService) Start() chan error {
 		}()
 	}
 
+	if l.gserver != nil {
+		wg.Add(1)
+		go func() {
+			addr := fmt.Sprintf("%v:%v", l.dev, l.grpcPort)
+
+			listener, err := net.Listen("tcp", addr)
+			if err != nil {
+				errChan <- fmt.Errorf("failed to start grpc listener: %v", err)
+				wg.Done()
+				return
+			}
+
+			logger.Infof("Ledger gRPC service listen on %v:%v", l.dev, l.grpcPort)
+			if err := l.gserver.Serve(listener); err != nil {
+				errChan <- fmt.Errorf("failed to start grpc listener: %v", err)
+			} else {
+				logger.Info("gRPC listener stopped")
+			}
+
+			wg.Done()
+		}()
+	}
+
 	go func() {
 		wg.Wait()
 		close(errChan)
@@ -136,21 +189,40 @@ func (l *MTlsService) Stop(ctx context.Context) error {
 	defer cancel()
 
 	if l.mserver != nil {
+		wg.Add(1)
 		go func() {
-			wg.Add(1)
 			err[0] = l.mserver.Shutdown(timeout)
 			wg.Done()
 		}()
 	}
 
 	if l.server != nil {
+		wg.Add(1)
 		go func() {
-			wg.Add(1)
 			err[1] = l.server.Shutdown(timeout)
 			wg.Done()
 		}()
 	}
 
+	if l.gserver != nil {
+		wg.Add(1)
+		go func() {
+			stopped := make(chan bool)
+			go func() {
+				l.gserver.GracefulStop()
+				close(stopped)
+			}()
+
+			select {
+			case <-stopped:
+			case <-timeout.Done():
+				l.gserver.Stop()
+			}
+
+			wg.Done()
+		}()
+	}
+
 	wg.Wait()
 
 	close(l.done)
@@ -177,3 +249,31 @@ func (s *MTlsService) getTLSConfig(ca string) (*tls.Config, error) {
 
 	return tlsConfig, nil
 }
+
+// newGrpcServer creates the grpc server with the registered services, it
+// uses the same mTLS settings as the web service
+func (l *MTlsService) newGrpcServer() (*grpc.Server, error) {
+	options := append([]grpc.ServerOption{}, l.grpcOptions...)
+
+	if l.mtls != nil {
+		tlsConfig, err := l.getTLSConfig(l.mtls.ClientCAs)
+		if err != nil {
+			return nil, err
+		}
+
+		cert, err := tls.LoadX509KeyPair(l.mtls.Certificate, l.mtls.Pkey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read grpc certificate: %v", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+		options = append(options, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	server := grpc.NewServer(options...)
+	for _, register := range l.grpcServices {
+		register(server)
+	}
+
+	return server, nil
+}
@@ -1,9 +1,11 @@
 package service_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"math/rand"
 	"net/http"
@@ -54,6 +56,11 @@ var (
 			Device: "",
 			Port:   12345,
 			MTls:   nil,
+
+			WebhookInterval: 20 * time.Millisecond,
+			WebhookRetries:  1,
+			WebhookBackoff:  10 * time.Millisecond,
+			WebhookTimeout:  time.Second,
 		},
 		ClientOptions: &immudb.Options{
 			Dir:                "./test_data",
@@ -82,6 +89,7 @@ var (
 
 	url        = ""
 	metricsUrl = ""
+	grpcAddr   = ""
 
 	assets = types.Assets{}
 )
@@ -104,6 +112,58 @@ func Test_Assets(t *testing.T) {
 		if !assert.Contains(t, cfg.Assets, types.Asset(v.Symbol)) {
 			return
 		}
+
+		assert.Equal(t, cfg.Assets[types.Asset(v.Symbol)].Enabled, v.Enabled)
+	}
+}
+
+func Test_AssetRegistry(t *testing.T) {
+	symbol := "REG-" + randomName()
+	holder := randomName()
+
+	resp, err := request("PUT", fmt.Sprintf("%v/assets/%v", url, symbol), &service.AssetRequest{Name: "Registered", Decimals: types.Decimals(2)}, nil)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	asset := &service.Asset{}
+	if assert.NoError(t, json.NewDecoder(resp.Body).Decode(asset)) {
+		assert.Equal(t, symbol, asset.Symbol)
+		assert.True(t, asset.Enabled)
+	}
+
+	resp, err = request("PUT", fmt.Sprintf("%v/accounts/%v/%v/1", url, holder, symbol), nil, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	resp, err = request("PUT", fmt.Sprintf("%v/accounts/%v/%v/0.001", url, holder, symbol), nil, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+
+	resp, err = request("POST", fmt.Sprintf("%v/assets/%v/disable", url, symbol), nil, nil)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	resp, err = request("PUT", fmt.Sprintf("%v/accounts/%v/%v/1", url, holder, symbol), nil, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+
+	resp, err = request("GET", fmt.Sprintf("%v/assets/%v/history", url, symbol), nil, nil)
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		history := []*service.Asset{}
+		if assert.NoError(t, json.NewDecoder(resp.Body).Decode(&history)) && assert.Len(t, history, 2) {
+			assert.True(t, history[0].Enabled)
+			assert.False(t, history[1].Enabled)
+		}
+	}
+
+	resp, err = request("POST", fmt.Sprintf("%v/assets/%v/enable", url, randomName()), nil, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
 	}
 }
 
@@ -121,6 +181,17 @@ func Test_Status(t *testing.T) {
 
 	assert.Len(t, statuses, len(cfg.Statuses))
 
+	for _, v := range statuses {
+		switch v.Name {
+		case "Created":
+			assert.ElementsMatch(t, []string{"Finished", "Canceled"}, v.Transitions)
+			assert.False(t, v.Terminal)
+		case "CancellationFinished":
+			assert.Empty(t, v.Transitions)
+			assert.True(t, v.Terminal)
+		}
+	}
+
 	for _, v := range statuses {
 		name := v.Name
 		status := types.Status(v.ID)
@@ -254,6 +325,11 @@ func Test_Add_Remove_Balance(t *testing.T) {
 	assert.Equal(t, tx3.ID, tx4.ID)
 	assert.Equal(t, types.Finished.String(types.DefaultStatusMap), tx4.Status)
 
+	resp, err = patch("/accounts/%v/%v/%v/%v/%v", tx3.Holder, tx3.Asset, tx3.Account, tx3.ID, types.Created)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusBadRequest, resp.StatusCode) {
+		return
+	}
+
 	time.Sleep(500 * time.Millisecond)
 
 	resp, err = get("/accounts/%v/%v/%v/%v", tx3.Holder, tx3.Asset, tx3.Account, tx3.ID)
@@ -279,7 +355,7 @@ func TestMain(m *testing.M) {
 		rand.Seed(time.Now().UTC().UnixNano())
 		ctx := context.Background()
 
-		client, port, metrics, err := start(ctx, &cfg)
+		client, port, metrics, grpcPort, err := start(ctx, &cfg)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -290,6 +366,7 @@ func TestMain(m *testing.M) {
 
 		url = fmt.Sprintf("http://localhost:%d", port)
 		metricsUrl = fmt.Sprintf("http://localhost:%d", metrics)
+		grpcAddr = fmt.Sprintf("localhost:%d", grpcPort)
 	}
 
 	code := m.Run()
@@ -297,42 +374,58 @@ func TestMain(m *testing.M) {
 	os.Exit(code)
 }
 
-func start(ctx context.Context, c *config.Config) (*client.Client, int, int, error) {
+func start(ctx context.Context, c *config.Config) (*client.Client, int, int, int, error) {
 	port, err := freeport.GetFreePort()
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, 0, 0, 0, err
 	}
 
 	metrics, err := freeport.GetFreePort()
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, 0, 0, 0, err
+	}
+
+	grpcPort, err := freeport.GetFreePort()
+	if err != nil {
+		return nil, 0, 0, 0, err
+	}
+
+	dir, err := os.MkdirTemp("", "service")
+	if err != nil {
+		return nil, 0, 0, 0, err
 	}
 
-	client, err := client.New(ctx, c.ClientOptions.Username, c.ClientOptions.Password, c.ClientOptions.Database,
-		client.ClientOptions(c.ClientOptions),
+	client, err := client.NewEmbedded(ctx, dir, c.ClientOptions.Database,
 		client.Limit(25),
 	)
 	if err != nil {
-		return nil, 0, 0, fmt.Errorf("database client error: %v", err)
+		return nil, 0, 0, 0, fmt.Errorf("database client error: %v", err)
 	}
 
 	l := ledger.New(client,
 		ledger.SupportedAssets(c.Assets),
 		ledger.SupportedStatuses(c.Statuses),
+		ledger.SpendPending(),
 	)
 
-	scfg := cfg.Service
+	scfg := c.Service
 	scfg.Port = port
-	scfg.Metrics = metrics
+	scfg.Grpc = grpcPort
+
+	// the metrics can only be registered by one service, a negative port
+	// disables them
+	if scfg.Metrics >= 0 {
+		scfg.Metrics = metrics
+	}
 
 	svc, err := service.NewLedgerService(ctx, l, &scfg)
 	if err != nil {
-		return nil, 0, 0, fmt.Errorf("service error: %v", err)
+		return nil, 0, 0, 0, fmt.Errorf("service error: %v", err)
 	}
 
 	go svc.Start()
 
-	return client, port, metrics, nil
+	return client, port, metrics, grpcPort, nil
 }
 
 func randomName() string {
@@ -359,10 +452,24 @@ func randomAsset() types.Asset {
 }
 
 func call(method string, format string, args ...interface{}) (*http.Response, error) {
+	return send(method, nil, format, args...)
+}
+
+func send(method string, body interface{}, format string, args ...interface{}) (*http.Response, error) {
 	client := &http.Client{}
 	url := fmt.Sprintf(url+format, args...)
 
-	req, err := http.NewRequest(method, url, nil)
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, url, reader)
 	if err != nil {
 		return nil, err
 	}
@@ -385,3 +492,7 @@ func get(format string, args ...interface{}) (*http.Response, error) {
 func patch(format string, args ...interface{}) (*http.Response, error) {
 	return call("PATCH", format, args...)
 }
+
+func post(body interface{}, format string, args ...interface{}) (*http.Response, error) {
+	return send("POST", body, format, args...)
+}
@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type TransactionsService struct {
+	chi.Router
+	ledger *ledger.Ledger
+}
+
+func NewTransactionsService(ledger *ledger.Ledger) chi.Router {
+	router := chi.NewRouter()
+	svc := &TransactionsService{
+		Router: router,
+		ledger: ledger,
+	}
+
+	// find transactions by reference
+	router.Get("/", svc.find)
+	// show the current state of a transaction
+	router.Get("/{id}", svc.get)
+	// show the history of a transaction
+	router.Get("/{id}/history", svc.history)
+	// show the links of a transaction
+	router.Get("/{id}/links", svc.links)
+	// set the status of a transaction
+	router.Patch("/{id}/status/{status}", svc.change)
+	// revert a transaction
+	router.Delete("/{id}", svc.cancel)
+
+	return svc
+}
+
+// @Summary      Find Transactions
+// @Description  Find the transactions of all holders by reference, case and a 0x prefix are ignored
+// @Tags         Transactions
+// @Produce      json
+// @Param        ref   		query      	string 	true	"Reference"
+// @Success      200  {array}  service.Transaction
+// @Failure      400
+// @Failure      404
+// @Failure      500
+// @Router       /transactions/ [get]
+func (t *TransactionsService) find(w http.ResponseWriter, r *http.Request) {
+	ref := r.URL.Query().Get("ref")
+	if ref == "" {
+		http.Error(w, "reference is mandatory", http.StatusBadRequest)
+		return
+	}
+
+	result := []*Transaction{}
+	err := t.ledger.FindReference(r.Context(), ref, func(ctx context.Context, tx *ledger.Transaction) (bool, error) {
+		if !permitted(ctx, ReadOperation, tx.Holder, tx.Asset) {
+			return true, nil
+		}
+
+		output := &Transaction{}
+		output.Set(t.ledger, tx)
+		result = append(result, output)
+		return true, nil
+	})
+
+	if isError(w, err) {
+		return
+	}
+
+	if len(result) == 0 {
+		http.Error(w, fmt.Sprintf("no transaction with reference %v found", ref), http.StatusNotFound)
+		return
+	}
+
+	render.JSON(w, r, result)
+}
+
+// @Summary      Show Transaction
+// @Description  Show the current state of a transaction, the holder, asset and account are not needed
+// @Tags         Transactions
+// @Produce      json
+// @Param        id   		path      	string  true  	"Transaction ID"
+// @Success      200  {object}  service.Transaction
+// @Failure      400
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /transactions/{id} [get]
+func (t *TransactionsService) get(w http.ResponseWriter, r *http.Request) {
+	tx, ok := t.transaction(w, r, ReadOperation)
+	if !ok {
+		return
+	}
+
+	output := &Transaction{}
+	output.Set(t.ledger, tx)
+	render.JSON(w, r, output)
+}
+
+// @Summary      Show Transaction History
+// @Description  Show all versions of a transaction
+// @Tags         Transactions
+// @Produce      json
+// @Param        id   		path      	string  true  	"Transaction ID"
+// @Success      200  {array}  service.Transaction
+// @Failure      400
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /transactions/{id}/history [get]
+func (t *TransactionsService) history(w http.ResponseWriter, r *http.Request) {
+	tx, ok := t.transaction(w, r, ReadOperation)
+	if !ok {
+		return
+	}
+
+	txs := []*Transaction{}
+
+	err := t.ledger.History(r.Context(), tx.ID, func(ctx context.Context, tx *ledger.Transaction) (bool, error) {
+		output := &Transaction{}
+		output.Set(t.ledger, tx)
+		txs = append(txs, output)
+		return true, nil
+	})
+
+	if isError(w, err) {
+		return
+	}
+
+	render.JSON(w, r, txs)
+}
+
+// @Summary      Change Transaction Status
+// @Description  Change the status of a transaction, the holder, asset and account are not needed
+// @Tags         Transactions
+// @Produce      json
+// @Param        id   		path      	string  true  	"Transaction ID"
+// @Param        status   	path      	string  true  	"Transaction Status"
+// @Success      200  {object}  service.Transaction
+// @Failure      400
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /transactions/{id}/status/{status} [patch]
+func (t *TransactionsService) change(w http.ResponseWriter, r *http.Request) {
+	status, err := t.ledger.SupportedStatus().Parse(chi.URLParam(r, "status"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tx, ok := t.transaction(w, r, StatusOperation)
+	if !ok {
+		return
+	}
+
+	tx, err = t.ledger.Status(r.Context(), tx, status)
+	if isError(w, err) {
+		return
+	}
+
+	output := &Transaction{}
+	output.Set(t.ledger, tx)
+	render.JSON(w, r, output)
+}
+
+// @Summary      Revert Transaction
+// @Description  Cancel a transaction, the holder, asset and account are not needed
+// @Tags         Transactions
+// @Produce      json
+// @Param        id   		path      	string  true  	"Transaction ID"
+// @Success      200  {object}  service.Transaction
+// @Failure      400
+// @Failure      403
+// @Failure      404
+// @Failure      409
+// @Failure      500
+// @Router       /transactions/{id} [delete]
+func (t *TransactionsService) cancel(w http.ResponseWriter, r *http.Request) {
+	tx, ok := t.transaction(w, r, CancelOperation)
+	if !ok {
+		return
+	}
+
+	cancel, err := t.ledger.Cancel(r.Context(), tx.Holder, tx.Asset, tx.Account, tx.ID)
+	if isError(w, err) {
+		return
+	}
+
+	output := &Transaction{}
+	output.Set(t.ledger, cancel)
+	render.JSON(w, r, output)
+}
+
+// @Summary      Transaction Links
+// @Description  List the incoming and outgoing links of a transaction or hold (cancel, refund, transfer, capture)
+// @Tags         Transactions
+// @Produce      json
+// @Param        id   		path      	string  true  	"Transaction ID"
+// @Success      200  {object}  service.Links
+// @Failure      400
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /transactions/{id}/links [get]
+func (t *TransactionsService) links(w http.ResponseWriter, r *http.Request) {
+	id, ok := t.id(w, r)
+	if !ok {
+		return
+	}
+
+	links, err := t.ledger.Links(r.Context(), id)
+	if isError(w, err) {
+		return
+	}
+
+	if !t.authorize(w, r, id) {
+		return
+	}
+
+	output := &Links{
+		ID:       id.UUID,
+		Incoming: t.filter(r, links.Incoming),
+		Outgoing: t.filter(r, links.Outgoing),
+	}
+
+	render.JSON(w, r, output)
+}
+
+// filter drops the links to transactions and holds the principal isn't
+// allowed to read
+func (t *TransactionsService) filter(r *http.Request, links []*ledger.Link) []*Link {
+	result := []*Link{}
+
+	for _, link := range links {
+		if link.Transaction != nil && !permitted(r.Context(), ReadOperation, link.Transaction.Holder, link.Transaction.Asset) {
+			continue
+		}
+
+		if link.Hold != nil && !permitted(r.Context(), ReadOperation, link.Hold.Holder, link.Hold.Asset) {
+			continue
+		}
+
+		output := &Link{}
+		output.Set(t.ledger, link)
+		result = append(result, output)
+	}
+
+	return result
+}
+
+// authorize checks the read permission of the holder and asset of the
+// transaction or hold
+func (t *TransactionsService) authorize(w http.ResponseWriter, r *http.Request, id types.ID) bool {
+	if PrincipalFrom(r.Context()) == nil {
+		return true
+	}
+
+	tx, err := t.ledger.Get(r.Context(), id)
+	if err == nil {
+		return !isError(w, authorize(r.Context(), ReadOperation, tx.Holder, tx.Asset))
+	}
+
+	hold, err := t.ledger.GetHold(r.Context(), id)
+	if isError(w, err) {
+		return false
+	}
+
+	return !isError(w, authorize(r.Context(), ReadOperation, hold.Holder, hold.Asset))
+}
+
+// transaction reads the transaction of the id and checks the operation for
+// its holder and asset
+func (t *TransactionsService) transaction(w http.ResponseWriter, r *http.Request, op Operation) (*ledger.Transaction, bool) {
+	id, ok := t.id(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	tx, err := t.ledger.Get(r.Context(), id)
+	if err != nil {
+		if strings.Contains(err.Error(), store.ErrKeyNotFound.Error()) {
+			http.Error(w, fmt.Sprintf("transaction %v not found", id), http.StatusNotFound)
+			return nil, false
+		}
+
+		http.Error(w, fmt.Sprintf("cant read transaction %v: %v", id, err), http.StatusInternalServerError)
+		return nil, false
+	}
+
+	if isError(w, authorize(r.Context(), op, tx.Holder, tx.Asset)) {
+		return nil, false
+	}
+
+	return tx, true
+}
+
+func (t *TransactionsService) id(w http.ResponseWriter, r *http.Request) (types.ID, bool) {
+	guid, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("transaction id is invalid: %v", err), http.StatusBadRequest)
+		return types.ZeroID, false
+	}
+
+	return types.ID{UUID: guid}, true
+}
@@ -0,0 +1,181 @@
+package service_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ec-systems/core.ledger.server/pkg/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Links(t *testing.T) {
+	holder := randomName()
+	asset := randomAsset()
+
+	resp, err := put("/accounts/%v/%v/%v", holder, asset, 3)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	var tx service.Transaction
+	err = json.NewDecoder(resp.Body).Decode(&tx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	resp, err = del("/accounts/%v/%v/%v/%v", holder, asset, tx.Account, tx.ID)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	var cancel service.Transaction
+	err = json.NewDecoder(resp.Body).Decode(&cancel)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	resp, err = get("/transactions/%v/links", tx.ID)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	links := &service.Links{}
+	err = json.NewDecoder(resp.Body).Decode(links)
+	if assert.NoError(t, err) && assert.Len(t, links.Outgoing, 1) {
+		assert.Empty(t, links.Incoming)
+		assert.Equal(t, "cancel", links.Outgoing[0].Relation)
+		assert.Equal(t, cancel.ID, links.Outgoing[0].Target)
+		if assert.NotNil(t, links.Outgoing[0].Transaction) {
+			assert.Equal(t, cancel.ID, links.Outgoing[0].Transaction.ID)
+		}
+	}
+
+	resp, err = get("/transactions/%v/links", cancel.ID)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	links = &service.Links{}
+	err = json.NewDecoder(resp.Body).Decode(links)
+	if assert.NoError(t, err) && assert.Len(t, links.Incoming, 1) {
+		assert.Equal(t, tx.ID, links.Incoming[0].Source)
+	}
+
+	resp, err = get("/transactions/%v/links", uuid.New())
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+
+	resp, err = get("/transactions/invalid/links")
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+}
+
+func Test_FindReference(t *testing.T) {
+	holder := randomName()
+	asset := randomAsset()
+	hash := "0x" + randomName() + "ABC"
+
+	resp, err := put("/accounts/%v/%v/%v?ref=%v", holder, asset, 1, hash)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	var tx service.Transaction
+	err = json.NewDecoder(resp.Body).Decode(&tx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	resp, err = get("/transactions?ref=%v", strings.ToLower(hash[2:]))
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	result := []*service.Transaction{}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if assert.NoError(t, err) && assert.Len(t, result, 1) {
+		assert.Equal(t, tx.ID, result[0].ID)
+		assert.Equal(t, hash, result[0].Reference)
+	}
+
+	resp, err = get("/transactions?ref=%v", randomName()+"_unknown")
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+
+	resp, err = get("/transactions")
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+}
+
+func Test_TransactionResource(t *testing.T) {
+	holder := randomName()
+	asset := randomAsset()
+
+	resp, err := put("/accounts/%v/%v/%v", holder, asset, 2)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	var tx service.Transaction
+	err = json.NewDecoder(resp.Body).Decode(&tx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	resp, err = get("/transactions/%v", tx.ID)
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		current := &service.Transaction{}
+		if assert.NoError(t, json.NewDecoder(resp.Body).Decode(current)) {
+			assert.Equal(t, holder, current.Holder)
+			assert.Equal(t, tx.Account, current.Account)
+			assert.Equal(t, "Created", current.Status)
+		}
+	}
+
+	resp, err = patch("/transactions/%v/status/%v", tx.ID, "Finished")
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		changed := &service.Transaction{}
+		if assert.NoError(t, json.NewDecoder(resp.Body).Decode(changed)) {
+			assert.Equal(t, "Finished", changed.Status)
+		}
+	}
+
+	resp, err = patch("/transactions/%v/status/%v", tx.ID, "Unsupported")
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+
+	resp, err = get("/transactions/%v/history", tx.ID)
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		history := []*service.Transaction{}
+		if assert.NoError(t, json.NewDecoder(resp.Body).Decode(&history)) && assert.Len(t, history, 2) {
+			assert.Equal(t, "Created", history[0].Status)
+			assert.Equal(t, "Finished", history[1].Status)
+		}
+	}
+
+	resp, err = del("/transactions/%v", tx.ID)
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		cancel := &service.Transaction{}
+		if assert.NoError(t, json.NewDecoder(resp.Body).Decode(cancel)) {
+			assert.Equal(t, "-2", cancel.Amount.String())
+		}
+	}
+
+	resp, err = del("/transactions/%v", tx.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	}
+
+	resp, err = get("/transactions/%v", uuid.New())
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+}
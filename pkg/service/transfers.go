@@ -0,0 +1,99 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+type TransfersService struct {
+	chi.Router
+	ledger *ledger.Ledger
+}
+
+func NewTransfersService(ledger *ledger.Ledger) chi.Router {
+	router := chi.NewRouter()
+	svc := &TransfersService{
+		Router: router,
+		ledger: ledger,
+	}
+
+	// transfer assets between two holders
+	router.Post("/", svc.transfer)
+
+	return svc
+}
+
+// @Summary      Transfer Assets
+// @Description  Move assets from one holder to another in a single ledger transaction
+// @Tags         Transfers
+// @Accept       json
+// @Produce      json
+// @Param        transfer  	body      	service.TransferRequest  true  	"Transfer"
+// @Success      200  {object}  service.Transfer
+// @Failure      400
+// @Failure      404
+// @Failure      406
+// @Failure      500
+// @Router       /transfers/ [post]
+func (t *TransfersService) transfer(w http.ResponseWriter, r *http.Request) {
+	req := &TransferRequest{}
+
+	err := json.NewDecoder(r.Body).Decode(req)
+	if err != nil {
+		http.Error(w, "invalid transfer request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.From == "" || req.To == "" {
+		http.Error(w, "from and to holder are mandatory", http.StatusBadRequest)
+		return
+	}
+
+	asset, err := t.ledger.SupportedAssets().Parse(req.Asset)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if asset == types.AllAssets {
+		http.Error(w, "asset is mandatory", http.StatusBadRequest)
+		return
+	}
+
+	if isError(w, authorize(r.Context(), RemoveOperation, req.From, asset)) {
+		return
+	}
+
+	if isError(w, authorize(r.Context(), AddOperation, req.To, asset)) {
+		return
+	}
+
+	options := []ledger.TransactionOption{}
+
+	if req.Order != "" {
+		options = append(options, ledger.OrderID(req.Order))
+	}
+
+	if req.Item != "" {
+		options = append(options, ledger.OrderItemID(req.Item))
+	}
+
+	if req.Reference != "" {
+		options = append(options, ledger.Reference(req.Reference))
+	}
+
+	debit, credit, err := t.ledger.Transfer(r.Context(), req.From, req.To, asset, req.Amount, options...)
+	if isError(w, err) {
+		return
+	}
+
+	output := &Transfer{}
+	output.From.Set(t.ledger, debit)
+	output.To.Set(t.ledger, credit)
+	render.JSON(w, r, output)
+}
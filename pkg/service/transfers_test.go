@@ -0,0 +1,71 @@
+package service_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/ec-systems/core.ledger.server/pkg/service"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Transfer(t *testing.T) {
+	from := randomName()
+	to := from + "_to"
+	asset := randomAsset()
+	ref := randomName()
+
+	amount1, _ := decimal.NewFromString("2.5")
+	amount2, _ := decimal.NewFromString("1.5")
+
+	resp, err := put("/accounts/%v/%v/%v", from, asset, amount1)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	resp, err = post(&service.TransferRequest{
+		From:      from,
+		To:        to,
+		Asset:     asset.String(),
+		Amount:    amount2,
+		Reference: ref,
+	}, "/transfers")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	var transfer service.Transfer
+	err = json.NewDecoder(resp.Body).Decode(&transfer)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, from, transfer.From.Holder)
+	assert.Equal(t, amount2.Neg().String(), transfer.From.Amount.String())
+	assert.Equal(t, ref, transfer.From.Reference)
+	assert.Equal(t, to, transfer.To.Holder)
+	assert.Equal(t, amount2.String(), transfer.To.Amount.String())
+	assert.Equal(t, ref, transfer.To.Reference)
+
+	resp, err = get("/accounts/%v/%v", to, asset)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	var balances []*service.Balance
+	err = json.NewDecoder(resp.Body).Decode(&balances)
+	if assert.NoError(t, err) && assert.Len(t, balances, 1) {
+		assert.Equal(t, amount2.String(), balances[0].Sum.String())
+	}
+
+	resp, err = post(&service.TransferRequest{
+		From:   from,
+		To:     to,
+		Asset:  asset.String(),
+		Amount: amount2,
+	}, "/transfers")
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+}
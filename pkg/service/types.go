@@ -1,9 +1,11 @@
 package service
 
 import (
+	"sort"
 	"time"
 
 	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
@@ -19,10 +21,33 @@ type Account struct {
 }
 
 type Balance struct {
-	Asset    string            `json:"Asset"`
-	Sum      decimal.Decimal   `json:"Sum"`
-	Accounts []*AccountBalance `json:"Accounts,omitempty"`
-	Count    uint              `json:"Count"`
+	Asset     string            `json:"Asset"`
+	Sum       decimal.Decimal   `json:"Sum"`
+	Available decimal.Decimal   `json:"Available"`
+	Pending   decimal.Decimal   `json:"Pending"`
+	Finished  decimal.Decimal   `json:"Finished"`
+	Canceled  decimal.Decimal   `json:"Canceled"`
+	Held      decimal.Decimal   `json:"Held"`
+	Accounts  []*AccountBalance `json:"Accounts,omitempty"`
+	Count     uint              `json:"Count"`
+}
+
+func (b *Balance) Set(asset types.Asset, balance *types.Balance) {
+	b.Asset = asset.String()
+	b.Sum = balance.Sum
+	b.Available = balance.Available
+	b.Pending = balance.Pending()
+	b.Finished = balance.Finished()
+	b.Canceled = balance.Canceled()
+	b.Held = balance.Held
+	b.Count = balance.Count
+	b.Accounts = []*AccountBalance{}
+
+	for account, a := range balance.Accounts {
+		acc := &AccountBalance{}
+		acc.Set(account, a)
+		b.Accounts = append(b.Accounts, acc)
+	}
 }
 
 type AssetBalance struct {
@@ -31,9 +56,25 @@ type AssetBalance struct {
 }
 
 type AccountBalance struct {
-	ID    string          `json:"ID"`
-	Count uint            `json:"Count"`
-	Sum   decimal.Decimal `json:"Sum"`
+	ID        string          `json:"ID"`
+	Count     uint            `json:"Count"`
+	Sum       decimal.Decimal `json:"Sum"`
+	Available decimal.Decimal `json:"Available"`
+	Pending   decimal.Decimal `json:"Pending"`
+	Finished  decimal.Decimal `json:"Finished"`
+	Canceled  decimal.Decimal `json:"Canceled"`
+	Held      decimal.Decimal `json:"Held"`
+}
+
+func (a *AccountBalance) Set(account types.Account, balance *types.AccountBalance) {
+	a.ID = account.String()
+	a.Count = balance.Count
+	a.Sum = balance.Sum
+	a.Available = balance.Available
+	a.Pending = balance.Pending()
+	a.Finished = balance.Finished()
+	a.Canceled = balance.Canceled()
+	a.Held = balance.Held
 }
 
 type Transaction struct {
@@ -42,6 +83,7 @@ type Transaction struct {
 	Holder  string    `json:"Holder"`
 	Order   string    `json:"Order,omitempty"`
 	Item    string    `json:"Item,omitempty"`
+	Journal string    `json:"Journal,omitempty"`
 
 	Asset  string          `json:"Asset"`
 	Amount decimal.Decimal `json:"Amount"`
@@ -59,6 +101,7 @@ func (t *Transaction) Set(l *ledger.Ledger, tx *ledger.Transaction) {
 	t.Holder = tx.Holder
 	t.Order = tx.Order
 	t.Item = tx.Item
+	t.Journal = tx.Journal
 	t.Asset = tx.Asset.String()
 	t.Amount = tx.Amount
 	t.Status = tx.Status.String(l.SupportedStatus())
@@ -68,9 +111,299 @@ func (t *Transaction) Set(l *ledger.Ledger, tx *ledger.Transaction) {
 	t.User = tx.User
 }
 
+type TransferRequest struct {
+	From      string          `json:"From"`
+	To        string          `json:"To"`
+	Asset     string          `json:"Asset"`
+	Amount    decimal.Decimal `json:"Amount"`
+	Order     string          `json:"Order,omitempty"`
+	Item      string          `json:"Item,omitempty"`
+	Reference string          `json:"Reference,omitempty"`
+}
+
+type Transfer struct {
+	From Transaction `json:"From"`
+	To   Transaction `json:"To"`
+}
+
+type JournalLegRequest struct {
+	Holder  string          `json:"Holder"`
+	Account string          `json:"Account,omitempty"`
+	Asset   string          `json:"Asset"`
+	Amount  decimal.Decimal `json:"Amount"`
+}
+
+type JournalRequest struct {
+	Order     string              `json:"Order,omitempty"`
+	Reference string              `json:"Reference,omitempty"`
+	Legs      []JournalLegRequest `json:"Legs"`
+}
+
+type Journal struct {
+	ID   uuid.UUID      `json:"ID"`
+	Legs []*Transaction `json:"Legs"`
+}
+
+type HoldRequest struct {
+	Holder    string          `json:"Holder"`
+	Account   string          `json:"Account,omitempty"`
+	Asset     string          `json:"Asset"`
+	Amount    decimal.Decimal `json:"Amount"`
+	Expires   *time.Time      `json:"Expires,omitempty"`
+	Order     string          `json:"Order,omitempty"`
+	Reference string          `json:"Reference,omitempty"`
+}
+
+type Hold struct {
+	ID          uuid.UUID       `json:"ID"`
+	Holder      string          `json:"Holder"`
+	Account     string          `json:"Account"`
+	Asset       string          `json:"Asset"`
+	Amount      decimal.Decimal `json:"Amount"`
+	Status      string          `json:"Status"`
+	Transaction string          `json:"Transaction,omitempty"`
+	Order       string          `json:"Order,omitempty"`
+	Reference   string          `json:"Reference,omitempty"`
+	Expires     *time.Time      `json:"Expires,omitempty"`
+	Created     *time.Time      `json:"Created"`
+	Modified    *time.Time      `json:"Modified,omitempty"`
+}
+
+func (h *Hold) Set(hold *ledger.Hold) {
+	h.ID = hold.ID.UUID
+	h.Holder = hold.Holder
+	h.Account = hold.Account.String()
+	h.Asset = hold.Asset.String()
+	h.Amount = hold.Amount
+	h.Status = string(hold.Status)
+	h.Order = hold.Order
+	h.Reference = hold.Reference
+	h.Expires = hold.Expires
+	h.Created = hold.Created
+	h.Modified = hold.Modified
+
+	if !hold.Transaction.IsEmpty() {
+		h.Transaction = hold.Transaction.String()
+	}
+}
+
+type Capture struct {
+	Hold        Hold        `json:"Hold"`
+	Transaction Transaction `json:"Transaction"`
+}
+
+type Link struct {
+	Relation    string       `json:"Relation"`
+	Source      uuid.UUID    `json:"Source"`
+	Target      uuid.UUID    `json:"Target"`
+	Transaction *Transaction `json:"Transaction,omitempty"`
+	Hold        *Hold        `json:"Hold,omitempty"`
+}
+
+func (k *Link) Set(l *ledger.Ledger, link *ledger.Link) {
+	k.Relation = string(link.Relation)
+	k.Source = link.Source.UUID
+	k.Target = link.Target.UUID
+
+	if link.Transaction != nil {
+		k.Transaction = &Transaction{}
+		k.Transaction.Set(l, link.Transaction)
+	}
+
+	if link.Hold != nil {
+		k.Hold = &Hold{}
+		k.Hold.Set(link.Hold)
+	}
+}
+
+type Links struct {
+	ID       uuid.UUID `json:"ID"`
+	Incoming []*Link   `json:"Incoming"`
+	Outgoing []*Link   `json:"Outgoing"`
+}
+
+type OrderItem struct {
+	Item         string         `json:"Item"`
+	Status       string         `json:"Status"`
+	Transactions []*Transaction `json:"Transactions"`
+}
+
+type Order struct {
+	Holder string          `json:"Holder"`
+	Order  string          `json:"Order"`
+	Status string          `json:"Status"`
+	Net    []*AssetBalance `json:"Net"`
+	Items  []*OrderItem    `json:"Items"`
+}
+
+func (o *Order) Set(l *ledger.Ledger, order *ledger.Order) {
+	o.Holder = order.Holder
+	o.Order = order.Order
+	o.Status = string(order.Status)
+	o.Net = []*AssetBalance{}
+	o.Items = []*OrderItem{}
+
+	for asset, sum := range order.Net {
+		o.Net = append(o.Net, &AssetBalance{Asset: asset.String(), Sum: sum})
+	}
+
+	sort.Slice(o.Net, func(i, j int) bool {
+		return o.Net[i].Asset < o.Net[j].Asset
+	})
+
+	for _, item := range order.Items {
+		i := &OrderItem{
+			Item:         item.Item,
+			Status:       string(item.Status),
+			Transactions: []*Transaction{},
+		}
+
+		for _, tx := range item.Transactions {
+			t := &Transaction{}
+			t.Set(l, tx)
+			i.Transactions = append(i.Transactions, t)
+		}
+
+		o.Items = append(o.Items, i)
+	}
+}
+
+type Proof struct {
+	Transaction *Transaction `json:"Transaction,omitempty"`
+	Verified    bool         `json:"Verified"`
+	Error       string       `json:"Error,omitempty"`
+	Signed      bool         `json:"Signed"`
+	Tx          uint64       `json:"Tx"`
+	SourceTx    uint64       `json:"SourceTx"`
+	TargetTx    uint64       `json:"TargetTx"`
+	TargetAlh   string       `json:"TargetAlh,omitempty"`
+	Leaf        int32        `json:"Leaf"`
+	Width       int32        `json:"Width"`
+}
+
+type WebhookRequest struct {
+	URL    string `json:"URL"`
+	Holder string `json:"Holder,omitempty"`
+	Asset  string `json:"Asset,omitempty"`
+	Status string `json:"Status,omitempty"`
+	Secret string `json:"Secret,omitempty"`
+}
+
+type Webhook struct {
+	ID       uuid.UUID  `json:"ID"`
+	URL      string     `json:"URL"`
+	Holder   string     `json:"Holder,omitempty"`
+	Asset    string     `json:"Asset,omitempty"`
+	Status   string     `json:"Status,omitempty"`
+	Secret   string     `json:"Secret,omitempty"`
+	Position uint64     `json:"Position"`
+	Created  *time.Time `json:"Created"`
+	Modified *time.Time `json:"Modified,omitempty"`
+}
+
+func (h *Webhook) Set(l *ledger.Ledger, hook *ledger.Webhook) {
+	h.ID = hook.ID.UUID
+	h.URL = hook.URL
+	h.Holder = hook.Holder
+	h.Asset = hook.Asset.String()
+	h.Position = hook.Position
+	h.Created = hook.Created
+	h.Modified = hook.Modified
+
+	if hook.Status != types.AllStatuses {
+		h.Status = hook.Status.String(l.SupportedStatus())
+	}
+}
+
+type Delivery struct {
+	ID          uuid.UUID  `json:"ID"`
+	Webhook     uuid.UUID  `json:"Webhook"`
+	Tx          uint64     `json:"Tx"`
+	Transaction uuid.UUID  `json:"Transaction"`
+	Attempt     int        `json:"Attempt"`
+	Status      string     `json:"Status"`
+	Code        int        `json:"Code,omitempty"`
+	Error       string     `json:"Error,omitempty"`
+	Created     *time.Time `json:"Created"`
+}
+
+func (d *Delivery) Set(delivery *ledger.Delivery) {
+	d.ID = delivery.ID.UUID
+	d.Webhook = delivery.Webhook.UUID
+	d.Tx = delivery.Tx
+	d.Transaction = delivery.Transaction.UUID
+	d.Attempt = delivery.Attempt
+	d.Status = string(delivery.Status)
+	d.Code = delivery.Code
+	d.Error = delivery.Error
+	d.Created = delivery.Created
+}
+
+// WebhookEvent is the payload posted to a webhook, the body is signed with
+// the secret of the webhook in the X-Ledger-Signature header
+type WebhookEvent struct {
+	Webhook     uuid.UUID    `json:"Webhook"`
+	Tx          uint64       `json:"Tx"`
+	Transaction *Transaction `json:"Transaction"`
+}
+
+type APIKeyRequest struct {
+	Name string `json:"Name"`
+}
+
+type APIKey struct {
+	ID      uuid.UUID  `json:"ID"`
+	Name    string     `json:"Name"`
+	Key     string     `json:"Key,omitempty"`
+	Created *time.Time `json:"Created"`
+	Revoked *time.Time `json:"Revoked,omitempty"`
+}
+
+func (k *APIKey) Set(apiKey *ledger.APIKey) {
+	k.ID = apiKey.ID.UUID
+	k.Name = apiKey.Name
+	k.Created = apiKey.Created
+	k.Revoked = apiKey.Revoked
+}
+
+type AssetRequest struct {
+	Name     string          `json:"Name"`
+	Decimals *int32          `json:"Decimals,omitempty"`
+	Min      decimal.Decimal `json:"Min,omitempty"`
+	Max      decimal.Decimal `json:"Max,omitempty"`
+	Enabled  *bool           `json:"Enabled,omitempty"`
+}
+
 type Asset struct {
-	Symbol string `json:"Symbol"`
-	Name   string `json:"Name"`
+	Symbol   string           `json:"Symbol"`
+	Name     string           `json:"Name"`
+	Decimals *int32           `json:"Decimals,omitempty"`
+	Min      *decimal.Decimal `json:"Min,omitempty"`
+	Max      *decimal.Decimal `json:"Max,omitempty"`
+	Enabled  bool             `json:"Enabled"`
+	Modified *time.Time       `json:"Modified,omitempty"`
+	User     string           `json:"User,omitempty"`
+}
+
+func (a *Asset) Set(asset types.Asset, definition types.AssetDefinition) {
+	a.Symbol = asset.String()
+	a.Name = definition.Name
+	a.Decimals = definition.Decimals
+	a.Enabled = definition.Enabled
+
+	if !definition.Min.IsZero() {
+		a.Min = &definition.Min
+	}
+
+	if !definition.Max.IsZero() {
+		a.Max = &definition.Max
+	}
+}
+
+func (a *Asset) SetRegistered(asset *ledger.RegisteredAsset) {
+	a.Set(asset.Symbol, asset.Definition)
+	a.Modified = asset.Modified
+	a.User = asset.User
 }
 
 type Assets []Asset
@@ -80,8 +413,10 @@ func (a Assets) Less(i, j int) bool { return a[i].Symbol < a[j].Symbol }
 func (a Assets) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 
 type Status struct {
-	ID   int    `json:"ID"`
-	Name string `json:"Name"`
+	ID          int      `json:"ID"`
+	Name        string   `json:"Name"`
+	Transitions []string `json:"Transitions,omitempty"`
+	Terminal    bool     `json:"Terminal"`
 }
 
 type Statuses []Status
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ec-systems/core.ledger.server/pkg/ledger"
+	"github.com/ec-systems/core.ledger.server/pkg/types"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type WebhooksService struct {
+	chi.Router
+	ledger *ledger.Ledger
+}
+
+func NewWebhooksService(ledger *ledger.Ledger) chi.Router {
+	router := chi.NewRouter()
+	svc := &WebhooksService{
+		Router: router,
+		ledger: ledger,
+	}
+
+	router.Use(authorizeOperation(AdminOperation))
+
+	// add a webhook
+	router.Post("/", svc.add)
+	// list the webhooks
+	router.Get("/", svc.webhooks)
+	// show a webhook
+	router.Get("/{id}", svc.get)
+	// deliver the transactions after a tx again
+	router.Post("/{id}/replay", svc.replay)
+	// list the delivery attempts
+	router.Get("/{id}/deliveries", svc.deliveries)
+	// list the failed delivery attempts
+	router.Get("/{id}/failures", svc.failures)
+
+	return svc
+}
+
+// @Summary      Add Webhook
+// @Description  Add a webhook for the transactions matching the holder, asset and status filter, it receives the transactions written after its creation. The secret is only returned once, a random secret is generated if none is given.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        webhook  	body      	service.WebhookRequest  true  	"Webhook"
+// @Success      200  {object}  service.Webhook
+// @Failure      400
+// @Failure      404
+// @Failure      500
+// @Router       /webhooks/ [post]
+func (h *WebhooksService) add(w http.ResponseWriter, r *http.Request) {
+	req := &WebhookRequest{}
+
+	err := json.NewDecoder(r.Body).Decode(req)
+	if err != nil {
+		http.Error(w, "invalid webhook request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	hook := ledger.NewWebhook(req.URL)
+	hook.Holder = req.Holder
+	hook.Secret = req.Secret
+
+	hook.Asset, err = h.ledger.SupportedAssets().Parse(req.Asset)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.Status != "" {
+		hook.Status, err = h.ledger.SupportedStatus().Parse(req.Status)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	hook, err = h.ledger.AddWebhook(r.Context(), hook)
+	if isError(w, err) {
+		return
+	}
+
+	output := &Webhook{}
+	output.Set(h.ledger, hook)
+	output.Secret = hook.Secret
+	render.JSON(w, r, output)
+}
+
+// @Summary      List Webhooks
+// @Description  List all webhooks
+// @Tags         Webhooks
+// @Produce      json
+// @Success      200  {array}  service.Webhook
+// @Failure      500
+// @Router       /webhooks/ [get]
+func (h *WebhooksService) webhooks(w http.ResponseWriter, r *http.Request) {
+	output := []*Webhook{}
+
+	err := h.ledger.Webhooks(r.Context(), func(ctx context.Context, hook *ledger.Webhook) (bool, error) {
+		o := &Webhook{}
+		o.Set(h.ledger, hook)
+		output = append(output, o)
+		return true, nil
+	})
+
+	if isError(w, err) {
+		return
+	}
+
+	render.JSON(w, r, output)
+}
+
+// @Summary      Show Webhook
+// @Description  Show a webhook
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id   		path      	string  true  	"Webhook ID"
+// @Success      200  {object}  service.Webhook
+// @Failure      400
+// @Failure      404
+// @Failure      500
+// @Router       /webhooks/{id} [get]
+func (h *WebhooksService) get(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.id(w, r)
+	if !ok {
+		return
+	}
+
+	hook, err := h.ledger.GetWebhook(r.Context(), id)
+	if isError(w, err) {
+		return
+	}
+
+	output := &Webhook{}
+	output.Set(h.ledger, hook)
+	render.JSON(w, r, output)
+}
+
+// @Summary      Replay Webhook
+// @Description  Deliver all matching transactions written after the given immudb tx again
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id   		path      	string  true  	"Webhook ID"
+// @Param        from   	query      	int  	true  	"Replay after this immudb tx"
+// @Success      200  {object}  service.Webhook
+// @Failure      400
+// @Failure      404
+// @Failure      500
+// @Router       /webhooks/{id}/replay [post]
+func (h *WebhooksService) replay(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.id(w, r)
+	if !ok {
+		return
+	}
+
+	from, err := strconv.ParseUint(r.URL.Query().Get("from"), 10, 64)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid from tx: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	hook, err := h.ledger.ReplayWebhook(r.Context(), id, from)
+	if isError(w, err) {
+		return
+	}
+
+	output := &Webhook{}
+	output.Set(h.ledger, hook)
+	render.JSON(w, r, output)
+}
+
+// @Summary      List Deliveries
+// @Description  List the delivery attempts of a webhook, the latest first
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id   		path      	string  true  	"Webhook ID"
+// @Param        limit   	query      	int  	false  	"Max number of attempts"
+// @Success      200  {array}  service.Delivery
+// @Failure      400
+// @Failure      404
+// @Failure      500
+// @Router       /webhooks/{id}/deliveries [get]
+func (h *WebhooksService) deliveries(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, "")
+}
+
+// @Summary      List Failures
+// @Description  List the failed delivery attempts of a webhook, the latest first
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id   		path      	string  true  	"Webhook ID"
+// @Param        limit   	query      	int  	false  	"Max number of attempts"
+// @Success      200  {array}  service.Delivery
+// @Failure      400
+// @Failure      404
+// @Failure      500
+// @Router       /webhooks/{id}/failures [get]
+func (h *WebhooksService) failures(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, ledger.Failed)
+}
+
+func (h *WebhooksService) list(w http.ResponseWriter, r *http.Request, status ledger.DeliveryStatus) {
+	id, ok := h.id(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 0
+	if l := r.URL.Query().Get("limit"); l != "" {
+		value, err := strconv.Atoi(l)
+		if err != nil || value < 0 {
+			http.Error(w, fmt.Sprintf("invalid limit %v", l), http.StatusBadRequest)
+			return
+		}
+
+		limit = value
+	}
+
+	_, err := h.ledger.GetWebhook(r.Context(), id)
+	if isError(w, err) {
+		return
+	}
+
+	output := []*Delivery{}
+
+	err = h.ledger.Deliveries(r.Context(), id, status, func(ctx context.Context, delivery *ledger.Delivery) (bool, error) {
+		o := &Delivery{}
+		o.Set(delivery)
+		output = append(output, o)
+		return limit == 0 || len(output) < limit, nil
+	})
+
+	if isError(w, err) {
+		return
+	}
+
+	render.JSON(w, r, output)
+}
+
+func (h *WebhooksService) id(w http.ResponseWriter, r *http.Request) (types.ID, bool) {
+	guid, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("webhook id is invalid: %v", err), http.StatusBadRequest)
+		return types.ZeroID, false
+	}
+
+	return types.ID{UUID: guid}, true
+}
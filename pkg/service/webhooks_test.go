@@ -0,0 +1,194 @@
+package service_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ec-systems/core.ledger.server/pkg/service"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+type receiver struct {
+	*httptest.Server
+
+	lock   sync.Mutex
+	events []*service.WebhookEvent
+	fail   int32
+	secret string
+	errors []string
+}
+
+func newReceiver(secret string) *receiver {
+	r := &receiver{
+		secret: secret,
+	}
+
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if atomic.LoadInt32(&r.fail) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, _ := io.ReadAll(req.Body)
+
+		r.lock.Lock()
+		defer r.lock.Unlock()
+
+		if req.Header.Get(service.SignatureHeader) != service.Sign(r.secret, body) {
+			r.errors = append(r.errors, "invalid signature")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		e := &service.WebhookEvent{}
+		err := json.Unmarshal(body, e)
+		if err != nil {
+			r.errors = append(r.errors, err.Error())
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		r.events = append(r.events, e)
+	}))
+
+	return r
+}
+
+func (r *receiver) received() []*service.WebhookEvent {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return append([]*service.WebhookEvent{}, r.events...)
+}
+
+func Test_Webhooks(t *testing.T) {
+	holder := randomName()
+	asset := randomAsset()
+
+	recv := newReceiver("secret")
+	defer recv.Close()
+
+	resp, err := post(&service.WebhookRequest{URL: "ftp://invalid"}, "/webhooks")
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+
+	resp, err = post(&service.WebhookRequest{URL: recv.URL, Holder: holder, Secret: "secret"}, "/webhooks")
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	var hook service.Webhook
+	err = json.NewDecoder(resp.Body).Decode(&hook)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "secret", hook.Secret)
+	assert.Equal(t, holder, hook.Holder)
+
+	amount, _ := decimal.NewFromString("1.5")
+
+	resp, err = put("/accounts/%v/%v/%v", holder, asset, amount)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	resp, err = put("/accounts/%v/%v/%v", randomName(), asset, amount)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	assert.Eventually(t, func() bool { return len(recv.received()) == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	events := recv.received()
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, hook.ID, events[0].Webhook)
+		assert.Equal(t, holder, events[0].Transaction.Holder)
+		assert.Equal(t, amount.String(), events[0].Transaction.Amount.String())
+	}
+
+	// the position moves forward and the secret isn't listed
+	var current service.Webhook
+	assert.Eventually(t, func() bool {
+		resp, err := get("/webhooks/%v", hook.ID)
+		if err != nil || resp.StatusCode != http.StatusOK {
+			return false
+		}
+
+		err = json.NewDecoder(resp.Body).Decode(&current)
+		return err == nil && current.Position == events[0].Tx
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Empty(t, current.Secret)
+
+	// failed deliveries are retried and recorded
+	atomic.StoreInt32(&recv.fail, 1)
+
+	resp, err = put("/accounts/%v/%v/%v", holder, asset, amount)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	var failures []*service.Delivery
+	assert.Eventually(t, func() bool {
+		resp, err := get("/webhooks/%v/failures", hook.ID)
+		if err != nil || resp.StatusCode != http.StatusOK {
+			return false
+		}
+
+		err = json.NewDecoder(resp.Body).Decode(&failures)
+		return err == nil && len(failures) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	if assert.Len(t, failures, 2) {
+		assert.Equal(t, 2, failures[0].Attempt)
+		assert.Equal(t, 1, failures[1].Attempt)
+		assert.Equal(t, http.StatusServiceUnavailable, failures[0].Code)
+		assert.Equal(t, "Failed", failures[0].Status)
+	}
+
+	// a replay delivers all matching transactions again
+	atomic.StoreInt32(&recv.fail, 0)
+
+	resp, err = post(nil, "/webhooks/%v/replay?from=%v", hook.ID, events[0].Tx-1)
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		return
+	}
+
+	assert.Eventually(t, func() bool { return len(recv.received()) == 3 }, 5*time.Second, 10*time.Millisecond)
+
+	resp, err = get("/webhooks/%v/deliveries?limit=2", hook.ID)
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		var deliveries []*service.Delivery
+		err = json.NewDecoder(resp.Body).Decode(&deliveries)
+		if assert.NoError(t, err) && assert.Len(t, deliveries, 2) {
+			assert.Equal(t, "Delivered", deliveries[0].Status)
+		}
+	}
+
+	recv.lock.Lock()
+	assert.Empty(t, recv.errors)
+	recv.lock.Unlock()
+
+	resp, err = get("/webhooks")
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, resp.StatusCode) {
+		var hooks []*service.Webhook
+		err = json.NewDecoder(resp.Body).Decode(&hooks)
+		if assert.NoError(t, err) {
+			ids := []string{}
+			for _, h := range hooks {
+				ids = append(ids, h.ID.String())
+			}
+
+			assert.Contains(t, ids, hook.ID.String())
+		}
+	}
+}
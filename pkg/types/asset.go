@@ -3,8 +3,15 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
 )
 
 const (
@@ -18,7 +25,113 @@ const (
 	USDCoin   Asset = "USDC"
 )
 
-type Assets map[Asset]string
+type Assets map[Asset]AssetDefinition
+
+// AssetDefinition limits the amounts of the transactions of an asset, the
+// precision isn't limited without decimals and zero min or max amounts
+// aren't checked
+type AssetDefinition struct {
+	Name     string          `json:"Name" yaml:"name"`
+	Decimals *int32          `json:"Decimals,omitempty" yaml:"decimals,omitempty"`
+	Min      decimal.Decimal `json:"Min" yaml:"min"`
+	Max      decimal.Decimal `json:"Max" yaml:"max"`
+	Enabled  bool            `json:"Enabled" yaml:"enabled"`
+}
+
+// NewAssetDefinition returns an enabled asset without amount limits
+func NewAssetDefinition(name string) AssetDefinition {
+	return AssetDefinition{
+		Name:    name,
+		Enabled: true,
+	}
+}
+
+// Decimals is a helper for the decimals of a definition
+func Decimals(decimals int32) *int32 {
+	return &decimals
+}
+
+// MarshalJSON writes only the name of assets without limits like in the
+// configuration
+func (d AssetDefinition) MarshalJSON() ([]byte, error) {
+	if d.plain() {
+		return json.Marshal(d.Name)
+	}
+
+	type definition AssetDefinition
+	return json.Marshal(definition(d))
+}
+
+func (d *AssetDefinition) UnmarshalJSON(data []byte) error {
+	var name string
+	if json.Unmarshal(data, &name) == nil {
+		*d = NewAssetDefinition(name)
+		return nil
+	}
+
+	type definition AssetDefinition
+	tmp := definition(NewAssetDefinition(""))
+
+	err := json.Unmarshal(data, &tmp)
+	if err != nil {
+		return err
+	}
+
+	*d = AssetDefinition(tmp)
+	return nil
+}
+
+func (d AssetDefinition) MarshalTOML() ([]byte, error) {
+	if d.plain() {
+		return []byte(strconv.Quote(d.Name)), nil
+	}
+
+	fields := []string{"Name = " + strconv.Quote(d.Name)}
+
+	if d.Decimals != nil {
+		fields = append(fields, fmt.Sprintf("Decimals = %v", *d.Decimals))
+	}
+
+	if !d.Min.IsZero() {
+		fields = append(fields, "Min = "+strconv.Quote(d.Min.String()))
+	}
+
+	if !d.Max.IsZero() {
+		fields = append(fields, "Max = "+strconv.Quote(d.Max.String()))
+	}
+
+	fields = append(fields, fmt.Sprintf("Enabled = %v", d.Enabled))
+
+	return []byte("{ " + strings.Join(fields, ", ") + " }"), nil
+}
+
+func (d AssetDefinition) plain() bool {
+	return d.Enabled && d.Decimals == nil && d.Min.IsZero() && d.Max.IsZero()
+}
+
+// Validate checks the absolute amount of a transaction against the
+// definition
+func (d AssetDefinition) Validate(amount decimal.Decimal) error {
+	amount = amount.Abs()
+
+	if !d.Enabled {
+		return fmt.Errorf("asset is disabled")
+	}
+
+	if d.Decimals != nil && !amount.Equal(amount.Truncate(*d.Decimals)) {
+		return fmt.Errorf("amount %v has more than %v decimals", amount, *d.Decimals)
+	}
+
+	if d.Min.IsPositive() && amount.LessThan(d.Min) {
+		return fmt.Errorf("amount %v is less than the minimum of %v", amount, d.Min)
+	}
+
+	if d.Max.IsPositive() && amount.GreaterThan(d.Max) {
+		return fmt.Errorf("amount %v is more than the maximum of %v", amount, d.Max)
+	}
+
+	return nil
+}
 
 func (a Assets) Parse(txt string) (Asset, error) {
 	if txt == "" {
@@ -74,9 +187,9 @@ func (a Assets) MarshalYAML() (interface{}, error) {
 type Asset string
 
 func (a Assets) Name(asset Asset) string {
-	name, ok := a[asset]
+	definition, ok := a[asset]
 	if ok {
-		return name
+		return definition.Name
 	}
 
 	return "Unknown"
@@ -90,3 +203,63 @@ func (c Asset) Check(a Assets) bool {
 	_, ok := a[c]
 	return ok
 }
+
+// AssetHookFunc decodes the configured assets, an asset is either only the
+// name or a map with the fields of the definition
+func AssetHookFunc() mapstructure.DecodeHookFuncType {
+	return func(
+		f reflect.Type,
+		t reflect.Type,
+		data interface{},
+	) (interface{}, error) {
+		if t != reflect.TypeOf(AssetDefinition{}) {
+			return data, nil
+		}
+
+		switch f.Kind() {
+		case reflect.String:
+			return NewAssetDefinition(data.(string)), nil
+		case reflect.Map:
+			definition := NewAssetDefinition("")
+
+			iter := reflect.ValueOf(data).MapRange()
+			for iter.Next() {
+				err := definition.set(fmt.Sprint(iter.Key().Interface()), fmt.Sprint(iter.Value().Interface()))
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			return definition, nil
+		}
+
+		return data, nil
+	}
+}
+
+func (d *AssetDefinition) set(key string, value string) error {
+	var err error
+
+	switch strings.ToLower(key) {
+	case "name":
+		d.Name = value
+	case "decimals":
+		var decimals int64
+		decimals, err = strconv.ParseInt(value, 10, 32)
+		d.Decimals = Decimals(int32(decimals))
+	case "min":
+		d.Min, err = decimal.NewFromString(value)
+	case "max":
+		d.Max, err = decimal.NewFromString(value)
+	case "enabled":
+		d.Enabled, err = strconv.ParseBool(value)
+	default:
+		return fmt.Errorf("unknown asset field '%v'", key)
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid asset %v '%v': %v", key, value, err)
+	}
+
+	return nil
+}
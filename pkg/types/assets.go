@@ -2,169 +2,169 @@
 package types
 
 var DefaultAssetMap = Assets{
-	Asset("1INCH"): "1inch Exchange",
-	Asset("AAVE"): "Aave",
-	Asset("ADA"): "Cardano",
-	Asset("AGI"): "SingularityNET",
-	Asset("AION"): "Aion",
-	Asset("ALGO"): "Algorand",
-	Asset("ANKR"): "Ankr",
-	Asset("APE"): "ApeCoin",
-	Asset("AR"): "Arweave",
-	Asset("ARK"): "Ark",
-	Asset("ATOM"): "Cosmos",
-	Asset("AUDIO"): "Audius",
-	Asset("AVAX"): "Avalanche",
-	Asset("AXS"): "Axie Infinity",
-	Asset("BAND"): "BandChain",
-	Asset("BAT"): "Basic Attention Token",
-	Asset("BCH"): "Bitcoin Cash",
-	Asset("BEAM"): "Beam",
-	Asset("BNB"): "Binance Coin",
-	Asset("BSV"): "Bitcoin SV",
-	Asset("BTC"): "Bitcoin",
-	Asset("BTG"): "Bitcoin Gold",
-	Asset("BTT"): "BitTorrent",
-	Asset("BUSD"): "Binance USD",
-	Asset("CAKE"): "PancakeSwap",
-	Asset("CELO"): "Celo",
-	Asset("CENNZ"): "Centrality",
-	Asset("CHZ"): "Chiliz",
-	Asset("CKB"): "Nervos Network",
-	Asset("COMP"): "Compound",
-	Asset("COTI"): "COTI",
-	Asset("CRO"): "Crypto.com CRO",
-	Asset("DAI"): "Dai",
-	Asset("DASH"): "Dash",
-	Asset("DATA"): "Streamr DATAcoin",
-	Asset("DCR"): "Decred",
-	Asset("DENT"): "Dent",
-	Asset("DFI"): "DeFiChain",
-	Asset("DGB"): "DigiByte",
-	Asset("DGD"): "DigixDAO",
-	Asset("DOGE"): "Dogecoin",
-	Asset("DOT"): "Polkadot",
-	Asset("DRGN"): "Dragonchain",
-	Asset("EGLD"): "Elrond",
-	Asset("ENJ"): "Enjin",
-	Asset("EOS"): "EOS",
-	Asset("ERG"): "Ergo",
-	Asset("ETC"): "Ethereum Classic",
-	Asset("ETH"): "Ethereum",
-	Asset("ETN"): "Electroneum",
-	Asset("FCT"): "Factom",
-	Asset("FET"): "Fetch.AI",
-	Asset("FIL"): "Filecoin",
-	Asset("FRONT"): "Frontier",
-	Asset("FTM"): "Fantom",
-	Asset("FTT"): "FTX Token",
-	Asset("FUN"): "FunFair",
-	Asset("GALA"): "Gala",
-	Asset("GAS"): "NEO gas",
-	Asset("GMT"): "STEPN",
-	Asset("GNT"): "Golem",
-	Asset("GRT"): "The Graph",
-	Asset("GVT"): "Genesis Vision",
-	Asset("HBAR"): "Hedera Hashgraph",
-	Asset("HIVE"): "Hive",
-	Asset("HNT"): "Helium",
-	Asset("HOT"): "Holo",
-	Asset("ICX"): "Icon",
-	Asset("ILV"): "Illuvium",
-	Asset("INJ"): "Injective Protocol",
-	Asset("IOTA"): "IOTA",
-	Asset("KAVA"): "Kava",
-	Asset("KCS"): "KuCoin Shares",
-	Asset("KDA"): "Kadena",
-	Asset("KMD"): "Komodo",
-	Asset("KNC"): "Kyber Network",
-	Asset("KSM"): "Kusama",
-	Asset("LINK"): "Chainlink",
-	Asset("LRC"): "Loopring",
-	Asset("LSK"): "Lisk",
-	Asset("LTC"): "Litecoin",
-	Asset("MANA"): "Decentraland",
-	Asset("MATIC"): "Polygon",
-	Asset("MKR"): "Maker",
-	Asset("NAV"): "NavCoin",
-	Asset("NCASH"): "Nucleus",
-	Asset("NEAR"): "Near Protocol",
-	Asset("NEO"): "NEO",
-	Asset("NIM"): "Nimiq",
-	Asset("NMR"): "Numeraire",
-	Asset("NPXS"): "Pundi X",
-	Asset("NXS"): "Nexus",
-	Asset("OCEAN"): "Ocean Protocol",
-	Asset("OGN"): "Origin Protocol",
-	Asset("OMG"): "OmiseGo",
-	Asset("ONE"): "Harmony",
-	Asset("ONG"): "Ontology Gas",
-	Asset("ONT"): "Ontology",
-	Asset("ORN"): "Orion Protocol",
-	Asset("OXT"): "Orchid",
-	Asset("PART"): "Particl",
-	Asset("PAXG"): "PAX Gold",
-	Asset("POE"): "Po.et",
-	Asset("POLY"): "Polymath",
-	Asset("POWR"): "Power Ledger",
-	Asset("QNT"): "Quant Network",
-	Asset("QTUM"): "QTUM",
-	Asset("RCN"): "Ripio Credit",
-	Asset("REEF"): "Reef",
-	Asset("REN"): "Ren",
-	Asset("REP"): "Augur",
-	Asset("REQ"): "Request",
-	Asset("RLC"): "iExec RLC",
-	Asset("RNDR"): "Render",
-	Asset("ROSE"): "Oasis",
-	Asset("RSR"): "Reserve Rights",
-	Asset("RUNE"): "THORChain",
-	Asset("RVN"): "Ravencoin",
-	Asset("SAND"): "The Sandbox",
-	Asset("SC"): "Siacoin",
-	Asset("SHIB"): "Shiba Inu",
-	Asset("SLP"): "Smooth Love Potion",
-	Asset("SNT"): "Status",
-	Asset("SNX"): "Synthetix",
-	Asset("SOL"): "Solana",
-	Asset("STEEM"): "Steem",
-	Asset("STMX"): "StormX",
-	Asset("STX"): "Stacks",
-	Asset("SUSHI"): "SushiSwap",
-	Asset("SXP"): "Swipe",
-	Asset("TFUEL"): "Theta Fuel",
-	Asset("THETA"): "Theta Token",
-	Asset("TNT"): "Tierion",
-	Asset("TRAC"): "OriginTrail",
-	Asset("TRX"): "TRON",
-	Asset("TUSD"): "TrueUSD",
-	Asset("TWT"): "Trust Wallet Token",
-	Asset("UNI"): "Uniswap",
-	Asset("USDC"): "USD Coin",
-	Asset("USDT"): "Tether",
-	Asset("UTK"): "Utrust",
-	Asset("VET"): "VeChain",
-	Asset("VGX"): "Voyager",
-	Asset("VIA"): "Viacoin",
-	Asset("WABI"): "Tael",
-	Asset("WAN"): "Wanchain",
-	Asset("WAVES"): "Waves",
-	Asset("WAXP"): "WAX",
-	Asset("WILD"): "Wilder World",
-	Asset("WTC"): "Waltonchain",
-	Asset("XEM"): "NEM",
-	Asset("XLM"): "Stellar",
-	Asset("XMR"): "Monero",
-	Asset("XNO"): "Nano",
-	Asset("XPR"): "Proton Chain",
-	Asset("XRP"): "XRP",
-	Asset("XTZ"): "Tezos",
-	Asset("XVG"): "Verge",
-	Asset("XZC"): "Firo",
-	Asset("YFI"): "Yearn.Finance",
-	Asset("YGG"): "Yield Guild Games",
-	Asset("ZEC"): "ZCash",
-	Asset("ZIL"): "Zilliqa",
-	Asset("ZRX"): "0x",
+	Asset("1INCH"): {Name: "1inch Exchange", Enabled: true},
+	Asset("AAVE"): {Name: "Aave", Enabled: true},
+	Asset("ADA"): {Name: "Cardano", Enabled: true},
+	Asset("AGI"): {Name: "SingularityNET", Enabled: true},
+	Asset("AION"): {Name: "Aion", Enabled: true},
+	Asset("ALGO"): {Name: "Algorand", Enabled: true},
+	Asset("ANKR"): {Name: "Ankr", Enabled: true},
+	Asset("APE"): {Name: "ApeCoin", Enabled: true},
+	Asset("AR"): {Name: "Arweave", Enabled: true},
+	Asset("ARK"): {Name: "Ark", Enabled: true},
+	Asset("ATOM"): {Name: "Cosmos", Enabled: true},
+	Asset("AUDIO"): {Name: "Audius", Enabled: true},
+	Asset("AVAX"): {Name: "Avalanche", Enabled: true},
+	Asset("AXS"): {Name: "Axie Infinity", Enabled: true},
+	Asset("BAND"): {Name: "BandChain", Enabled: true},
+	Asset("BAT"): {Name: "Basic Attention Token", Enabled: true},
+	Asset("BCH"): {Name: "Bitcoin Cash", Enabled: true},
+	Asset("BEAM"): {Name: "Beam", Enabled: true},
+	Asset("BNB"): {Name: "Binance Coin", Enabled: true},
+	Asset("BSV"): {Name: "Bitcoin SV", Enabled: true},
+	Asset("BTC"): {Name: "Bitcoin", Enabled: true},
+	Asset("BTG"): {Name: "Bitcoin Gold", Enabled: true},
+	Asset("BTT"): {Name: "BitTorrent", Enabled: true},
+	Asset("BUSD"): {Name: "Binance USD", Enabled: true},
+	Asset("CAKE"): {Name: "PancakeSwap", Enabled: true},
+	Asset("CELO"): {Name: "Celo", Enabled: true},
+	Asset("CENNZ"): {Name: "Centrality", Enabled: true},
+	Asset("CHZ"): {Name: "Chiliz", Enabled: true},
+	Asset("CKB"): {Name: "Nervos Network", Enabled: true},
+	Asset("COMP"): {Name: "Compound", Enabled: true},
+	Asset("COTI"): {Name: "COTI", Enabled: true},
+	Asset("CRO"): {Name: "Crypto.com CRO", Enabled: true},
+	Asset("DAI"): {Name: "Dai", Enabled: true},
+	Asset("DASH"): {Name: "Dash", Enabled: true},
+	Asset("DATA"): {Name: "Streamr DATAcoin", Enabled: true},
+	Asset("DCR"): {Name: "Decred", Enabled: true},
+	Asset("DENT"): {Name: "Dent", Enabled: true},
+	Asset("DFI"): {Name: "DeFiChain", Enabled: true},
+	Asset("DGB"): {Name: "DigiByte", Enabled: true},
+	Asset("DGD"): {Name: "DigixDAO", Enabled: true},
+	Asset("DOGE"): {Name: "Dogecoin", Enabled: true},
+	Asset("DOT"): {Name: "Polkadot", Enabled: true},
+	Asset("DRGN"): {Name: "Dragonchain", Enabled: true},
+	Asset("EGLD"): {Name: "Elrond", Enabled: true},
+	Asset("ENJ"): {Name: "Enjin", Enabled: true},
+	Asset("EOS"): {Name: "EOS", Enabled: true},
+	Asset("ERG"): {Name: "Ergo", Enabled: true},
+	Asset("ETC"): {Name: "Ethereum Classic", Enabled: true},
+	Asset("ETH"): {Name: "Ethereum", Enabled: true},
+	Asset("ETN"): {Name: "Electroneum", Enabled: true},
+	Asset("FCT"): {Name: "Factom", Enabled: true},
+	Asset("FET"): {Name: "Fetch.AI", Enabled: true},
+	Asset("FIL"): {Name: "Filecoin", Enabled: true},
+	Asset("FRONT"): {Name: "Frontier", Enabled: true},
+	Asset("FTM"): {Name: "Fantom", Enabled: true},
+	Asset("FTT"): {Name: "FTX Token", Enabled: true},
+	Asset("FUN"): {Name: "FunFair", Enabled: true},
+	Asset("GALA"): {Name: "Gala", Enabled: true},
+	Asset("GAS"): {Name: "NEO gas", Enabled: true},
+	Asset("GMT"): {Name: "STEPN", Enabled: true},
+	Asset("GNT"): {Name: "Golem", Enabled: true},
+	Asset("GRT"): {Name: "The Graph", Enabled: true},
+	Asset("GVT"): {Name: "Genesis Vision", Enabled: true},
+	Asset("HBAR"): {Name: "Hedera Hashgraph", Enabled: true},
+	Asset("HIVE"): {Name: "Hive", Enabled: true},
+	Asset("HNT"): {Name: "Helium", Enabled: true},
+	Asset("HOT"): {Name: "Holo", Enabled: true},
+	Asset("ICX"): {Name: "Icon", Enabled: true},
+	Asset("ILV"): {Name: "Illuvium", Enabled: true},
+	Asset("INJ"): {Name: "Injective Protocol", Enabled: true},
+	Asset("IOTA"): {Name: "IOTA", Enabled: true},
+	Asset("KAVA"): {Name: "Kava", Enabled: true},
+	Asset("KCS"): {Name: "KuCoin Shares", Enabled: true},
+	Asset("KDA"): {Name: "Kadena", Enabled: true},
+	Asset("KMD"): {Name: "Komodo", Enabled: true},
+	Asset("KNC"): {Name: "Kyber Network", Enabled: true},
+	Asset("KSM"): {Name: "Kusama", Enabled: true},
+	Asset("LINK"): {Name: "Chainlink", Enabled: true},
+	Asset("LRC"): {Name: "Loopring", Enabled: true},
+	Asset("LSK"): {Name: "Lisk", Enabled: true},
+	Asset("LTC"): {Name: "Litecoin", Enabled: true},
+	Asset("MANA"): {Name: "Decentraland", Enabled: true},
+	Asset("MATIC"): {Name: "Polygon", Enabled: true},
+	Asset("MKR"): {Name: "Maker", Enabled: true},
+	Asset("NAV"): {Name: "NavCoin", Enabled: true},
+	Asset("NCASH"): {Name: "Nucleus", Enabled: true},
+	Asset("NEAR"): {Name: "Near Protocol", Enabled: true},
+	Asset("NEO"): {Name: "NEO", Enabled: true},
+	Asset("NIM"): {Name: "Nimiq", Enabled: true},
+	Asset("NMR"): {Name: "Numeraire", Enabled: true},
+	Asset("NPXS"): {Name: "Pundi X", Enabled: true},
+	Asset("NXS"): {Name: "Nexus", Enabled: true},
+	Asset("OCEAN"): {Name: "Ocean Protocol", Enabled: true},
+	Asset("OGN"): {Name: "Origin Protocol", Enabled: true},
+	Asset("OMG"): {Name: "OmiseGo", Enabled: true},
+	Asset("ONE"): {Name: "Harmony", Enabled: true},
+	Asset("ONG"): {Name: "Ontology Gas", Enabled: true},
+	Asset("ONT"): {Name: "Ontology", Enabled: true},
+	Asset("ORN"): {Name: "Orion Protocol", Enabled: true},
+	Asset("OXT"): {Name: "Orchid", Enabled: true},
+	Asset("PART"): {Name: "Particl", Enabled: true},
+	Asset("PAXG"): {Name: "PAX Gold", Enabled: true},
+	Asset("POE"): {Name: "Po.et", Enabled: true},
+	Asset("POLY"): {Name: "Polymath", Enabled: true},
+	Asset("POWR"): {Name: "Power Ledger", Enabled: true},
+	Asset("QNT"): {Name: "Quant Network", Enabled: true},
+	Asset("QTUM"): {Name: "QTUM", Enabled: true},
+	Asset("RCN"): {Name: "Ripio Credit", Enabled: true},
+	Asset("REEF"): {Name: "Reef", Enabled: true},
+	Asset("REN"): {Name: "Ren", Enabled: true},
+	Asset("REP"): {Name: "Augur", Enabled: true},
+	Asset("REQ"): {Name: "Request", Enabled: true},
+	Asset("RLC"): {Name: "iExec RLC", Enabled: true},
+	Asset("RNDR"): {Name: "Render", Enabled: true},
+	Asset("ROSE"): {Name: "Oasis", Enabled: true},
+	Asset("RSR"): {Name: "Reserve Rights", Enabled: true},
+	Asset("RUNE"): {Name: "THORChain", Enabled: true},
+	Asset("RVN"): {Name: "Ravencoin", Enabled: true},
+	Asset("SAND"): {Name: "The Sandbox", Enabled: true},
+	Asset("SC"): {Name: "Siacoin", Enabled: true},
+	Asset("SHIB"): {Name: "Shiba Inu", Enabled: true},
+	Asset("SLP"): {Name: "Smooth Love Potion", Enabled: true},
+	Asset("SNT"): {Name: "Status", Enabled: true},
+	Asset("SNX"): {Name: "Synthetix", Enabled: true},
+	Asset("SOL"): {Name: "Solana", Enabled: true},
+	Asset("STEEM"): {Name: "Steem", Enabled: true},
+	Asset("STMX"): {Name: "StormX", Enabled: true},
+	Asset("STX"): {Name: "Stacks", Enabled: true},
+	Asset("SUSHI"): {Name: "SushiSwap", Enabled: true},
+	Asset("SXP"): {Name: "Swipe", Enabled: true},
+	Asset("TFUEL"): {Name: "Theta Fuel", Enabled: true},
+	Asset("THETA"): {Name: "Theta Token", Enabled: true},
+	Asset("TNT"): {Name: "Tierion", Enabled: true},
+	Asset("TRAC"): {Name: "OriginTrail", Enabled: true},
+	Asset("TRX"): {Name: "TRON", Enabled: true},
+	Asset("TUSD"): {Name: "TrueUSD", Enabled: true},
+	Asset("TWT"): {Name: "Trust Wallet Token", Enabled: true},
+	Asset("UNI"): {Name: "Uniswap", Enabled: true},
+	Asset("USDC"): {Name: "USD Coin", Enabled: true},
+	Asset("USDT"): {Name: "Tether", Enabled: true},
+	Asset("UTK"): {Name: "Utrust", Enabled: true},
+	Asset("VET"): {Name: "VeChain", Enabled: true},
+	Asset("VGX"): {Name: "Voyager", Enabled: true},
+	Asset("VIA"): {Name: "Viacoin", Enabled: true},
+	Asset("WABI"): {Name: "Tael", Enabled: true},
+	Asset("WAN"): {Name: "Wanchain", Enabled: true},
+	Asset("WAVES"): {Name: "Waves", Enabled: true},
+	Asset("WAXP"): {Name: "WAX", Enabled: true},
+	Asset("WILD"): {Name: "Wilder World", Enabled: true},
+	Asset("WTC"): {Name: "Waltonchain", Enabled: true},
+	Asset("XEM"): {Name: "NEM", Enabled: true},
+	Asset("XLM"): {Name: "Stellar", Enabled: true},
+	Asset("XMR"): {Name: "Monero", Enabled: true},
+	Asset("XNO"): {Name: "Nano", Enabled: true},
+	Asset("XPR"): {Name: "Proton Chain", Enabled: true},
+	Asset("XRP"): {Name: "XRP", Enabled: true},
+	Asset("XTZ"): {Name: "Tezos", Enabled: true},
+	Asset("XVG"): {Name: "Verge", Enabled: true},
+	Asset("XZC"): {Name: "Firo", Enabled: true},
+	Asset("YFI"): {Name: "Yearn.Finance", Enabled: true},
+	Asset("YGG"): {Name: "Yield Guild Games", Enabled: true},
+	Asset("ZEC"): {Name: "ZCash", Enabled: true},
+	Asset("ZIL"): {Name: "Zilliqa", Enabled: true},
+	Asset("ZRX"): {Name: "0x", Enabled: true},
 }
 
@@ -3,34 +3,179 @@ package types
 import "github.com/shopspring/decimal"
 
 type AccountBalance struct {
-	Count uint
-	Sum   decimal.Decimal
+	Count     uint
+	Sum       decimal.Decimal
+	Available decimal.Decimal
+	Held      decimal.Decimal
+	Statuses  map[Status]decimal.Decimal
 }
 
 type Balance struct {
-	Sum      decimal.Decimal
+	AccountBalance
 	Accounts map[Account]*AccountBalance
-	Count    uint
+
+	status Status
 }
 
 func NewBalance(status Status) *Balance {
 	return &Balance{
-		Sum:      decimal.Zero,
-		Count:    0,
-		Accounts: map[Account]*AccountBalance{},
+		AccountBalance: *NewAccountBalance(),
+		Accounts:       map[Account]*AccountBalance{},
+		status:         status,
 	}
 }
 
 func NewAccountBalance() *AccountBalance {
 	return &AccountBalance{
-		Count: 0,
-		Sum:   decimal.Zero,
+		Count:     0,
+		Sum:       decimal.Zero,
+		Available: decimal.Zero,
+		Held:      decimal.Zero,
+		Statuses:  map[Status]decimal.Decimal{},
+	}
+}
+
+// Spendable reports if an amount with the given status counts to the
+// available balance, these are the finished credits and all debits. The
+// credits of a canceled transaction count as well, they are offset by the
+// finished debit of the cancel
+func Spendable(amount decimal.Decimal, status Status) bool {
+	if !amount.IsPositive() {
+		return true
+	}
+
+	switch status {
+	case Finished, Canceled, CancellationFinished:
+		return true
+	default:
+		return false
+	}
+}
+
+func (a *AccountBalance) Add(amount decimal.Decimal, status Status) {
+	a.Sum = a.Sum.Add(amount)
+	a.Count++
+
+	if Spendable(amount, status) {
+		a.Available = a.Available.Add(amount)
+	}
+
+	a.add(amount, status)
+}
+
+// Move moves an amount from one status to another
+func (a *AccountBalance) Move(amount decimal.Decimal, from Status, to Status) {
+	if from == to {
+		return
+	}
+
+	if Spendable(amount, from) {
+		a.Available = a.Available.Sub(amount)
+	}
+
+	if Spendable(amount, to) {
+		a.Available = a.Available.Add(amount)
+	}
+
+	a.add(amount.Neg(), from)
+	a.add(amount, to)
+}
+
+// Hold reserves an amount, it is no longer available but still part of the sum
+func (a *AccountBalance) Hold(amount decimal.Decimal) {
+	a.Held = a.Held.Add(amount)
+	a.Available = a.Available.Sub(amount)
+}
+
+// Release returns a reserved amount to the available balance
+func (a *AccountBalance) Release(amount decimal.Decimal) {
+	a.Held = a.Held.Sub(amount)
+	a.Available = a.Available.Add(amount)
+}
+
+func (a *AccountBalance) Merge(o *AccountBalance) {
+	a.Sum = a.Sum.Add(o.Sum)
+	a.Count += o.Count
+	a.Available = a.Available.Add(o.Available)
+	a.Held = a.Held.Add(o.Held)
+
+	for status, sum := range o.Statuses {
+		a.add(sum, status)
+	}
+}
+
+func (a *AccountBalance) Equal(o *AccountBalance) bool {
+	if a.Count != o.Count || !a.Sum.Equal(o.Sum) || !a.Available.Equal(o.Available) || !a.Held.Equal(o.Held) {
+		return false
+	}
+
+	for status, sum := range a.Statuses {
+		if !sum.Equal(o.Status(status)) {
+			return false
+		}
+	}
+
+	for status, sum := range o.Statuses {
+		if !sum.Equal(a.Status(status)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (a *AccountBalance) Status(status Status) decimal.Decimal {
+	sum, ok := a.Statuses[status]
+	if !ok {
+		return decimal.Zero
+	}
+
+	return sum
+}
+
+func (a *AccountBalance) Pending() decimal.Decimal {
+	return a.Status(Created)
+}
+
+func (a *AccountBalance) Finished() decimal.Decimal {
+	return a.Status(Finished)
+}
+
+func (a *AccountBalance) Canceled() decimal.Decimal {
+	return a.Status(Canceled)
+}
+
+func (a *AccountBalance) add(amount decimal.Decimal, status Status) {
+	if a.Statuses == nil {
+		a.Statuses = map[Status]decimal.Decimal{}
+	}
+
+	sum := a.Status(status).Add(amount)
+	if sum.IsZero() {
+		delete(a.Statuses, status)
+	} else {
+		a.Statuses[status] = sum
 	}
 }
 
 func (b *Balance) Add(account Account, amount decimal.Decimal, status Status) {
-	b.Sum = b.Sum.Add(amount)
-	b.Count++
+	if b.status != AllStatuses && b.status != status {
+		return
+	}
+
+	b.AccountBalance.Add(amount, status)
+
+	acc, ok := b.Accounts[account]
+	if !ok {
+		acc = NewAccountBalance()
+		b.Accounts[account] = acc
+	}
+
+	acc.Add(amount, status)
+}
+
+func (b *Balance) AddAccount(account Account, balance *AccountBalance) {
+	b.AccountBalance.Merge(balance)
 
 	acc, ok := b.Accounts[account]
 	if !ok {
@@ -38,6 +183,5 @@ func (b *Balance) Add(account Account, amount decimal.Decimal, status Status) {
 		b.Accounts[account] = acc
 	}
 
-	acc.Sum = acc.Sum.Add(amount)
-	acc.Count++
+	acc.Merge(balance)
 }
@@ -2,6 +2,7 @@ package types
 
 import (
 	"encoding/json"
+	"strings"
 )
 
 type Reference string
@@ -28,3 +29,10 @@ func (r Reference) Content() interface{} {
 
 	return content
 }
+
+// Normalized is the reference used in the lookup index, case and a hex
+// prefix of hashes are ignored
+func (r Reference) Normalized() string {
+	ref := strings.ToLower(strings.TrimSpace(string(r)))
+	return strings.TrimPrefix(ref, "0x")
+}
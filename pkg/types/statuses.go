@@ -16,3 +16,10 @@ var DefaultStatusMap = Statuses{
 	"Canceled": Canceled,
 	"Finished": Finished,
 }
+
+var DefaultTransitions = Transitions{
+	"Created": {"Finished", "Canceled"},
+	"CancellationFinished": {},
+	"Canceled": {"CancellationFinished"},
+	"Finished": {"Canceled"},
+}
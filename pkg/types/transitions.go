@@ -0,0 +1,128 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// Transitions maps a status name to the names of the statuses it may change to.
+// A status with an empty list is terminal, a status without an entry is not
+// restricted at all.
+type Transitions map[string][]string
+
+func (t Transitions) Allowed(statuses Statuses, from Status, to Status) bool {
+	if len(t) == 0 || from == to {
+		return true
+	}
+
+	next, ok := t[from.String(statuses)]
+	if !ok {
+		return true
+	}
+
+	name := to.String(statuses)
+	for _, n := range next {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t Transitions) Terminal(statuses Statuses, status Status) bool {
+	next, ok := t[status.String(statuses)]
+	return ok && len(next) == 0
+}
+
+func (t Transitions) Next(statuses Statuses, status Status) []string {
+	return t[status.String(statuses)]
+}
+
+func (t Transitions) Validate(statuses Statuses) error {
+	for from, next := range t {
+		if _, ok := statuses[from]; !ok {
+			return fmt.Errorf("unknown status %v in transitions", from)
+		}
+
+		for _, to := range next {
+			if _, ok := statuses[to]; !ok {
+				return fmt.Errorf("unknown status %v in transitions of %v", to, from)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (t Transitions) String() string {
+	list := []string{}
+
+	for k, v := range t {
+		list = append(list, fmt.Sprintf("%v=%v", k, strings.Join(v, "|")))
+	}
+
+	sort.Strings(list)
+
+	return strings.Join(list, ",")
+}
+
+func (t *Transitions) Set(text string) error {
+	transitions := Transitions{}
+
+	for _, pair := range strings.Split(text, ",") {
+		kv := strings.Split(pair, "=")
+		if len(kv) != 2 {
+			continue
+		}
+
+		key := strings.Trim(kv[0], " ")
+		next := []string{}
+
+		for _, n := range strings.Split(kv[1], "|") {
+			n = strings.Trim(n, " ")
+			if n != "" {
+				next = append(next, n)
+			}
+		}
+
+		transitions[key] = next
+	}
+
+	*t = transitions
+
+	return nil
+}
+
+func (t Transitions) Type() string {
+	return "Transitions"
+}
+
+func TransitionsHookFunc() mapstructure.DecodeHookFuncType {
+	return func(
+		f reflect.Type,
+		t reflect.Type,
+		data interface{},
+	) (interface{}, error) {
+		if f.Kind() != reflect.String {
+			return data, nil
+		}
+
+		if t != reflect.TypeOf(DefaultTransitions) {
+			return data, nil
+		}
+
+		transitions := Transitions{}
+
+		err := transitions.Set(data.(string))
+		if err != nil {
+			return nil, err
+		}
+
+		return transitions, nil
+	}
+}